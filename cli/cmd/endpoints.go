@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/runconduit/conduit/controller/api/util"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	pb "github.com/runconduit/conduit/controller/gen/public"
+	"github.com/runconduit/conduit/pkg/addr"
+	"github.com/runconduit/conduit/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const defaultEndpointsPort = 80
+
+type endpointsOptions struct {
+	namespace string
+	watch     bool
+}
+
+func newEndpointsOptions() *endpointsOptions {
+	return &endpointsOptions{
+		namespace: "default",
+		watch:     false,
+	}
+}
+
+func newCmdEndpoints() *cobra.Command {
+	options := newEndpointsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "endpoints [flags] (RESOURCE)",
+		Short: "Display the endpoints that proxies are routing to",
+		Long: `Display the endpoints that proxies are routing to.
+
+  The RESOURCE argument specifies the destination to look up, in the same way
+  that proxies look it up with the Destination service:
+  (TYPE/NAME[:PORT])
+
+  Examples:
+  * svc/web:8080
+  * au/web.prod.svc.cluster.local:8080
+  * au/api.example.com:443
+
+  Valid resource types include:
+
+  * services
+  * authorities
+
+If PORT is omitted, 80 is used.`,
+		Example: `  # Get the endpoints of port 8080 of the web service in the prod namespace.
+  conduit endpoints svc/web:8080 -n prod
+
+  # Print changes to the endpoints of the web service as they happen.
+  conduit endpoints svc/web --watch`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildEndpointsRequest(args[0], options)
+			if err != nil {
+				return err
+			}
+
+			client, err := newPublicAPIClient()
+			if err != nil {
+				return err
+			}
+
+			return requestEndpointsFromAPI(os.Stdout, client, req, options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"Namespace of the specified service")
+	cmd.PersistentFlags().BoolVarP(&options.watch, "watch", "w", options.watch,
+		"After listing the endpoints, print changes to them as they happen")
+
+	return cmd
+}
+
+func buildEndpointsRequest(destination string, options *endpointsOptions) (*common.Destination, error) {
+	port := defaultEndpointsPort
+	resource := destination
+	if i := strings.LastIndex(destination, ":"); i > strings.LastIndex(destination, "/") {
+		var err error
+		port, err = strconv.Atoi(destination[i+1:])
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port [%s]", destination[i+1:])
+		}
+		resource = destination[:i]
+	}
+
+	target, err := util.BuildResource(options.namespace, resource)
+	if err != nil {
+		return nil, fmt.Errorf("target resource invalid: %s", err)
+	}
+	if target.Name == "" {
+		return nil, fmt.Errorf("a resource name is required, e.g. svc/web:8080")
+	}
+
+	var path string
+	switch target.Type {
+	case k8s.Services:
+		path = fmt.Sprintf("%s.%s.svc.cluster.local:%d", target.Name, target.Namespace, port)
+	case k8s.Authorities:
+		path = fmt.Sprintf("%s:%d", target.Name, port)
+	default:
+		return nil, fmt.Errorf("unsupported resource type [%s]", target.Type)
+	}
+
+	return &common.Destination{
+		Scheme: "k8s",
+		Path:   path,
+	}, nil
+}
+
+func requestEndpointsFromAPI(w io.Writer, client pb.ApiClient, req *common.Destination, options *endpointsOptions) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rsp, err := client.Endpoints(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if options.watch {
+		return renderEndpointUpdates(w, rsp)
+	}
+
+	// The first update holds all of the endpoints that are known when the
+	// lookup starts.
+	update, err := rsp.Recv()
+	if err == io.EOF {
+		return fmt.Errorf("no response for destination [%s]", req.Path)
+	}
+	if err != nil {
+		return err
+	}
+	return renderEndpoints(w, req, update)
+}
+
+func renderEndpoints(w io.Writer, req *common.Destination, update *destinationPb.Update) error {
+	if noEndpoints := update.GetNoEndpoints(); noEndpoints != nil {
+		if !noEndpoints.Exists {
+			return fmt.Errorf("destination [%s] does not exist", req.Path)
+		}
+		fmt.Fprintln(w, "No endpoints found.")
+		return nil
+	}
+
+	addrs := append([]*destinationPb.WeightedAddr{}, update.GetAdd().GetAddrs()...)
+	sort.Slice(addrs, func(i, j int) bool {
+		return addr.AddressToString(addrs[i].GetAddr()) < addr.AddressToString(addrs[j].GetAddr())
+	})
+
+	tableWriter := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tableWriter, strings.Join([]string{"ADDRESS", "WEIGHT", "POD", "TLS_IDENTITY", "LABELS"}, "\t"))
+	for _, weightedAddr := range addrs {
+		fmt.Fprintf(tableWriter, "%s\t%d\t%s\t%s\t%s\n",
+			addr.AddressToString(weightedAddr.GetAddr()),
+			weightedAddr.GetWeight(),
+			valueOrDash(weightedAddr.GetMetricLabels()["pod"]),
+			valueOrDash(tlsIdentity(weightedAddr)),
+			valueOrDash(renderEndpointLabels(weightedAddr.GetMetricLabels())),
+		)
+	}
+	return tableWriter.Flush()
+}
+
+func renderEndpointUpdates(w io.Writer, rsp pb.Api_EndpointsClient) error {
+	for {
+		log.Debug("Waiting for data...")
+		update, err := rsp.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, line := range renderEndpointUpdate(update) {
+			_, err = fmt.Fprintln(w, line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func renderEndpointUpdate(update *destinationPb.Update) []string {
+	lines := make([]string, 0)
+	switch u := update.GetUpdate().(type) {
+	case *destinationPb.Update_Add:
+		for _, weightedAddr := range u.Add.GetAddrs() {
+			line := fmt.Sprintf("add %s weight=%d",
+				addr.AddressToString(weightedAddr.GetAddr()),
+				weightedAddr.GetWeight(),
+			)
+			if identity := tlsIdentity(weightedAddr); identity != "" {
+				line += " tls_identity=" + identity
+			}
+			if labels := renderEndpointLabels(weightedAddr.GetMetricLabels()); labels != "" {
+				line += " " + strings.Replace(labels, ",", " ", -1)
+			}
+			lines = append(lines, line)
+		}
+	case *destinationPb.Update_Remove:
+		for _, tcpAddr := range u.Remove.GetAddrs() {
+			lines = append(lines, fmt.Sprintf("remove %s", addr.AddressToString(tcpAddr)))
+		}
+	case *destinationPb.Update_NoEndpoints:
+		lines = append(lines, fmt.Sprintf("no_endpoints exists=%t", u.NoEndpoints.GetExists()))
+	}
+	return lines
+}
+
+func tlsIdentity(weightedAddr *destinationPb.WeightedAddr) string {
+	return weightedAddr.GetTlsIdentity().GetK8SPodIdentity().GetPodIdentity()
+}
+
+// renderEndpointLabels renders metric labels as sorted "key=value" pairs,
+// separated by commas.
+func renderEndpointLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/runconduit/conduit/controller/api/public"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+)
+
+func TestBuildEndpointsRequest(t *testing.T) {
+	t.Run("Builds the destination path of services and authorities", func(t *testing.T) {
+		expectations := map[string]string{
+			"svc/web:8080":                       "web.prod.svc.cluster.local:8080",
+			"services/web":                       "web.prod.svc.cluster.local:80",
+			"au/web.other.svc.cluster.local:443": "web.other.svc.cluster.local:443",
+			"authority/api.example.com":          "api.example.com:80",
+		}
+
+		for arg, expectedPath := range expectations {
+			req, err := buildEndpointsRequest(arg, &endpointsOptions{namespace: "prod"})
+			if err != nil {
+				t.Fatalf("Unexpected error for [%s]: %v", arg, err)
+			}
+
+			if req.Scheme != "k8s" || req.Path != expectedPath {
+				t.Fatalf("Expected [%s] to be looked up as [k8s %s], got [%s %s]", arg, expectedPath, req.Scheme, req.Path)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid destinations", func(t *testing.T) {
+		for _, arg := range []string{"svc", "svc/web:http", "svc/web:0", "deploy/web:8080", "foo/web:8080"} {
+			_, err := buildEndpointsRequest(arg, &endpointsOptions{namespace: "prod"})
+			if err == nil {
+				t.Fatalf("Expected an error for [%s], got nothing", arg)
+			}
+		}
+	})
+}
+
+func TestRequestEndpointsFromAPI(t *testing.T) {
+	add := destinationPb.Update{
+		Update: &destinationPb.Update_Add{
+			Add: &destinationPb.WeightedAddrSet{
+				Addrs: []*destinationPb.WeightedAddr{
+					&destinationPb.WeightedAddr{
+						Addr:   &common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 167772162}}, Port: 8080},
+						Weight: 10000,
+						MetricLabels: map[string]string{
+							"deployment": "web",
+							"pod":        "web-5c8b9f5d4-x7q2k",
+						},
+						TlsIdentity: &destinationPb.TlsIdentity{
+							Strategy: &destinationPb.TlsIdentity_K8SPodIdentity_{
+								K8SPodIdentity: &destinationPb.TlsIdentity_K8SPodIdentity{
+									PodIdentity:  "web.deployment.prod.conduit-managed.conduit.svc.cluster.local",
+									ControllerNs: "conduit",
+								},
+							},
+						},
+					},
+					&destinationPb.WeightedAddr{
+						Addr:   &common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 167772161}}, Port: 8080},
+						Weight: 1,
+					},
+				},
+			},
+		},
+	}
+	remove := destinationPb.Update{
+		Update: &destinationPb.Update_Remove{
+			Remove: &destinationPb.AddrSet{
+				Addrs: []*common.TcpAddress{
+					&common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 167772161}}, Port: 8080},
+				},
+			},
+		},
+	}
+	noEndpoints := destinationPb.Update{
+		Update: &destinationPb.Update_NoEndpoints{
+			NoEndpoints: &destinationPb.NoEndpoints{Exists: true},
+		},
+	}
+
+	for _, tt := range []struct {
+		name       string
+		watch      bool
+		goldenFile string
+	}{
+		{"Should render the current endpoints", false, "endpoints_one_output.golden"},
+		{"Should render all updates when watching", true, "endpoints_watch_output.golden"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := buildEndpointsRequest("svc/web:8080", &endpointsOptions{namespace: "prod"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			mockApiClient := &public.MockConduitApiClient{}
+			mockApiClient.Api_EndpointsClientToReturn = &public.MockApi_EndpointsClient{
+				UpdatesToReturn: []destinationPb.Update{add, remove, noEndpoints},
+			}
+
+			writer := bytes.NewBufferString("")
+			err = requestEndpointsFromAPI(writer, mockApiClient, req, &endpointsOptions{watch: tt.watch})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			goldenFileBytes, err := ioutil.ReadFile("testdata/" + tt.goldenFile)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			diffCompare(t, writer.String(), string(goldenFileBytes))
+		})
+	}
+
+	t.Run("Should say so if the destination has no endpoints", func(t *testing.T) {
+		req, err := buildEndpointsRequest("svc/web:8080", &endpointsOptions{namespace: "prod"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		mockApiClient := &public.MockConduitApiClient{}
+		mockApiClient.Api_EndpointsClientToReturn = &public.MockApi_EndpointsClient{
+			UpdatesToReturn: []destinationPb.Update{noEndpoints},
+		}
+
+		writer := bytes.NewBufferString("")
+		err = requestEndpointsFromAPI(writer, mockApiClient, req, &endpointsOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		diffCompare(t, writer.String(), "No endpoints found.\n")
+	})
+
+	t.Run("Should return an error if the destination does not exist", func(t *testing.T) {
+		req, err := buildEndpointsRequest("svc/missing:8080", &endpointsOptions{namespace: "prod"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		mockApiClient := &public.MockConduitApiClient{}
+		mockApiClient.Api_EndpointsClientToReturn = &public.MockApi_EndpointsClient{
+			UpdatesToReturn: []destinationPb.Update{
+				destinationPb.Update{
+					Update: &destinationPb.Update_NoEndpoints{
+						NoEndpoints: &destinationPb.NoEndpoints{Exists: false},
+					},
+				},
+			},
+		}
+
+		err = requestEndpointsFromAPI(bytes.NewBufferString(""), mockApiClient, req, &endpointsOptions{})
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+}
@@ -30,6 +30,8 @@ const (
 	ControlPlanePodName = "controller"
 	// The name of the variable used to pass the pod's namespace.
 	PodNamespaceEnvVarName = "CONDUIT_PROXY_POD_NAMESPACE"
+	// The name of the variable used to pass the name of the pod's node.
+	NodeNameEnvVarName = "CONDUIT_PROXY_NODE_NAME"
 )
 
 type injectOptions struct {
@@ -222,6 +224,10 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 				Name:      PodNamespaceEnvVarName,
 				ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
 			},
+			{
+				Name:      NodeNameEnvVarName,
+				ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+			},
 		},
 	}
 
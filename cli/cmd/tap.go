@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 
 	"github.com/runconduit/conduit/controller/api/util"
 	common "github.com/runconduit/conduit/controller/gen/common"
@@ -13,30 +17,63 @@ import (
 	"github.com/runconduit/conduit/pkg/addr"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"google.golang.org/grpc/codes"
 )
 
 type tapOptions struct {
-	namespace   string
-	toResource  string
-	toNamespace string
-	maxRps      float32
-	scheme      string
-	method      string
-	authority   string
-	path        string
+	namespace     string
+	toResource    string
+	toNamespace   string
+	fromResource  string
+	fromNamespace string
+	maxRps        float32
+	scheme        string
+	method        string
+	authority     string
+	path          string
+	// header matches, each of the form NAME=VALUE
+	headers        []string
+	headerPrefixes []string
+	output         string
+	// how long the correlated output waits for the response of a request
+	flushTimeout time.Duration
+	// the file that the tapped events are recorded to, if any
+	record string
 }
 
+const (
+	// tapOutputDefault renders each event as a human-readable line.
+	tapOutputDefault = ""
+	// tapOutputJSON renders each event as a line of JSON.
+	tapOutputJSON = "json"
+	// tapOutputCorrelated renders each request, along with its response, as a
+	// human-readable line.
+	tapOutputCorrelated = "correlated"
+	// tapOutputHar renders the requests, along with their responses, as an
+	// HTTP Archive once the tap ends.
+	tapOutputHar = "har"
+)
+
+var tapOutputs = []string{tapOutputJSON, tapOutputCorrelated, tapOutputHar}
+
 func newTapOptions() *tapOptions {
 	return &tapOptions{
-		namespace:   "default",
-		toResource:  "",
-		toNamespace: "",
-		maxRps:      1.0,
-		scheme:      "",
-		method:      "",
-		authority:   "",
-		path:        "",
+		namespace:      "default",
+		toResource:     "",
+		toNamespace:    "",
+		fromResource:   "",
+		fromNamespace:  "",
+		maxRps:         1.0,
+		scheme:         "",
+		method:         "",
+		authority:      "",
+		path:           "",
+		headers:        []string{},
+		headerPrefixes: []string{},
+		output:         tapOutputDefault,
+		flushTimeout:   30 * time.Second,
+		record:         "",
 	}
 }
 
@@ -63,7 +100,7 @@ func newCmdTap() *cobra.Command {
   * namespaces
   * pods
   * replicationcontrollers
-  * services (only supported as a "--to" resource)`,
+  * services (only supported as a "--to" or "--from" resource)`,
 		Example: `  # tap the web deployment in the default namespace
   conduit tap deploy/web
 
@@ -71,10 +108,33 @@ func newCmdTap() *cobra.Command {
   conduit tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  conduit tap ns/test --to ns/prod`,
+  conduit tap ns/test --to ns/prod
+
+  # tap the web deployment, filter by requests from the vote-bot deployment
+  conduit tap deploy/web --from deploy/vote-bot
+
+  # tap the api deployment, filter by requests of a single tenant
+  conduit tap deploy/api --header x-tenant-id=42
+
+  # tap the web deployment, and print the events as JSON
+  conduit tap deploy/web -o json
+
+  # tap the web deployment, and print a line per request with its response
+  conduit tap deploy/web -o correlated
+
+  # tap the web deployment until interrupted, and save the requests as an HTTP Archive
+  conduit tap deploy/web -o har > web.har
+
+  # tap the web deployment, and record the events to render them later
+  conduit tap deploy/web --record web.tap`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			err := validateTapOutput(options.output)
+			if err != nil {
+				return err
+			}
+
 			req, err := buildTapByResourceRequest(args, options)
 			if err != nil {
 				return err
@@ -85,28 +145,57 @@ func newCmdTap() *cobra.Command {
 				return err
 			}
 
-			return requestTapByResourceFromAPI(os.Stdout, client, req)
+			return requestTapByResourceFromAPI(os.Stdout, client, req, options)
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+	addTapFilterFlags(cmd.PersistentFlags(), options)
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
+		"Output format; one of: \"json\", which prints one JSON object per event, \"correlated\", which prints one line per request, or \"har\", which prints an HTTP Archive of the requests when the tap ends")
+	cmd.PersistentFlags().DurationVar(&options.flushTimeout, "flush-timeout", options.flushTimeout,
+		"How long the \"correlated\" output waits for the response of a request, before printing the request without it")
+	cmd.PersistentFlags().StringVar(&options.record, "record", options.record,
+		"Also write the events to this file, to be rendered later by \"conduit tap replay\"")
+
+	cmd.AddCommand(newCmdTapReplay(options))
+
+	return cmd
+}
+
+// addTapFilterFlags adds the flags that select the requests to tap, which are
+// shared by the commands built on tap.
+func addTapFilterFlags(flags *pflag.FlagSet, options *tapOptions) {
+	flags.StringVarP(&options.namespace, "namespace", "n", options.namespace,
 		"Namespace of the specified resource")
-	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource,
+	flags.StringVar(&options.toResource, "to", options.toResource,
 		"Display requests to this resource")
-	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
+	flags.StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
 		"Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
-	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
+	flags.StringVar(&options.fromResource, "from", options.fromResource,
+		"Display requests from this resource")
+	flags.StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace,
+		"Sets the namespace used to lookup the \"--from\" resource; by default the current \"--namespace\" is used")
+	flags.Float32Var(&options.maxRps, "max-rps", options.maxRps,
 		"Maximum requests per second to tap.")
-	cmd.PersistentFlags().StringVar(&options.scheme, "scheme", options.scheme,
+	flags.StringVar(&options.scheme, "scheme", options.scheme,
 		"Display requests with this scheme")
-	cmd.PersistentFlags().StringVar(&options.method, "method", options.method,
+	flags.StringVar(&options.method, "method", options.method,
 		"Display requests with this HTTP method")
-	cmd.PersistentFlags().StringVar(&options.authority, "authority", options.authority,
+	flags.StringVar(&options.authority, "authority", options.authority,
 		"Display requests with this :authority")
-	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
+	flags.StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
+	flags.StringArrayVar(&options.headers, "header", options.headers,
+		"Display requests with this header value, given as NAME=VALUE; may be repeated, and the matching headers are displayed")
+	flags.StringArrayVar(&options.headerPrefixes, "header-prefix", options.headerPrefixes,
+		"Display requests with a header value that starts with this prefix, given as NAME=PREFIX; may be repeated, and the matching headers are displayed")
+}
 
-	return cmd
+func validateTapOutput(output string) error {
+	if output != tapOutputDefault && !contains(tapOutputs, output) {
+		return fmt.Errorf("output format [%s] not supported; must be one of: %s", output, strings.Join(tapOutputs, ", "))
+	}
+	return nil
 }
 
 func buildTapByResourceRequest(
@@ -122,6 +211,23 @@ func buildTapByResourceRequest(
 		return nil, fmt.Errorf("unsupported resource type [%s]", target.Type)
 	}
 
+	match, err := buildTapByResourceMatch(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.TapByResourceRequest{
+		Target: &pb.ResourceSelection{
+			Resource: &target,
+		},
+		MaxRps: options.maxRps,
+		Match:  match,
+	}, nil
+}
+
+// buildTapByResourceMatch returns a match of all of the filters given by the
+// options.
+func buildTapByResourceMatch(options *tapOptions) (*pb.TapByResourceRequest_Match, error) {
 	matches := []*pb.TapByResourceRequest_Match{}
 
 	if options.toResource != "" {
@@ -130,7 +236,7 @@ func buildTapByResourceRequest(
 			return nil, fmt.Errorf("destination resource invalid: %s", err)
 		}
 		if !contains(util.ValidDestinations, destination.Type) {
-			return nil, fmt.Errorf("unsupported resource type [%s]", target.Type)
+			return nil, fmt.Errorf("unsupported resource type [%s]", destination.Type)
 		}
 
 		match := pb.TapByResourceRequest_Match{
@@ -143,6 +249,29 @@ func buildTapByResourceRequest(
 		matches = append(matches, &match)
 	}
 
+	if options.fromResource != "" {
+		fromNamespace := options.fromNamespace
+		if fromNamespace == "" {
+			fromNamespace = options.namespace
+		}
+		source, err := util.BuildResource(fromNamespace, options.fromResource)
+		if err != nil {
+			return nil, fmt.Errorf("source resource invalid: %s", err)
+		}
+		if !contains(util.ValidDestinations, source.Type) {
+			return nil, fmt.Errorf("unsupported resource type [%s]", source.Type)
+		}
+
+		match := pb.TapByResourceRequest_Match{
+			Match: &pb.TapByResourceRequest_Match_Sources{
+				Sources: &pb.ResourceSelection{
+					Resource: &source,
+				},
+			},
+		}
+		matches = append(matches, &match)
+	}
+
 	if options.scheme != "" {
 		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
 			Match: &pb.TapByResourceRequest_Match_Http_Scheme{Scheme: options.scheme},
@@ -168,16 +297,31 @@ func buildTapByResourceRequest(
 		matches = append(matches, &match)
 	}
 
-	return &pb.TapByResourceRequest{
-		Target: &pb.ResourceSelection{
-			Resource: &target,
-		},
-		MaxRps: options.maxRps,
-		Match: &pb.TapByResourceRequest_Match{
-			Match: &pb.TapByResourceRequest_Match_All{
-				All: &pb.TapByResourceRequest_Match_Seq{
-					Matches: matches,
-				},
+	for _, header := range options.headers {
+		headerMatch, err := buildHeaderMatch("header", header, false)
+		if err != nil {
+			return nil, err
+		}
+		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
+			Match: &pb.TapByResourceRequest_Match_Http_Header_{Header: headerMatch},
+		})
+		matches = append(matches, &match)
+	}
+	for _, header := range options.headerPrefixes {
+		headerMatch, err := buildHeaderMatch("header-prefix", header, true)
+		if err != nil {
+			return nil, err
+		}
+		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
+			Match: &pb.TapByResourceRequest_Match_Http_Header_{Header: headerMatch},
+		})
+		matches = append(matches, &match)
+	}
+
+	return &pb.TapByResourceRequest_Match{
+		Match: &pb.TapByResourceRequest_Match_All{
+			All: &pb.TapByResourceRequest_Match_Seq{
+				Matches: matches,
 			},
 		},
 	}, nil
@@ -200,18 +344,65 @@ func buildMatchHTTP(match *pb.TapByResourceRequest_Match_Http) pb.TapByResourceR
 	}
 }
 
-func requestTapByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest) error {
+// buildHeaderMatch parses the NAME=VALUE value of a header flag into a match
+// of requests whose header has exactly that value, or starts with it if
+// `prefix` is set.
+func buildHeaderMatch(flag, value string, prefix bool) (*pb.TapByResourceRequest_Match_Http_Header, error) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid --%s value [%s]: expected NAME=VALUE", flag, value)
+	}
+
+	header := &pb.TapByResourceRequest_Match_Http_Header{Name: parts[0]}
+	if prefix {
+		header.Match = &pb.TapByResourceRequest_Match_Http_Header_Prefix{Prefix: parts[1]}
+	} else {
+		header.Match = &pb.TapByResourceRequest_Match_Http_Header_Exact{Exact: parts[1]}
+	}
+	return header, nil
+}
+
+func requestTapByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, options *tapOptions) error {
 	rsp, err := client.TapByResource(context.Background(), req)
 	if err != nil {
 		return err
 	}
 
-	return renderTap(w, rsp)
+	return renderTap(w, rsp, options)
+}
+
+// tapEventReceiver is a stream of tap events, which is either a tap of the
+// cluster or a recording of one.
+type tapEventReceiver interface {
+	Recv() (*common.TapEvent, error)
 }
 
-func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient) error {
+func renderTap(w io.Writer, tapClient tapEventReceiver, options *tapOptions) error {
+	if options.record != "" {
+		file, err := os.Create(options.record)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		tapClient = &recordingTapEventReceiver{receiver: tapClient, recording: file}
+	}
+
+	switch options.output {
+	case tapOutputJSON:
+		return writeTapEventsToBuffer(tapClient, w, renderTapEventJSON)
+	case tapOutputCorrelated:
+		return writeCorrelatedTapEvents(tapClient, w, options.flushTimeout)
+	case tapOutputHar:
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(interrupt)
+		return writeHarTapEvents(tapClient, w, interrupt)
+	}
+
 	tableWriter := tabwriter.NewWriter(w, 0, 0, 0, ' ', tabwriter.AlignRight)
-	err := writeTapEventsToBuffer(tapClient, tableWriter)
+	err := writeTapEventsToBuffer(tapClient, tableWriter, func(event *common.TapEvent) (string, error) {
+		return renderTapEvent(event), nil
+	})
 	if err != nil {
 		return err
 	}
@@ -220,7 +411,8 @@ func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient) error {
 	return nil
 }
 
-func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.Writer) error {
+func writeTapEventsToBuffer(tapClient tapEventReceiver, w io.Writer, render func(*common.TapEvent) (string, error)) error {
+	warnings := make(tapStatusWarnings)
 	for {
 		log.Debug("Waiting for data...")
 		event, err := tapClient.Recv()
@@ -231,7 +423,17 @@ func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.W
 			fmt.Fprintln(os.Stderr, err)
 			break
 		}
-		_, err = fmt.Fprintln(w, renderTapEvent(event))
+		if event.GetTapStatus() != nil {
+			if warning := warnings.render(event); warning != "" {
+				fmt.Fprintln(os.Stderr, warning)
+			}
+			continue
+		}
+		rendered, err := render(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, rendered)
 		if err != nil {
 			return err
 		}
@@ -240,44 +442,49 @@ func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.W
 	return nil
 }
 
-func renderTapEvent(event *common.TapEvent) string {
-	dstLabels := event.GetDestinationMeta().GetLabels()
-
-	dst := addr.AddressToString(event.GetDestination())
-	if pod := dstLabels["pod"]; pod != "" {
-		dst = fmt.Sprintf("%s:%d", pod, event.GetDestination().GetPort())
+// tapStatusWarnings renders the TapStatus events of a tap as warnings, and
+// holds the last state of each proxy's tap, keyed by the proxy's address. A
+// tap that connects is only reported if it had failed before.
+type tapStatusWarnings map[string]common.TapEvent_TapStatus_State
+
+func (w tapStatusWarnings) render(event *common.TapEvent) string {
+	proxyAddr := addr.AddressToString(event.GetDestination())
+	proxy := proxyAddr
+	labels := event.GetDestinationMeta().GetLabels()
+	if pod := labels["pod"]; pod != "" {
+		proxy = fmt.Sprintf("pod %s/%s", labels["namespace"], pod)
 	}
 
-	proxy := "???"
-	tls := ""
-	switch event.GetProxyDirection() {
-	case common.TapEvent_INBOUND:
-		proxy = "in " // A space is added so it aligns with `out`.
-		srcLabels := event.GetSourceMeta().GetLabels()
-		tls = srcLabels["tls"]
-	case common.TapEvent_OUTBOUND:
-		proxy = "out"
-		tls = dstLabels["tls"]
-	default:
-		// Too old for TLS.
+	tapStatus := event.GetTapStatus()
+	previous := w[proxyAddr]
+	w[proxyAddr] = tapStatus.GetState()
+
+	switch tapStatus.GetState() {
+	case common.TapEvent_TapStatus_CONNECTED:
+		if previous == common.TapEvent_TapStatus_RETRYING || previous == common.TapEvent_TapStatus_FAILED {
+			return fmt.Sprintf("Tap of %s reconnected", proxy)
+		}
+	case common.TapEvent_TapStatus_RETRYING:
+		return fmt.Sprintf("Warning: tap of %s failed, retrying: %s", proxy, tapStatus.GetError())
+	case common.TapEvent_TapStatus_FAILED:
+		return fmt.Sprintf("Warning: tap of %s failed: %s", proxy, tapStatus.GetError())
 	}
+	return ""
+}
 
-	flow := fmt.Sprintf("proxy=%s src=%s dst=%s tls=%s",
-		proxy,
-		addr.AddressToString(event.GetSource()),
-		dst,
-		tls,
-	)
+func renderTapEvent(event *common.TapEvent) string {
+	flow := renderTapFlow(event)
 
 	switch ev := event.GetHttp().GetEvent().(type) {
 	case *common.TapEvent_Http_RequestInit_:
-		return fmt.Sprintf("req id=%d:%d %s :method=%s :authority=%s :path=%s",
+		return fmt.Sprintf("req id=%d:%d %s :method=%s :authority=%s :path=%s%s",
 			ev.RequestInit.GetId().GetBase(),
 			ev.RequestInit.GetId().GetStream(),
 			flow,
 			ev.RequestInit.GetMethod().GetRegistered().String(),
 			ev.RequestInit.GetAuthority(),
 			ev.RequestInit.GetPath(),
+			renderHeaders(ev.RequestInit.GetHeaders()),
 		)
 
 	case *common.TapEvent_Http_ResponseInit_:
@@ -325,3 +532,44 @@ func renderTapEvent(event *common.TapEvent) string {
 		return fmt.Sprintf("unknown %s", flow)
 	}
 }
+
+// renderTapFlow renders the proxy, addresses and TLS status of an event.
+func renderTapFlow(event *common.TapEvent) string {
+	dstLabels := event.GetDestinationMeta().GetLabels()
+
+	dst := addr.AddressToString(event.GetDestination())
+	if pod := dstLabels["pod"]; pod != "" {
+		dst = fmt.Sprintf("%s:%d", pod, event.GetDestination().GetPort())
+	}
+
+	proxy := "???"
+	tls := ""
+	switch event.GetProxyDirection() {
+	case common.TapEvent_INBOUND:
+		proxy = "in " // A space is added so it aligns with `out`.
+		srcLabels := event.GetSourceMeta().GetLabels()
+		tls = srcLabels["tls"]
+	case common.TapEvent_OUTBOUND:
+		proxy = "out"
+		tls = dstLabels["tls"]
+	default:
+		// Too old for TLS.
+	}
+
+	return fmt.Sprintf("proxy=%s src=%s dst=%s tls=%s",
+		proxy,
+		addr.AddressToString(event.GetSource()),
+		dst,
+		tls,
+	)
+}
+
+// renderHeaders returns the headers as " name=value" pairs, in the order in
+// which they were reported.
+func renderHeaders(headers []*common.TapEvent_Http_Header) string {
+	rendered := ""
+	for _, header := range headers {
+		rendered += fmt.Sprintf(" %s=%s", header.GetName(), header.GetValue())
+	}
+	return rendered
+}
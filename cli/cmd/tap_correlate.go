@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"google.golang.org/grpc/codes"
+)
+
+// tapExpireInterval is how often requests that have been waiting for their
+// response for longer than the flush timeout are printed.
+const tapExpireInterval = time.Second
+
+// tapRequest holds the events of a request, from its RequestInit event to
+// its ResponseEnd event.
+type tapRequest struct {
+	// the RequestInit event
+	event        *common.TapEvent
+	responseInit *common.TapEvent_Http_ResponseInit
+	responseEnd  *common.TapEvent_Http_ResponseEnd
+	// when the RequestInit event was received
+	received time.Time
+}
+
+// tapCorrelator pairs the events of each request by stream. Responses whose
+// requests weren't seen are dropped.
+type tapCorrelator struct {
+	timeout time.Duration
+	pending map[tapStreamKey]*tapRequest
+}
+
+func newTapCorrelator(timeout time.Duration) *tapCorrelator {
+	return &tapCorrelator{
+		timeout: timeout,
+		pending: make(map[tapStreamKey]*tapRequest),
+	}
+}
+
+// add adds an event received at `now`, and returns its request if the event
+// completes it.
+func (c *tapCorrelator) add(event *common.TapEvent, now time.Time) *tapRequest {
+	key := newTapStreamKey(event)
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *common.TapEvent_Http_RequestInit_:
+		c.pending[key] = &tapRequest{event: event, received: now}
+
+	case *common.TapEvent_Http_ResponseInit_:
+		if req, ok := c.pending[key]; ok {
+			req.responseInit = ev.ResponseInit
+		}
+
+	case *common.TapEvent_Http_ResponseEnd_:
+		if req, ok := c.pending[key]; ok {
+			delete(c.pending, key)
+			req.responseEnd = ev.ResponseEnd
+			return req
+		}
+	}
+	return nil
+}
+
+// expire removes and returns the requests that were received more than the
+// timeout before `now`, in the order in which they were received.
+func (c *tapCorrelator) expire(now time.Time) []*tapRequest {
+	expired := []*tapRequest{}
+	for key, req := range c.pending {
+		if now.Sub(req.received) >= c.timeout {
+			delete(c.pending, key)
+			expired = append(expired, req)
+		}
+	}
+	sortTapRequests(expired)
+	return expired
+}
+
+// flush removes and returns all of the pending requests, in the order in
+// which they were received.
+func (c *tapCorrelator) flush() []*tapRequest {
+	flushed := []*tapRequest{}
+	for key, req := range c.pending {
+		delete(c.pending, key)
+		flushed = append(flushed, req)
+	}
+	sortTapRequests(flushed)
+	return flushed
+}
+
+func sortTapRequests(requests []*tapRequest) {
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].received.Before(requests[j].received)
+	})
+}
+
+// receiveTapEvents receives the events of a tap in a goroutine, so that they
+// can be waited on along with timers. Once the tap ends, the error that ended
+// it is sent on the returned error channel, which is io.EOF if the tap ended
+// normally.
+func receiveTapEvents(tapClient tapEventReceiver) (<-chan *common.TapEvent, <-chan error) {
+	events := make(chan *common.TapEvent)
+	done := make(chan error, 1)
+	go func() {
+		for {
+			event, err := tapClient.Recv()
+			if err != nil {
+				done <- err
+				return
+			}
+			events <- event
+		}
+	}()
+	return events, done
+}
+
+// writeCorrelatedTapEvents writes a line per request of a tap once its
+// response ends. Requests whose response hasn't ended after the timeout, or
+// by the end of the tap, are written without it.
+func writeCorrelatedTapEvents(tapClient tapEventReceiver, w io.Writer, timeout time.Duration) error {
+	correlator := newTapCorrelator(timeout)
+	warnings := make(tapStatusWarnings)
+	events, done := receiveTapEvents(tapClient)
+
+	ticker := time.NewTicker(tapExpireInterval)
+	defer ticker.Stop()
+
+	writeRequests := func(requests ...*tapRequest) error {
+		for _, req := range requests {
+			_, err := fmt.Fprintln(w, renderTapRequest(req))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case event := <-events:
+			if event.GetTapStatus() != nil {
+				if warning := warnings.render(event); warning != "" {
+					fmt.Fprintln(os.Stderr, warning)
+				}
+				continue
+			}
+			if req := correlator.add(event, time.Now()); req != nil {
+				if err := writeRequests(req); err != nil {
+					return err
+				}
+			}
+
+		case now := <-ticker.C:
+			if err := writeRequests(correlator.expire(now)...); err != nil {
+				return err
+			}
+
+		case err := <-done:
+			if err != io.EOF {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return writeRequests(correlator.flush()...)
+		}
+	}
+}
+
+// renderTapRequest renders a request and as much of its response as was
+// received. Requests whose response didn't end are rendered as "incomplete".
+func renderTapRequest(req *tapRequest) string {
+	init := req.event.GetHttp().GetRequestInit()
+
+	prefix := "req"
+	if req.responseEnd == nil {
+		prefix = "incomplete"
+	}
+
+	rendered := fmt.Sprintf("%s id=%d:%d %s :method=%s :authority=%s :path=%s%s",
+		prefix,
+		init.GetId().GetBase(),
+		init.GetId().GetStream(),
+		renderTapFlow(req.event),
+		methodString(init.GetMethod()),
+		init.GetAuthority(),
+		init.GetPath(),
+		renderHeaders(init.GetHeaders()),
+	)
+
+	if req.responseInit != nil {
+		rendered += fmt.Sprintf(" :status=%d latency=%dµs",
+			req.responseInit.GetHttpStatus(),
+			durationNanos(req.responseInit.GetSinceRequestInit())/1000,
+		)
+	}
+
+	if end := req.responseEnd; end != nil {
+		rendered += fmt.Sprintf(" duration=%dµs response-length=%dB",
+			durationNanos(end.GetSinceResponseInit())/1000,
+			end.GetResponseBytes(),
+		)
+		switch eos := end.GetEos().GetEnd().(type) {
+		case *common.Eos_GrpcStatusCode:
+			rendered += fmt.Sprintf(" grpc-status=%s", codes.Code(eos.GrpcStatusCode))
+		case *common.Eos_ResetErrorCode:
+			rendered += fmt.Sprintf(" reset-error=%+v", eos.ResetErrorCode)
+		}
+	}
+
+	return rendered
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/pkg/addr"
+	"github.com/runconduit/conduit/pkg/version"
+	"google.golang.org/grpc/codes"
+)
+
+// The types below define an HTTP Archive (HAR) 1.2 document, as specified by
+// http://www.softwareishard.com/blog/har-12-spec/. Times are given in
+// milliseconds, and sizes that aren't known are given as -1.
+
+type har struct {
+	Log *harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator *harCreator `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string       `json:"startedDateTime"`
+	Time            float64      `json:"time"`
+	Request         *harRequest  `json:"request"`
+	Response        *harResponse `json:"response"`
+	Cache           *harCache    `json:"cache"`
+	Timings         *harTimings  `json:"timings"`
+	ServerIPAddress string       `json:"serverIPAddress,omitempty"`
+	Comment         string       `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*harNameValue `json:"cookies"`
+	Headers     []*harNameValue `json:"headers"`
+	QueryString []*harNameValue `json:"queryString"`
+	HeadersSize int64           `json:"headersSize"`
+	BodySize    int64           `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      uint32          `json:"status"`
+	StatusText  string          `json:"statusText"`
+	HTTPVersion string          `json:"httpVersion"`
+	Cookies     []*harNameValue `json:"cookies"`
+	Headers     []*harNameValue `json:"headers"`
+	Content     *harContent     `json:"content"`
+	RedirectURL string          `json:"redirectURL"`
+	HeadersSize int64           `json:"headersSize"`
+	BodySize    int64           `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+// harCache is always empty, as the proxies don't cache responses.
+type harCache struct{}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// writeHarTapEvents collects the requests of a tap, along with their
+// responses, and writes them as a HAR document once the tap ends or a signal
+// is received on `interrupt`. Requests whose response hadn't ended by then
+// are included without it.
+func writeHarTapEvents(tapClient tapEventReceiver, w io.Writer, interrupt <-chan os.Signal) error {
+	// requests are only flushed once the tap ends, so they never time out
+	correlator := newTapCorrelator(0)
+	warnings := make(tapStatusWarnings)
+	events, done := receiveTapEvents(tapClient)
+
+	requests := []*tapRequest{}
+
+collect:
+	for {
+		select {
+		case event := <-events:
+			if event.GetTapStatus() != nil {
+				if warning := warnings.render(event); warning != "" {
+					fmt.Fprintln(os.Stderr, warning)
+				}
+				continue
+			}
+			if req := correlator.add(event, time.Now()); req != nil {
+				requests = append(requests, req)
+			}
+
+		case err := <-done:
+			if err != io.EOF {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			break collect
+
+		case <-interrupt:
+			break collect
+		}
+	}
+
+	return writeHar(w, append(requests, correlator.flush()...))
+}
+
+// writeHar writes requests as a HAR document, in the order in which they were
+// received.
+func writeHar(w io.Writer, requests []*tapRequest) error {
+	sortTapRequests(requests)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(toHar(requests))
+}
+
+func toHar(requests []*tapRequest) *har {
+	entries := make([]*harEntry, 0)
+	for _, req := range requests {
+		entries = append(entries, toHarEntry(req))
+	}
+
+	return &har{
+		Log: &harLog{
+			Version: "1.2",
+			Creator: &harCreator{Name: "conduit", Version: version.Version},
+			Entries: entries,
+		},
+	}
+}
+
+// toHarEntry renders a request and as much of its response as was received.
+// The tap doesn't report the HTTP version, the query string, the response
+// headers or the body of either message, and so they are left empty. The
+// tap's view of the request, along with its gRPC status or reset error, is
+// given as the entry's comment.
+func toHarEntry(req *tapRequest) *harEntry {
+	init := req.event.GetHttp().GetRequestInit()
+
+	headers := make([]*harNameValue, 0)
+	for _, header := range init.GetHeaders() {
+		headers = append(headers, &harNameValue{Name: header.GetName(), Value: header.GetValue()})
+	}
+
+	scheme := strings.ToLower(schemeString(init.GetScheme()))
+	if scheme == "" {
+		scheme = "http"
+	}
+	authority := init.GetAuthority()
+	if authority == "" {
+		authority = addr.AddressToString(req.event.GetDestination())
+	}
+
+	timings := &harTimings{}
+	response := &harResponse{
+		Cookies:     make([]*harNameValue, 0),
+		Headers:     make([]*harNameValue, 0),
+		Content:     &harContent{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	comment := renderTapFlow(req.event)
+
+	if req.responseInit != nil {
+		response.Status = req.responseInit.GetHttpStatus()
+		response.StatusText = http.StatusText(int(response.Status))
+		timings.Wait = nanosToMillis(durationNanos(req.responseInit.GetSinceRequestInit()))
+	}
+
+	if end := req.responseEnd; end != nil {
+		response.Content.Size = int64(end.GetResponseBytes())
+		response.BodySize = int64(end.GetResponseBytes())
+		timings.Receive = nanosToMillis(durationNanos(end.GetSinceResponseInit()))
+		switch eos := end.GetEos().GetEnd().(type) {
+		case *common.Eos_GrpcStatusCode:
+			comment += fmt.Sprintf(" grpc-status=%s", codes.Code(eos.GrpcStatusCode))
+		case *common.Eos_ResetErrorCode:
+			comment += fmt.Sprintf(" reset-error=%+v", eos.ResetErrorCode)
+		}
+	} else {
+		comment += " incomplete"
+	}
+
+	return &harEntry{
+		StartedDateTime: req.received.Format(time.RFC3339Nano),
+		Time:            timings.Send + timings.Wait + timings.Receive,
+		Request: &harRequest{
+			Method:      methodString(init.GetMethod()),
+			URL:         fmt.Sprintf("%s://%s%s", scheme, authority, init.GetPath()),
+			Cookies:     make([]*harNameValue, 0),
+			Headers:     headers,
+			QueryString: make([]*harNameValue, 0),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Response:        response,
+		Cache:           &harCache{},
+		Timings:         timings,
+		ServerIPAddress: addr.IPToString(req.event.GetDestination().GetIp()),
+		Comment:         comment,
+	}
+}
+
+func nanosToMillis(nanos int64) float64 {
+	return float64(nanos) / float64(time.Millisecond)
+}
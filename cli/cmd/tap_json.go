@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/pkg/addr"
+)
+
+// The types below define the JSON rendering of tap events, which is one JSON
+// object per line. Their field names are part of the CLI's interface, and
+// must not be changed. Durations are given in nanoseconds.
+
+type tapEventJSON struct {
+	// One of "requestInit", "responseInit", "responseEnd" or "unknown",
+	// naming the field below that holds the details of the event.
+	Type            string            `json:"type"`
+	ProxyDirection  string            `json:"proxyDirection"`
+	Source          *tcpAddressJSON   `json:"source"`
+	SourceMeta      *endpointMetaJSON `json:"sourceMeta"`
+	Destination     *tcpAddressJSON   `json:"destination"`
+	DestinationMeta *endpointMetaJSON `json:"destinationMeta"`
+	RequestInit     *requestInitJSON  `json:"requestInit,omitempty"`
+	ResponseInit    *responseInitJSON `json:"responseInit,omitempty"`
+	ResponseEnd     *responseEndJSON  `json:"responseEnd,omitempty"`
+}
+
+type tcpAddressJSON struct {
+	IP   string `json:"ip"`
+	Port uint32 `json:"port"`
+}
+
+type endpointMetaJSON struct {
+	Labels map[string]string `json:"labels"`
+}
+
+type streamIDJSON struct {
+	Base   uint32 `json:"base"`
+	Stream uint64 `json:"stream"`
+}
+
+type headerJSON struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type requestInitJSON struct {
+	ID        *streamIDJSON `json:"id"`
+	Method    string        `json:"method"`
+	Scheme    string        `json:"scheme"`
+	Authority string        `json:"authority"`
+	Path      string        `json:"path"`
+	Headers   []*headerJSON `json:"headers"`
+}
+
+type responseInitJSON struct {
+	ID                 *streamIDJSON `json:"id"`
+	SinceRequestInitNs int64         `json:"sinceRequestInitNs"`
+	HTTPStatus         uint32        `json:"httpStatus"`
+}
+
+type responseEndJSON struct {
+	ID                  *streamIDJSON `json:"id"`
+	SinceRequestInitNs  int64         `json:"sinceRequestInitNs"`
+	SinceResponseInitNs int64         `json:"sinceResponseInitNs"`
+	ResponseBytes       uint64        `json:"responseBytes"`
+	Eos                 *eosJSON      `json:"eos"`
+}
+
+// eosJSON has at most one of its fields set, and neither if the stream ended
+// without a gRPC status or a reset.
+type eosJSON struct {
+	GrpcStatusCode *uint32 `json:"grpcStatusCode,omitempty"`
+	ResetErrorCode *uint32 `json:"resetErrorCode,omitempty"`
+}
+
+func renderTapEventJSON(event *common.TapEvent) (string, error) {
+	rendered, err := json.Marshal(toTapEventJSON(event))
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+func toTapEventJSON(event *common.TapEvent) *tapEventJSON {
+	rendered := &tapEventJSON{
+		Type:            "unknown",
+		ProxyDirection:  event.GetProxyDirection().String(),
+		Source:          toTCPAddressJSON(event.GetSource()),
+		SourceMeta:      toEndpointMetaJSON(event.GetSourceMeta()),
+		Destination:     toTCPAddressJSON(event.GetDestination()),
+		DestinationMeta: toEndpointMetaJSON(event.GetDestinationMeta()),
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *common.TapEvent_Http_RequestInit_:
+		headers := make([]*headerJSON, 0)
+		for _, header := range ev.RequestInit.GetHeaders() {
+			headers = append(headers, &headerJSON{Name: header.GetName(), Value: header.GetValue()})
+		}
+		rendered.Type = "requestInit"
+		rendered.RequestInit = &requestInitJSON{
+			ID:        toStreamIDJSON(ev.RequestInit.GetId()),
+			Method:    methodString(ev.RequestInit.GetMethod()),
+			Scheme:    schemeString(ev.RequestInit.GetScheme()),
+			Authority: ev.RequestInit.GetAuthority(),
+			Path:      ev.RequestInit.GetPath(),
+			Headers:   headers,
+		}
+
+	case *common.TapEvent_Http_ResponseInit_:
+		rendered.Type = "responseInit"
+		rendered.ResponseInit = &responseInitJSON{
+			ID:                 toStreamIDJSON(ev.ResponseInit.GetId()),
+			SinceRequestInitNs: durationNanos(ev.ResponseInit.GetSinceRequestInit()),
+			HTTPStatus:         ev.ResponseInit.GetHttpStatus(),
+		}
+
+	case *common.TapEvent_Http_ResponseEnd_:
+		eos := &eosJSON{}
+		switch end := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *common.Eos_GrpcStatusCode:
+			eos.GrpcStatusCode = &end.GrpcStatusCode
+		case *common.Eos_ResetErrorCode:
+			eos.ResetErrorCode = &end.ResetErrorCode
+		}
+		rendered.Type = "responseEnd"
+		rendered.ResponseEnd = &responseEndJSON{
+			ID:                  toStreamIDJSON(ev.ResponseEnd.GetId()),
+			SinceRequestInitNs:  durationNanos(ev.ResponseEnd.GetSinceRequestInit()),
+			SinceResponseInitNs: durationNanos(ev.ResponseEnd.GetSinceResponseInit()),
+			ResponseBytes:       ev.ResponseEnd.GetResponseBytes(),
+			Eos:                 eos,
+		}
+	}
+
+	return rendered
+}
+
+func toTCPAddressJSON(address *common.TcpAddress) *tcpAddressJSON {
+	if address == nil {
+		return nil
+	}
+	return &tcpAddressJSON{
+		IP:   addr.IPToString(address.GetIp()),
+		Port: address.GetPort(),
+	}
+}
+
+func toEndpointMetaJSON(meta *common.TapEvent_EndpointMeta) *endpointMetaJSON {
+	labels := meta.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return &endpointMetaJSON{Labels: labels}
+}
+
+func toStreamIDJSON(id *common.TapEvent_Http_StreamId) *streamIDJSON {
+	return &streamIDJSON{
+		Base:   id.GetBase(),
+		Stream: id.GetStream(),
+	}
+}
+
+func methodString(method *common.HttpMethod) string {
+	if unregistered := method.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return method.GetRegistered().String()
+}
+
+func schemeString(scheme *common.Scheme) string {
+	if scheme == nil {
+		return ""
+	}
+	if unregistered := scheme.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return scheme.GetRegistered().String()
+}
+
+// durationNanos returns a duration in nanoseconds, or 0 if it is missing or
+// invalid.
+func durationNanos(d *duration.Duration) int64 {
+	if d == nil {
+		return 0
+	}
+	converted, err := ptypes.Duration(d)
+	if err != nil {
+		return 0
+	}
+	return int64(converted / time.Nanosecond)
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/runconduit/conduit/controller/api/public"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	pb "github.com/runconduit/conduit/controller/gen/public"
+	"github.com/runconduit/conduit/pkg/addr"
+	"github.com/runconduit/conduit/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+// A recording of a tap is the stream of TapEvents it received, each framed as
+// in the API's HTTP streams.
+
+func newCmdTapReplay(options *tapOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay [flags] FILE",
+		Short: "Render the events of a tap recorded with \"--record\"",
+		Long: `Render the events of a tap recorded with "--record".
+
+  The recorded events can be filtered with the same flags as the events of a
+  tap, and are rendered in the same formats. Replaying a recording doesn't
+  require access to the cluster, and so the "--from" flag isn't supported, and
+  only the headers that were matched on when recording can be matched on.`,
+		Example: `  # record a tap of the web deployment
+  conduit tap deploy/web --record web.tap
+
+  # render the recorded requests to the voting service, as JSON
+  conduit tap replay web.tap --to svc/voting-svc -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := validateTapOutput(options.output)
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			return replayTap(os.Stdout, file, options)
+		},
+	}
+
+	return cmd
+}
+
+// replayTap renders the events of a recording that pass the filters given by
+// the options.
+func replayTap(w io.Writer, recording io.Reader, options *tapOptions) error {
+	if options.fromResource != "" {
+		return errors.New("the \"--from\" flag requires access to the cluster, and isn't supported when replaying a tap")
+	}
+
+	match, err := buildTapByResourceMatch(options)
+	if err != nil {
+		return err
+	}
+
+	return renderTap(w, newTapReplay(recording, match), options)
+}
+
+// recordingTapEventReceiver writes each event it receives to a recording.
+type recordingTapEventReceiver struct {
+	receiver  tapEventReceiver
+	recording io.Writer
+}
+
+func (r *recordingTapEventReceiver) Recv() (*common.TapEvent, error) {
+	event, err := r.receiver.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	err = public.WritePayload(r.recording, event)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// tapReplay receives the events of a recording that pass a match, the way
+// that a proxy would have reported them: a request is matched by its
+// RequestInit event, and the events of its response are received only if
+// the request was.
+type tapReplay struct {
+	reader *bufio.Reader
+	match  *pb.TapByResourceRequest_Match
+	// the streams whose requests were matched, and whose response hasn't ended
+	streams map[tapStreamKey]struct{}
+}
+
+// tapStreamKey identifies a stream within a recording. Stream IDs are only
+// unique within a proxy, so the stream's addresses are part of the key.
+type tapStreamKey struct {
+	direction   common.TapEvent_ProxyDirection
+	source      string
+	destination string
+	base        uint32
+	stream      uint64
+}
+
+func newTapStreamKey(event *common.TapEvent) tapStreamKey {
+	var id *common.TapEvent_Http_StreamId
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *common.TapEvent_Http_RequestInit_:
+		id = ev.RequestInit.GetId()
+	case *common.TapEvent_Http_ResponseInit_:
+		id = ev.ResponseInit.GetId()
+	case *common.TapEvent_Http_ResponseEnd_:
+		id = ev.ResponseEnd.GetId()
+	}
+
+	return tapStreamKey{
+		direction:   event.GetProxyDirection(),
+		source:      addr.AddressToString(event.GetSource()),
+		destination: addr.AddressToString(event.GetDestination()),
+		base:        id.GetBase(),
+		stream:      id.GetStream(),
+	}
+}
+
+func newTapReplay(recording io.Reader, match *pb.TapByResourceRequest_Match) *tapReplay {
+	return &tapReplay{
+		reader:  bufio.NewReader(recording),
+		match:   match,
+		streams: make(map[tapStreamKey]struct{}),
+	}
+}
+
+func (r *tapReplay) Recv() (*common.TapEvent, error) {
+	for {
+		event := &common.TapEvent{}
+		err := public.ReadPayload(r.reader, event)
+		if err != nil {
+			return nil, err
+		}
+
+		key := newTapStreamKey(event)
+		switch event.GetHttp().GetEvent().(type) {
+		case *common.TapEvent_Http_RequestInit_:
+			if tapMatches(r.match, event) {
+				r.streams[key] = struct{}{}
+				return event, nil
+			}
+
+		case *common.TapEvent_Http_ResponseInit_:
+			if _, ok := r.streams[key]; ok {
+				return event, nil
+			}
+
+		case *common.TapEvent_Http_ResponseEnd_:
+			if _, ok := r.streams[key]; ok {
+				delete(r.streams, key)
+				return event, nil
+			}
+		}
+	}
+}
+
+// tapMatches evaluates a match against the RequestInit event of a request, as
+// the tap server and the proxies would have.
+func tapMatches(match *pb.TapByResourceRequest_Match, event *common.TapEvent) bool {
+	switch typed := match.GetMatch().(type) {
+	case nil:
+		return true
+
+	case *pb.TapByResourceRequest_Match_All:
+		for _, m := range typed.All.GetMatches() {
+			if !tapMatches(m, event) {
+				return false
+			}
+		}
+		return true
+
+	case *pb.TapByResourceRequest_Match_Any:
+		for _, m := range typed.Any.GetMatches() {
+			if tapMatches(m, event) {
+				return true
+			}
+		}
+		return false
+
+	case *pb.TapByResourceRequest_Match_Not:
+		return !tapMatches(typed.Not, event)
+
+	case *pb.TapByResourceRequest_Match_Destinations:
+		resource := typed.Destinations.GetResource()
+		dstLabels := event.GetDestinationMeta().GetLabels()
+		if resource.GetName() != "" && dstLabels[k8s.ResourceTypesToProxyLabels[resource.GetType()]] != resource.GetName() {
+			return false
+		}
+		if resource.GetType() != k8s.Namespaces && resource.GetNamespace() != "" && dstLabels["namespace"] != resource.GetNamespace() {
+			return false
+		}
+		return true
+
+	case *pb.TapByResourceRequest_Match_Http_:
+		return tapHTTPMatches(typed.Http, event.GetHttp().GetRequestInit())
+
+	default:
+		return false
+	}
+}
+
+func tapHTTPMatches(match *pb.TapByResourceRequest_Match_Http, req *common.TapEvent_Http_RequestInit) bool {
+	switch typed := match.GetMatch().(type) {
+	case *pb.TapByResourceRequest_Match_Http_Scheme:
+		return strings.EqualFold(schemeString(req.GetScheme()), typed.Scheme)
+
+	case *pb.TapByResourceRequest_Match_Http_Method:
+		return strings.EqualFold(methodString(req.GetMethod()), typed.Method)
+
+	case *pb.TapByResourceRequest_Match_Http_Authority:
+		return req.GetAuthority() == typed.Authority
+
+	case *pb.TapByResourceRequest_Match_Http_Path:
+		return strings.HasPrefix(req.GetPath(), typed.Path)
+
+	case *pb.TapByResourceRequest_Match_Http_Header_:
+		for _, header := range req.GetHeaders() {
+			if !strings.EqualFold(header.GetName(), typed.Header.GetName()) {
+				continue
+			}
+			switch value := typed.Header.GetMatch().(type) {
+			case *pb.TapByResourceRequest_Match_Http_Header_Exact:
+				if header.GetValue() == value.Exact {
+					return true
+				}
+			case *pb.TapByResourceRequest_Match_Http_Header_Prefix:
+				if strings.HasPrefix(header.GetValue(), value.Prefix) {
+					return true
+				}
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
@@ -2,14 +2,21 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/runconduit/conduit/controller/api/public"
 	common "github.com/runconduit/conduit/controller/gen/common"
+	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/pkg/addr"
 	"github.com/runconduit/conduit/pkg/k8s"
 	"google.golang.org/grpc/codes"
@@ -79,7 +86,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, options)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -95,6 +102,196 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 	})
 
+	t.Run("Should render busy response correlated", func(t *testing.T) {
+		options := newTapOptions()
+		options.output = tapOutputCorrelated
+
+		req, err := buildTapByResourceRequest([]string{k8s.Pods, "pod-666"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		dstMeta := map[string]string{
+			"pod": "my-pod",
+			"tls": "true",
+		}
+		requestInit := func(base uint32, method common.HttpMethod_Registered, path string) common.TapEvent {
+			return createEvent(
+				&common.TapEvent_Http{
+					Event: &common.TapEvent_Http_RequestInit_{
+						RequestInit: &common.TapEvent_Http_RequestInit{
+							Id: &common.TapEvent_Http_StreamId{Base: base},
+							Method: &common.HttpMethod{
+								Type: &common.HttpMethod_Registered_{Registered: method},
+							},
+							Authority: "localhost",
+							Path:      path,
+						},
+					},
+				},
+				dstMeta,
+			)
+		}
+		responseInit := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseInit_{
+					ResponseInit: &common.TapEvent_Http_ResponseInit{
+						Id:               &common.TapEvent_Http_StreamId{Base: 1},
+						SinceRequestInit: &duration.Duration{Nanos: 2000000},
+						HttpStatus:       200,
+					},
+				},
+			},
+			dstMeta,
+		)
+		responseEnd := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id: &common.TapEvent_Http_StreamId{Base: 1},
+						Eos: &common.Eos{
+							End: &common.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.OK)},
+						},
+						SinceRequestInit:  &duration.Duration{Nanos: 5000000},
+						SinceResponseInit: &duration.Duration{Nanos: 3000000},
+						ResponseBytes:     1337,
+					},
+				},
+			},
+			dstMeta,
+		)
+
+		mockApiClient := &public.MockConduitApiClient{}
+		mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+			TapEventsToReturn: []common.TapEvent{
+				requestInit(1, common.HttpMethod_POST, "/some/path"),
+				requestInit(2, common.HttpMethod_GET, "/other/path"),
+				responseInit,
+				responseEnd,
+			},
+		}
+
+		writer := bytes.NewBufferString("")
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/tap_busy_output_correlated.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectedContent := string(goldenFileBytes)
+		output := writer.String()
+		if expectedContent != output {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+
+	t.Run("Should render busy response as JSON", func(t *testing.T) {
+		options := newTapOptions()
+		options.output = tapOutputJSON
+
+		req, err := buildTapByResourceRequest([]string{k8s.Pods, "pod-666"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		event1 := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_RequestInit_{
+					RequestInit: &common.TapEvent_Http_RequestInit{
+						Id: &common.TapEvent_Http_StreamId{
+							Base: 1,
+						},
+						Method: &common.HttpMethod{
+							Type: &common.HttpMethod_Registered_{Registered: common.HttpMethod_POST},
+						},
+						Scheme: &common.Scheme{
+							Type: &common.Scheme_Registered_{Registered: common.Scheme_HTTPS},
+						},
+						Authority: "localhost",
+						Path:      "/some/path",
+						Headers: []*common.TapEvent_Http_Header{
+							{Name: "x-tenant-id", Value: "42"},
+						},
+					},
+				},
+			},
+			map[string]string{
+				"pod": "my-pod",
+				"tls": "true",
+			},
+		)
+		event2 := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseInit_{
+					ResponseInit: &common.TapEvent_Http_ResponseInit{
+						Id: &common.TapEvent_Http_StreamId{
+							Base: 1,
+						},
+						SinceRequestInit: &duration.Duration{Nanos: 999000},
+						HttpStatus:       http.StatusOK,
+					},
+				},
+			},
+			map[string]string{},
+		)
+		event3 := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id: &common.TapEvent_Http_StreamId{
+							Base: 1,
+						},
+						Eos: &common.Eos{
+							End: &common.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.OK)},
+						},
+						SinceRequestInit:  &duration.Duration{Seconds: 1},
+						SinceResponseInit: &duration.Duration{Nanos: 888000},
+						ResponseBytes:     1337,
+					},
+				},
+			},
+			map[string]string{},
+		)
+		event4 := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id: &common.TapEvent_Http_StreamId{
+							Base: 2,
+						},
+						Eos: &common.Eos{
+							End: &common.Eos_ResetErrorCode{ResetErrorCode: 123},
+						},
+					},
+				},
+			},
+			map[string]string{},
+		)
+		mockApiClient := &public.MockConduitApiClient{}
+		mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+			TapEventsToReturn: []common.TapEvent{event1, event2, event3, event4},
+		}
+
+		writer := bytes.NewBufferString("")
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/tap_busy_output_json.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectedContent := string(goldenFileBytes)
+		output := writer.String()
+		if expectedContent != output {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+
 	t.Run("Should render empty response if no events returned", func(t *testing.T) {
 		resourceType := k8s.Pods
 		targetName := "pod-666"
@@ -119,7 +316,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, options)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -160,13 +357,441 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, options)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing but output [%s]", writer.String())
 		}
 	})
 }
 
+func TestBuildTapByResourceRequestHeaders(t *testing.T) {
+	t.Run("Matches on each of the given headers", func(t *testing.T) {
+		options := newTapOptions()
+		options.headers = []string{"x-tenant-id=42", "x-empty="}
+		options.headerPrefixes = []string{"user-agent=curl/"}
+
+		req, err := buildTapByResourceRequest([]string{k8s.Deployments, "api"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedHeaders := []*pb.TapByResourceRequest_Match_Http_Header{
+			{Name: "x-tenant-id", Match: &pb.TapByResourceRequest_Match_Http_Header_Exact{Exact: "42"}},
+			{Name: "x-empty", Match: &pb.TapByResourceRequest_Match_Http_Header_Exact{Exact: ""}},
+			{Name: "user-agent", Match: &pb.TapByResourceRequest_Match_Http_Header_Prefix{Prefix: "curl/"}},
+		}
+		matches := req.GetMatch().GetAll().GetMatches()
+		if len(matches) != len(expectedHeaders) {
+			t.Fatalf("Expected [%d] matches, got [%d]: %v", len(expectedHeaders), len(matches), matches)
+		}
+		for i, expected := range expectedHeaders {
+			actual := matches[i].GetHttp().GetHeader()
+			if !proto.Equal(actual, expected) {
+				t.Fatalf("Expected match [%v], got [%v]", expected, actual)
+			}
+		}
+	})
+
+	t.Run("Returns an error for malformed headers", func(t *testing.T) {
+		for _, header := range []string{"x-tenant-id", "=42"} {
+			options := newTapOptions()
+			options.headers = []string{header}
+
+			_, err := buildTapByResourceRequest([]string{k8s.Deployments, "api"}, options)
+			if err == nil {
+				t.Fatalf("Expected an error for header [%s], got nothing", header)
+			}
+		}
+	})
+}
+
+func TestBuildTapByResourceRequestSources(t *testing.T) {
+	t.Run("Matches on the source, in the target's namespace by default", func(t *testing.T) {
+		for _, tt := range []struct {
+			fromNamespace     string
+			expectedNamespace string
+		}{
+			{"", "emojivoto"},
+			{"bots", "bots"},
+		} {
+			options := newTapOptions()
+			options.namespace = "emojivoto"
+			options.fromResource = "deploy/vote-bot"
+			options.fromNamespace = tt.fromNamespace
+
+			req, err := buildTapByResourceRequest([]string{"deploy/web"}, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			expected := &pb.Resource{Namespace: tt.expectedNamespace, Type: k8s.Deployments, Name: "vote-bot"}
+			actual := req.GetMatch().GetAll().GetMatches()[0].GetSources().GetResource()
+			if !proto.Equal(actual, expected) {
+				t.Fatalf("Expected source [%v], got [%v]", expected, actual)
+			}
+		}
+	})
+
+	t.Run("Returns an error for unsupported source types", func(t *testing.T) {
+		options := newTapOptions()
+		options.fromResource = "authorities/web"
+
+		_, err := buildTapByResourceRequest([]string{"deploy/web"}, options)
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+}
+
+func TestRecordAndReplayTap(t *testing.T) {
+	requestInit := func(base uint32, path string, dstMeta map[string]string) common.TapEvent {
+		return createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_RequestInit_{
+					RequestInit: &common.TapEvent_Http_RequestInit{
+						Id:        &common.TapEvent_Http_StreamId{Base: base},
+						Authority: "voting-svc.emojivoto:8080",
+						Path:      path,
+					},
+				},
+			},
+			dstMeta,
+		)
+	}
+	responseEnd := func(base uint32) common.TapEvent {
+		return createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id: &common.TapEvent_Http_StreamId{Base: base},
+						Eos: &common.Eos{
+							End: &common.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.OK)},
+						},
+					},
+				},
+			},
+			map[string]string{},
+		)
+	}
+
+	events := []common.TapEvent{
+		requestInit(1, "/emojivoto.v1.VotingService/VoteDoughnut", map[string]string{"deployment": "voting", "namespace": "emojivoto"}),
+		requestInit(2, "/emojivoto.v1.EmojiService/ListAll", map[string]string{"deployment": "emoji", "namespace": "emojivoto"}),
+		responseEnd(2),
+		responseEnd(1),
+	}
+	votingEvents := []common.TapEvent{events[0], events[3]}
+
+	dir, err := ioutil.TempDir("", "tap")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	recording := filepath.Join(dir, "session.tap")
+
+	options := newTapOptions()
+	options.record = recording
+	req, err := buildTapByResourceRequest([]string{k8s.Deployments, "web"}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mockApiClient := &public.MockConduitApiClient{}
+	mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+		TapEventsToReturn: events,
+	}
+	err = requestTapByResourceFromAPI(bytes.NewBufferString(""), mockApiClient, req, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectReplay := func(t *testing.T, options *tapOptions, expectedEvents []common.TapEvent) {
+		file, err := os.Open(recording)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer file.Close()
+
+		writer := bytes.NewBufferString("")
+		err = replayTap(writer, file, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput := ""
+		for i := range expectedEvents {
+			expectedOutput += renderTapEvent(&expectedEvents[i]) + "\n"
+		}
+		if writer.String() != expectedOutput {
+			t.Fatalf("Expected replay to render:\n%s\nbut got:\n%s", expectedOutput, writer.String())
+		}
+	}
+
+	t.Run("Replays all of the recorded events", func(t *testing.T) {
+		expectReplay(t, newTapOptions(), events)
+	})
+
+	t.Run("Replays the events of requests that match the path", func(t *testing.T) {
+		options := newTapOptions()
+		options.path = "/emojivoto.v1.VotingService/"
+		expectReplay(t, options, votingEvents)
+	})
+
+	t.Run("Replays the events of requests to the destination", func(t *testing.T) {
+		options := newTapOptions()
+		options.namespace = "emojivoto"
+		options.toResource = "deploy/voting"
+		options.toNamespace = "emojivoto"
+		expectReplay(t, options, votingEvents)
+	})
+
+	t.Run("Returns an error for source filters", func(t *testing.T) {
+		options := newTapOptions()
+		options.fromResource = "deploy/vote-bot"
+
+		err := replayTap(bytes.NewBufferString(""), bytes.NewBuffer([]byte{}), options)
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+}
+
+func TestTapCorrelator(t *testing.T) {
+	requestInit := func(base uint32) *common.TapEvent {
+		event := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_RequestInit_{
+					RequestInit: &common.TapEvent_Http_RequestInit{
+						Id: &common.TapEvent_Http_StreamId{Base: base},
+					},
+				},
+			},
+			map[string]string{},
+		)
+		return &event
+	}
+	responseEnd := func(base uint32) *common.TapEvent {
+		event := createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id: &common.TapEvent_Http_StreamId{Base: base},
+					},
+				},
+			},
+			map[string]string{},
+		)
+		return &event
+	}
+	expectBases := func(t *testing.T, requests []*tapRequest, expected ...uint32) {
+		if len(requests) != len(expected) {
+			t.Fatalf("Expected [%d] requests, got [%d]", len(expected), len(requests))
+		}
+		for i, req := range requests {
+			base := req.event.GetHttp().GetRequestInit().GetId().GetBase()
+			if base != expected[i] {
+				t.Fatalf("Expected request [%d] to have base [%d], got [%d]", i, expected[i], base)
+			}
+		}
+	}
+
+	start := time.Now()
+	correlator := newTapCorrelator(10 * time.Second)
+
+	if correlator.add(requestInit(1), start) != nil {
+		t.Fatalf("Expected a request to be incomplete without its response")
+	}
+	correlator.add(requestInit(2), start.Add(2*time.Second))
+	correlator.add(requestInit(3), start.Add(4*time.Second))
+
+	if req := correlator.add(responseEnd(2), start.Add(5*time.Second)); req == nil || req.responseEnd == nil {
+		t.Fatalf("Expected the response to complete its request, got [%v]", req)
+	}
+	if correlator.add(responseEnd(4), start.Add(5*time.Second)) != nil {
+		t.Fatalf("Expected a response without a request to be dropped")
+	}
+
+	expectBases(t, correlator.expire(start.Add(9*time.Second)))
+	expectBases(t, correlator.expire(start.Add(11*time.Second)), 1)
+	expectBases(t, correlator.flush(), 3)
+	expectBases(t, correlator.flush())
+}
+
+func TestTapHar(t *testing.T) {
+	dstMeta := map[string]string{
+		"pod": "my-pod",
+		"tls": "true",
+	}
+	requestInit := createEvent(
+		&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_RequestInit_{
+				RequestInit: &common.TapEvent_Http_RequestInit{
+					Id: &common.TapEvent_Http_StreamId{Base: 1},
+					Method: &common.HttpMethod{
+						Type: &common.HttpMethod_Registered_{Registered: common.HttpMethod_POST},
+					},
+					Scheme: &common.Scheme{
+						Type: &common.Scheme_Registered_{Registered: common.Scheme_HTTPS},
+					},
+					Authority: "web.example.com",
+					Path:      "/api/vote",
+					Headers: []*common.TapEvent_Http_Header{
+						{Name: "x-tenant-id", Value: "42"},
+					},
+				},
+			},
+		},
+		dstMeta,
+	)
+	responseInit := createEvent(
+		&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_ResponseInit_{
+				ResponseInit: &common.TapEvent_Http_ResponseInit{
+					Id:               &common.TapEvent_Http_StreamId{Base: 1},
+					SinceRequestInit: &duration.Duration{Nanos: 2000000},
+					HttpStatus:       200,
+				},
+			},
+		},
+		dstMeta,
+	)
+	responseEnd := createEvent(
+		&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+					Id: &common.TapEvent_Http_StreamId{Base: 1},
+					Eos: &common.Eos{
+						End: &common.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.OK)},
+					},
+					SinceRequestInit:  &duration.Duration{Nanos: 5000000},
+					SinceResponseInit: &duration.Duration{Nanos: 3000000},
+					ResponseBytes:     1337,
+				},
+			},
+		},
+		dstMeta,
+	)
+	incompleteRequestInit := createEvent(
+		&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_RequestInit_{
+				RequestInit: &common.TapEvent_Http_RequestInit{
+					Id:   &common.TapEvent_Http_StreamId{Base: 2},
+					Path: "/",
+				},
+			},
+		},
+		dstMeta,
+	)
+
+	t.Run("Should render requests and their responses", func(t *testing.T) {
+		start := time.Date(2018, time.May, 1, 12, 0, 0, 0, time.UTC)
+		correlator := newTapCorrelator(0)
+		correlator.add(&requestInit, start)
+		correlator.add(&incompleteRequestInit, start.Add(1500*time.Millisecond))
+		correlator.add(&responseInit, start.Add(2*time.Second))
+		req := correlator.add(&responseEnd, start.Add(3*time.Second))
+		if req == nil {
+			t.Fatalf("Expected the response to complete its request")
+		}
+
+		writer := bytes.NewBufferString("")
+		err := writeHar(writer, append(correlator.flush(), req))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		goldenFileBytes, err := ioutil.ReadFile("testdata/tap_har_output.golden")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectedContent := string(goldenFileBytes)
+		output := writer.String()
+		if expectedContent != output {
+			t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+		}
+	})
+
+	t.Run("Should write the requests received before an interrupt", func(t *testing.T) {
+		interrupt := make(chan os.Signal, 1)
+		tapClient := &interruptingTapEventReceiver{
+			events:    []*common.TapEvent{&incompleteRequestInit},
+			interrupt: interrupt,
+			ended:     make(chan struct{}),
+		}
+		defer close(tapClient.ended)
+
+		writer := bytes.NewBufferString("")
+		err := writeHarTapEvents(tapClient, writer, interrupt)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var rendered har
+		err = json.Unmarshal(writer.Bytes(), &rendered)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(rendered.Log.Entries) != 1 {
+			t.Fatalf("Expected [1] entry, got [%d]", len(rendered.Log.Entries))
+		}
+		entry := rendered.Log.Entries[0]
+		if entry.Request.URL != "http://0.0.0.9:0/" || entry.Response.Status != 0 {
+			t.Fatalf("Expected an incomplete request to http://0.0.0.9:0/, got [%+v] [%+v]", entry.Request, entry.Response)
+		}
+	})
+}
+
+// interruptingTapEventReceiver receives its events, and then sends an interrupt
+// and blocks until it is ended, as a tap that is interrupted would.
+type interruptingTapEventReceiver struct {
+	events    []*common.TapEvent
+	interrupt chan os.Signal
+	ended     chan struct{}
+}
+
+func (r *interruptingTapEventReceiver) Recv() (*common.TapEvent, error) {
+	if len(r.events) != 0 {
+		event := r.events[0]
+		r.events = r.events[1:]
+		return event, nil
+	}
+	r.interrupt <- os.Interrupt
+	<-r.ended
+	return nil, io.EOF
+}
+
+func TestTapStatusWarnings(t *testing.T) {
+	statusEvent := func(state common.TapEvent_TapStatus_State, err string) *common.TapEvent {
+		return &common.TapEvent{
+			Destination: &common.TcpAddress{
+				Ip:   &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: uint32(9)}},
+				Port: 4190,
+			},
+			DestinationMeta: &common.TapEvent_EndpointMeta{
+				Labels: map[string]string{"pod": "web-1", "namespace": "emojivoto"},
+			},
+			Event: &common.TapEvent_TapStatus_{
+				TapStatus: &common.TapEvent_TapStatus{State: state, Error: err},
+			},
+		}
+	}
+
+	warnings := make(tapStatusWarnings)
+	for _, tt := range []struct {
+		event    *common.TapEvent
+		expected string
+	}{
+		{statusEvent(common.TapEvent_TapStatus_CONNECTED, ""), ""},
+		{statusEvent(common.TapEvent_TapStatus_RETRYING, "connection refused"), "Warning: tap of pod emojivoto/web-1 failed, retrying: connection refused"},
+		{statusEvent(common.TapEvent_TapStatus_CONNECTED, ""), "Tap of pod emojivoto/web-1 reconnected"},
+		{statusEvent(common.TapEvent_TapStatus_FAILED, "unknown service"), "Warning: tap of pod emojivoto/web-1 failed: unknown service"},
+	} {
+		actual := warnings.render(tt.event)
+		if actual != tt.expected {
+			t.Fatalf("Expected warning [%s], got [%s]", tt.expected, actual)
+		}
+	}
+}
+
 func TestEventToString(t *testing.T) {
 	toTapEvent := func(httpEvent *common.TapEvent_Http) *common.TapEvent {
 		streamId := &common.TapEvent_Http_StreamId{
@@ -224,6 +849,56 @@ func TestEventToString(t *testing.T) {
 		}
 	})
 
+	t.Run("Converts HTTP request init event with headers to string", func(t *testing.T) {
+		event := toTapEvent(&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_RequestInit_{
+				RequestInit: &common.TapEvent_Http_RequestInit{
+					Method: &common.HttpMethod{
+						Type: &common.HttpMethod_Registered_{
+							Registered: common.HttpMethod_GET,
+						},
+					},
+					Authority: "hello.default:7777",
+					Path:      "/",
+					Headers: []*common.TapEvent_Http_Header{
+						{Name: "x-tenant-id", Value: "42"},
+						{Name: "user-agent", Value: "curl/7.54.0"},
+					},
+				},
+			},
+		})
+
+		expectedOutput := "req id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls= :method=GET :authority=hello.default:7777 :path=/ x-tenant-id=42 user-agent=curl/7.54.0"
+		output := renderTapEvent(event)
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+	})
+
+	t.Run("Converts HTTP request init event with IPv6 addresses to string", func(t *testing.T) {
+		event := toTapEvent(&common.TapEvent_Http{
+			Event: &common.TapEvent_Http_RequestInit_{
+				RequestInit: &common.TapEvent_Http_RequestInit{
+					Method: &common.HttpMethod{
+						Type: &common.HttpMethod_Registered_{
+							Registered: common.HttpMethod_GET,
+						},
+					},
+					Authority: "hello.default:7777",
+					Path:      "/",
+				},
+			},
+		})
+		event.Source.Ip = addr.IPV6(0xfd00000000000000, 1)
+		event.Destination.Ip = addr.IPV6(0xfd00000000000000, 2)
+
+		expectedOutput := "req id=7:8 proxy=out src=[fd00::1]:5555 dst=[fd00::2]:6666 tls= :method=GET :authority=hello.default:7777 :path=/"
+		output := renderTapEvent(event)
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+	})
+
 	t.Run("Converts HTTP response init event to string", func(t *testing.T) {
 		event := toTapEvent(&common.TapEvent_Http{
 			Event: &common.TapEvent_Http_ResponseInit_{
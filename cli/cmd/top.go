@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/runconduit/conduit/controller/api/util"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/pkg/addr"
+	"github.com/spf13/cobra"
+)
+
+type topOptions struct {
+	tap     *tapOptions
+	sortBy  string
+	maxRows int
+}
+
+const (
+	topSortByCount       = "count"
+	topSortBySuccess     = "success"
+	topSortByLatency     = "latency"
+	topSortByMaxLatency  = "max-latency"
+	topSortBySource      = "source"
+	topSortByDestination = "destination"
+	topSortByMethod      = "method"
+	topSortByPath        = "path"
+
+	// topRefreshInterval is how often the table is redrawn.
+	topRefreshInterval = time.Second
+
+	// clearScreen moves the cursor to the top left of the terminal and clears
+	// it.
+	clearScreen = "\033[H\033[2J"
+)
+
+var topSortColumns = []string{
+	topSortByCount,
+	topSortBySuccess,
+	topSortByLatency,
+	topSortByMaxLatency,
+	topSortBySource,
+	topSortByDestination,
+	topSortByMethod,
+	topSortByPath,
+}
+
+func newTopOptions() *topOptions {
+	return &topOptions{
+		tap:     newTapOptions(),
+		sortBy:  topSortByCount,
+		maxRows: 20,
+	}
+}
+
+func newCmdTop() *cobra.Command {
+	options := newTopOptions()
+
+	cmd := &cobra.Command{
+		Use:   "top [flags] (RESOURCE)",
+		Short: "Display a live table of the busiest requests of a traffic stream",
+		Long: `Display a live table of the busiest requests of a traffic stream.
+
+  The RESOURCE argument specifies the target resource(s) to tap, as in
+  "conduit tap". The requests of the tap are grouped by their source,
+  destination, method and path, and the table of the groups is refreshed
+  every second until the tap ends.
+
+  Each row shows the number of requests that completed, the percentage of them
+  that succeeded, and their average and maximum latency. A request succeeded
+  if its gRPC status is OK, or if it isn't a gRPC request and its HTTP status
+  is below 500.
+
+  Requests whose response hasn't ended after the flush timeout are dropped
+  without being counted.
+
+  Rows can be sorted by any column with "--sort-by". The count, success and
+  latency columns are sorted in descending order, and the others in ascending
+  order.`,
+		Example: `  # display the busiest requests of the web deployment in the default namespace
+  conduit top deploy/web
+
+  # display the slowest requests from the web deployment to the voting service
+  conduit top deploy/web --to svc/voting-svc --sort-by latency`,
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !contains(topSortColumns, options.sortBy) {
+				return fmt.Errorf("invalid sort column [%s]; must be one of: %s", options.sortBy, strings.Join(topSortColumns, ", "))
+			}
+
+			req, err := buildTapByResourceRequest(args, options.tap)
+			if err != nil {
+				return err
+			}
+
+			client, err := newPublicAPIClient()
+			if err != nil {
+				return err
+			}
+
+			rsp, err := client.TapByResource(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return watchTop(os.Stdout, rsp, options)
+		},
+	}
+
+	addTapFilterFlags(cmd.PersistentFlags(), options.tap)
+	cmd.PersistentFlags().StringVar(&options.sortBy, "sort-by", options.sortBy,
+		fmt.Sprintf("Column to sort the rows by; one of: %s", strings.Join(topSortColumns, ", ")))
+	cmd.PersistentFlags().IntVar(&options.maxRows, "rows", options.maxRows,
+		"Maximum number of rows to display; 0 displays all of them")
+	cmd.PersistentFlags().DurationVar(&options.tap.flushTimeout, "flush-timeout", options.tap.flushTimeout,
+		"How long to wait for the response of a request, before dropping the request")
+
+	return cmd
+}
+
+// watchTop aggregates the events of a tap and redraws their table every
+// topRefreshInterval, and once more when the tap ends.
+func watchTop(w io.Writer, tapClient tapEventReceiver, options *topOptions) error {
+	events, done := receiveTapEvents(tapClient)
+
+	table := newTopTable(options.tap.flushTimeout)
+	ticker := time.NewTicker(topRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			table.add(event, time.Now())
+
+		case now := <-ticker.C:
+			table.expire(now)
+			fmt.Fprint(w, clearScreen+renderTopTable(table, options))
+
+		case err := <-done:
+			fmt.Fprint(w, clearScreen+renderTopTable(table, options))
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// topRowKey identifies the requests that are aggregated into a row.
+type topRowKey struct {
+	source      string
+	destination string
+	method      string
+	path        string
+}
+
+type topRow struct {
+	key          topRowKey
+	count        uint64
+	successes    uint64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+func (r *topRow) successRate() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return float64(r.successes) / float64(r.count)
+}
+
+func (r *topRow) averageLatency() time.Duration {
+	if r.count == 0 {
+		return 0
+	}
+	return r.totalLatency / time.Duration(r.count)
+}
+
+// topTable pairs the events of each request, and aggregates the completed
+// requests into rows.
+type topTable struct {
+	rows       map[topRowKey]*topRow
+	correlator *tapCorrelator
+}
+
+// newTopTable returns a table that drops requests whose response hasn't ended
+// `timeout` after they were received, once expire is called.
+func newTopTable(timeout time.Duration) *topTable {
+	return &topTable{
+		rows:       make(map[topRowKey]*topRow),
+		correlator: newTapCorrelator(timeout),
+	}
+}
+
+// add adds an event received at `now`, and counts its request if the event
+// completes it.
+func (t *topTable) add(event *common.TapEvent, now time.Time) {
+	req := t.correlator.add(event, now)
+	if req == nil {
+		return
+	}
+
+	key := newTopRowKey(req.event)
+	row, ok := t.rows[key]
+	if !ok {
+		row = &topRow{key: key}
+		t.rows[key] = row
+	}
+
+	latency := time.Duration(durationNanos(req.responseEnd.GetSinceRequestInit()))
+	row.count++
+	row.totalLatency += latency
+	if latency > row.maxLatency {
+		row.maxLatency = latency
+	}
+	if topRequestSucceeded(req.responseInit.GetHttpStatus(), req.responseEnd.GetEos()) {
+		row.successes++
+	}
+}
+
+// expire drops the requests that have been waiting for their response for
+// longer than the table's timeout.
+func (t *topTable) expire(now time.Time) {
+	t.correlator.expire(now)
+}
+
+// newTopRowKey returns the key of the row that the request of a RequestInit
+// event is aggregated into.
+func newTopRowKey(event *common.TapEvent) topRowKey {
+	dst := addr.AddressToString(event.GetDestination())
+	if pod := event.GetDestinationMeta().GetLabels()["pod"]; pod != "" {
+		dst = fmt.Sprintf("%s:%d", pod, event.GetDestination().GetPort())
+	}
+	return topRowKey{
+		source:      addr.IPToString(event.GetSource().GetIp()),
+		destination: dst,
+		method:      methodString(event.GetHttp().GetRequestInit().GetMethod()),
+		path:        event.GetHttp().GetRequestInit().GetPath(),
+	}
+}
+
+func topRequestSucceeded(httpStatus uint32, eos *common.Eos) bool {
+	switch end := eos.GetEnd().(type) {
+	case *common.Eos_GrpcStatusCode:
+		return end.GrpcStatusCode == 0
+	case *common.Eos_ResetErrorCode:
+		return false
+	}
+	return httpStatus != 0 && httpStatus < 500
+}
+
+// sortedRows returns the rows of the table sorted by a column. Rows that tie
+// are sorted by their key, so that the order is stable between refreshes.
+func (t *topTable) sortedRows(sortBy string) []*topRow {
+	rows := make([]*topRow, 0, len(t.rows))
+	for _, row := range t.rows {
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch sortBy {
+		case topSortByCount:
+			if a.count != b.count {
+				return a.count > b.count
+			}
+		case topSortBySuccess:
+			if a.successRate() != b.successRate() {
+				return a.successRate() > b.successRate()
+			}
+		case topSortByLatency:
+			if a.averageLatency() != b.averageLatency() {
+				return a.averageLatency() > b.averageLatency()
+			}
+		case topSortByMaxLatency:
+			if a.maxLatency != b.maxLatency {
+				return a.maxLatency > b.maxLatency
+			}
+		case topSortByDestination:
+			if a.key.destination != b.key.destination {
+				return a.key.destination < b.key.destination
+			}
+		case topSortByMethod:
+			if a.key.method != b.key.method {
+				return a.key.method < b.key.method
+			}
+		case topSortByPath:
+			if a.key.path != b.key.path {
+				return a.key.path < b.key.path
+			}
+		}
+		// sorting by source is the same as sorting by the key
+		return topRowKeyLess(a.key, b.key)
+	})
+
+	return rows
+}
+
+func topRowKeyLess(a, b topRowKey) bool {
+	if a.source != b.source {
+		return a.source < b.source
+	}
+	if a.destination != b.destination {
+		return a.destination < b.destination
+	}
+	if a.method != b.method {
+		return a.method < b.method
+	}
+	return a.path < b.path
+}
+
+func renderTopTable(table *topTable, options *topOptions) string {
+	rows := table.sortedRows(options.sortBy)
+	if options.maxRows > 0 && len(rows) > options.maxRows {
+		rows = rows[:options.maxRows]
+	}
+
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tDESTINATION\tMETHOD\tPATH\tCOUNT\tSUCCESS\tLATENCY\tMAX_LATENCY\t")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%.2f%%\t%s\t%s\t\n",
+			row.key.source,
+			row.key.destination,
+			row.key.method,
+			row.key.path,
+			row.count,
+			row.successRate()*100,
+			formatTopLatency(row.averageLatency()),
+			formatTopLatency(row.maxLatency),
+		)
+	}
+	w.Flush()
+
+	return buffer.String()
+}
+
+func formatTopLatency(latency time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(latency)/float64(time.Millisecond))
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/runconduit/conduit/controller/api/public"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"google.golang.org/grpc/codes"
+)
+
+func TestTopTable(t *testing.T) {
+	requestInit := func(stream uint64, path string) common.TapEvent {
+		return createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_RequestInit_{
+					RequestInit: &common.TapEvent_Http_RequestInit{
+						Id: &common.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						Method: &common.HttpMethod{
+							Type: &common.HttpMethod_Registered_{
+								Registered: common.HttpMethod_POST,
+							},
+						},
+						Path: path,
+					},
+				},
+			},
+			map[string]string{"pod": "voting-7f8b9d"},
+		)
+	}
+	responseInit := func(stream uint64, status uint32) common.TapEvent {
+		return createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseInit_{
+					ResponseInit: &common.TapEvent_Http_ResponseInit{
+						Id:         &common.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						HttpStatus: status,
+					},
+				},
+			},
+			map[string]string{},
+		)
+	}
+	responseEnd := func(stream uint64, latencyMs int32, eos *common.Eos) common.TapEvent {
+		return createEvent(
+			&common.TapEvent_Http{
+				Event: &common.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &common.TapEvent_Http_ResponseEnd{
+						Id:               &common.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+						SinceRequestInit: &duration.Duration{Nanos: latencyMs * 1000000},
+						Eos:              eos,
+					},
+				},
+			},
+			map[string]string{},
+		)
+	}
+	grpcStatus := func(code codes.Code) *common.Eos {
+		return &common.Eos{End: &common.Eos_GrpcStatusCode{GrpcStatusCode: uint32(code)}}
+	}
+
+	events := []common.TapEvent{
+		requestInit(1, "/emojivoto.v1.VotingService/VoteDoughnut"),
+		requestInit(2, "/emojivoto.v1.VotingService/VoteDoughnut"),
+		requestInit(3, "/emojivoto.v1.VotingService/Leaderboard"),
+		requestInit(4, "/emojivoto.v1.VotingService/VoteDoughnut"),
+		responseInit(1, 200),
+		responseEnd(1, 2, grpcStatus(codes.OK)),
+		responseInit(2, 200),
+		responseEnd(2, 8, grpcStatus(codes.Unavailable)),
+		responseInit(3, 200),
+		responseEnd(3, 20, nil),
+		// the response of stream 4 hasn't ended, so it isn't counted
+		responseInit(4, 500),
+	}
+
+	expectRows := func(t *testing.T, sortBy string, expected ...string) {
+		table := newTopTable(time.Minute)
+		for i := range events {
+			table.add(&events[i], time.Now())
+		}
+
+		rows := table.sortedRows(sortBy)
+		if len(rows) != len(expected) {
+			t.Fatalf("Expected [%d] rows, got [%d]", len(expected), len(rows))
+		}
+		for i, row := range rows {
+			if row.key.path != expected[i] {
+				t.Fatalf("Expected row [%d] to have path [%s], got [%s]", i, expected[i], row.key.path)
+			}
+		}
+	}
+
+	t.Run("Sorts rows by count", func(t *testing.T) {
+		expectRows(t, topSortByCount, "/emojivoto.v1.VotingService/VoteDoughnut", "/emojivoto.v1.VotingService/Leaderboard")
+	})
+
+	t.Run("Sorts rows by success rate", func(t *testing.T) {
+		expectRows(t, topSortBySuccess, "/emojivoto.v1.VotingService/Leaderboard", "/emojivoto.v1.VotingService/VoteDoughnut")
+	})
+
+	t.Run("Sorts rows by path", func(t *testing.T) {
+		expectRows(t, topSortByPath, "/emojivoto.v1.VotingService/Leaderboard", "/emojivoto.v1.VotingService/VoteDoughnut")
+	})
+
+	t.Run("Drops requests whose response hasn't ended after the timeout", func(t *testing.T) {
+		table := newTopTable(time.Minute)
+		start := time.Now()
+		for i := range events {
+			table.add(&events[i], start)
+		}
+
+		table.expire(start.Add(time.Minute))
+		if len(table.correlator.pending) != 0 {
+			t.Fatalf("Expected no pending requests, got [%d]", len(table.correlator.pending))
+		}
+
+		// a late response doesn't count the dropped request
+		end := responseEnd(4, 1, nil)
+		table.add(&end, start.Add(time.Minute))
+		if rows := table.sortedRows(topSortByCount); rows[0].count != 2 {
+			t.Fatalf("Expected the busiest row to have a count of [2], got [%d]", rows[0].count)
+		}
+	})
+
+	t.Run("Renders the table when the tap ends", func(t *testing.T) {
+		mockApiClient := &public.MockApi_TapByResourceClient{
+			TapEventsToReturn: events,
+		}
+		writer := bytes.NewBufferString("")
+		err := watchTop(writer, mockApiClient, newTopOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedOutput := clearScreen +
+			"SOURCE    DESTINATION       METHOD   PATH                                       COUNT   SUCCESS   LATENCY   MAX_LATENCY   \n" +
+			"0.0.0.1   voting-7f8b9d:0   POST     /emojivoto.v1.VotingService/VoteDoughnut   2       50.00%    5.0ms     8.0ms         \n" +
+			"0.0.0.1   voting-7f8b9d:0   POST     /emojivoto.v1.VotingService/Leaderboard    1       100.00%   20.0ms    20.0ms        \n"
+		if writer.String() != expectedOutput {
+			t.Fatalf("Expected output:\n%q\nbut got:\n%q", expectedOutput, writer.String())
+		}
+	})
+}
@@ -26,7 +26,10 @@ rules:
   resources: ["deployments", "replicasets"]
   verbs: ["list", "get", "watch"]
 - apiGroups: [""]
-  resources: ["pods", "endpoints", "services", "namespaces", "replicationcontrollers"]
+  resources: ["pods", "endpoints", "services", "namespaces", "nodes", "replicationcontrollers", "configmaps"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["conduit.io"]
+  resources: ["serviceprofiles"]
   verbs: ["list", "get", "watch"]
 
 ---
@@ -76,6 +79,23 @@ subjects:
   name: conduit-prometheus
   namespace: {{.Namespace}}
 
+### Service Profile CRD ###
+---
+kind: CustomResourceDefinition
+apiVersion: apiextensions.k8s.io/v1beta1
+metadata:
+  name: serviceprofiles.conduit.io
+spec:
+  group: conduit.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: serviceprofiles
+    singular: serviceprofile
+    kind: ServiceProfile
+    shortNames:
+    - sp
+
 ### Controller ###
 ---
 kind: Service
@@ -135,6 +155,11 @@ spec:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
     spec:
       serviceAccount: conduit-controller
+      volumes:
+      - name: remote-clusters
+        secret:
+          secretName: conduit-remote-clusters
+          optional: true
       containers:
       - name: public-api
         ports:
@@ -166,11 +191,16 @@ spec:
           containerPort: 8089
         - name: admin-http
           containerPort: 9999
+        volumeMounts:
+        - name: remote-clusters
+          mountPath: /var/run/conduit/remote-clusters
+          readOnly: true
         image: {{.ControllerImage}}
         imagePullPolicy: {{.ImagePullPolicy}}
         args:
         - "destination"
         - "-enable-tls={{.EnableTLS}}"
+        - "-remote-kubeconfig-dir=/var/run/conduit/remote-clusters"
         - "-log-level={{.ControllerLogLevel}}"
         - "-logtostderr=true"
         livenessProbe:
@@ -14,9 +14,13 @@ import (
 type (
 	server struct {
 		destinationClient destination.DestinationClient
+		subscriptions     *subscriptions
 	}
 )
 
+// Get streams the updates of a destination from an upstream subscription that
+// is shared with all other proxies that ask for the same destination. Proxies
+// that join late are sent the destination's current state first.
 func (s *server) Get(dest *common.Destination, stream destination.Destination_GetServer) error {
 	log := log.WithFields(
 		log.Fields{
@@ -25,13 +29,57 @@ func (s *server) Get(dest *common.Destination, stream destination.Destination_Ge
 		})
 	log.Debug("Get")
 
-	rsp, err := s.destinationClient.Get(stream.Context(), dest)
+	key := destinationKey{
+		scheme:    dest.Scheme,
+		path:      dest.Path,
+		namespace: dest.CallerNamespace,
+		node:      dest.CallerNode,
+	}
+	downstream, err := s.subscriptions.subscribe(key)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	defer s.subscriptions.unsubscribe(downstream)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Debug("Get canceled")
+			return nil
+		case <-downstream.done:
+			if downstream.err != nil {
+				log.Error(downstream.err)
+				return downstream.err
+			}
+			log.Debug("Get complete")
+			return nil
+		case update := <-downstream.updates:
+			log.Debugf("Get update: %v", update)
+			err := stream.Send(update)
+			if err != nil {
+				log.Error(err)
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) GetProfile(dest *common.Destination, stream destination.Destination_GetProfileServer) error {
+	log := log.WithFields(
+		log.Fields{
+			"scheme": dest.Scheme,
+			"path":   dest.Path,
+		})
+	log.Debug("GetProfile")
+
+	rsp, err := s.destinationClient.GetProfile(stream.Context(), dest)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 	for {
-		update, err := rsp.Recv()
+		profile, err := rsp.Recv()
 		if err == io.EOF {
 			break
 		}
@@ -40,11 +88,15 @@ func (s *server) Get(dest *common.Destination, stream destination.Destination_Ge
 			return err
 		}
 
-		log.Debug("Get update: %v", update)
-		stream.Send(update)
+		log.Debugf("GetProfile update: %v", profile)
+		err = stream.Send(profile)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
 	}
 
-	log.Debug("Get complete")
+	log.Debug("GetProfile complete")
 	return nil
 }
 
@@ -59,7 +111,10 @@ func NewServer(addr string, destinationClient destination.DestinationClient) (*g
 	}
 
 	s := prometheus.NewGrpcServer()
-	srv := server{destinationClient: destinationClient}
+	srv := server{
+		destinationClient: destinationClient,
+		subscriptions:     newSubscriptions(destinationClient),
+	}
 	destination.RegisterDestinationServer(s, &srv)
 
 	return s, lis, nil
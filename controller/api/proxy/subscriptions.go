@@ -0,0 +1,302 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	destination "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/pkg/addr"
+	log "github.com/sirupsen/logrus"
+)
+
+// downstreamBufferSize is the number of updates that may be waiting to be sent
+// to a proxy before its stream is closed because it isn't keeping up.
+const downstreamBufferSize = 100
+
+var errSlowDownstream = errors.New("stream closed after falling too far behind destination updates")
+
+// destinationKey identifies the destinations that can share an upstream
+// subscription, because the destination service answers them identically.
+type destinationKey struct {
+	scheme string
+	path   string
+	// the namespace that names which aren't fully qualified are relative to,
+	// if the proxy gave one
+	namespace string
+	// the node the proxy runs on, which endpoints may be weighted towards
+	node string
+}
+
+// subscriptions multiplexes the Get streams of all proxies onto one upstream
+// Get stream per destination. The upstream stream is opened by the first
+// proxy to ask for a destination, and closed when the last one leaves.
+//
+// Since an upstream stream is shared by many proxies, only the parts of a
+// request that the destination service's answer depends on are forwarded:
+// proxies share a stream only if they're in the same namespace and on the
+// same node.
+type subscriptions struct {
+	destinationClient destination.DestinationClient
+	active            map[destinationKey]*subscription
+	// This mutex protects the active map. It is always taken before the
+	// mutex of a subscription.
+	mutex sync.Mutex
+}
+
+func newSubscriptions(destinationClient destination.DestinationClient) *subscriptions {
+	return &subscriptions{
+		destinationClient: destinationClient,
+		active:            make(map[destinationKey]*subscription),
+	}
+}
+
+// subscribe returns a downstream that receives the updates of a destination,
+// starting with the destination's current state if it is already known.
+func (s *subscriptions) subscribe(key destinationKey) (*downstream, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sub, ok := s.active[key]
+	if !ok {
+		var err error
+		sub, err = s.open(key)
+		if err != nil {
+			return nil, err
+		}
+		s.active[key] = sub
+	}
+	return sub.add(), nil
+}
+
+// unsubscribe removes a downstream from its subscription, and closes the
+// upstream stream if no other downstreams are left.
+func (s *subscriptions) unsubscribe(d *downstream) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sub := d.subscription
+	if sub.remove(d) == 0 && s.active[sub.key] == sub {
+		log.Debugf("Closing upstream subscription to %s", sub.key.path)
+		delete(s.active, sub.key)
+		sub.cancel()
+	}
+}
+
+// open starts an upstream Get stream for a destination. The caller must hold
+// s.mutex.
+func (s *subscriptions) open(key destinationKey) (*subscription, error) {
+	log.Debugf("Opening upstream subscription to %s", key.path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dest := &common.Destination{
+		Scheme:          key.scheme,
+		Path:            key.path,
+		CallerNamespace: key.namespace,
+		CallerNode:      key.node,
+	}
+
+	rsp, err := s.destinationClient.Get(ctx, dest)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	sub := newSubscription(key, cancel)
+	go s.receive(sub, rsp)
+	return sub, nil
+}
+
+// receive forwards the updates of an upstream stream to the subscription's
+// downstreams until the stream ends. The downstreams are then closed with the
+// stream's error, so that their proxies subscribe again.
+func (s *subscriptions) receive(sub *subscription, rsp destination.Destination_GetClient) {
+	for {
+		update, err := rsp.Recv()
+		if err != nil {
+			// If the subscription is no longer active, its last downstream
+			// left and the stream was canceled on purpose.
+			s.mutex.Lock()
+			active := s.active[sub.key] == sub
+			if active {
+				delete(s.active, sub.key)
+			}
+			s.mutex.Unlock()
+			sub.cancel()
+
+			if err == io.EOF {
+				err = nil
+			} else if active {
+				log.Errorf("Upstream subscription to %s failed: %s", sub.key.path, err)
+			}
+			sub.close(err)
+			return
+		}
+
+		sub.update(update)
+	}
+}
+
+// subscription is an upstream Get stream, along with the current state of its
+// destination, which is replayed to downstreams that join late.
+type subscription struct {
+	key    destinationKey
+	cancel context.CancelFunc
+	// the current addresses of the destination, keyed by address
+	addrs map[string]*destination.WeightedAddr
+	// the metric labels of the last address set that was added
+	metricLabels map[string]string
+	// set if the last update was a NoEndpoints update
+	noEndpoints *destination.NoEndpoints
+	downstreams map[*downstream]struct{}
+	mutex       sync.Mutex
+}
+
+func newSubscription(key destinationKey, cancel context.CancelFunc) *subscription {
+	return &subscription{
+		key:         key,
+		cancel:      cancel,
+		addrs:       make(map[string]*destination.WeightedAddr),
+		downstreams: make(map[*downstream]struct{}),
+	}
+}
+
+// add returns a new downstream, which has the updates that replay the current
+// state already queued.
+func (s *subscription) add() *downstream {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d := newDownstream(s)
+	for _, update := range s.replay() {
+		d.push(update)
+	}
+	s.downstreams[d] = struct{}{}
+	return d
+}
+
+// remove removes a downstream and returns the number of downstreams left.
+func (s *subscription) remove(d *downstream) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.downstreams, d)
+	return len(s.downstreams)
+}
+
+// update applies an update to the current state and queues it for all of the
+// downstreams. Downstreams that can't take any more updates are closed.
+func (s *subscription) update(update *destination.Update) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch u := update.Update.(type) {
+	case *destination.Update_Add:
+		s.noEndpoints = nil
+		s.metricLabels = u.Add.MetricLabels
+		for _, weightedAddr := range u.Add.Addrs {
+			s.addrs[addr.AddressToString(weightedAddr.Addr)] = weightedAddr
+		}
+	case *destination.Update_Remove:
+		for _, address := range u.Remove.Addrs {
+			delete(s.addrs, addr.AddressToString(address))
+		}
+	case *destination.Update_NoEndpoints:
+		s.addrs = make(map[string]*destination.WeightedAddr)
+		s.noEndpoints = u.NoEndpoints
+	}
+
+	for d := range s.downstreams {
+		if !d.push(update) {
+			log.Errorf("Closing downstream subscription to %s with %d queued updates", s.key.path, len(d.updates))
+			delete(s.downstreams, d)
+			d.close(errSlowDownstream)
+		}
+	}
+}
+
+// close closes all of the downstreams with `err`, which is nil if the
+// upstream stream ended normally.
+func (s *subscription) close(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for d := range s.downstreams {
+		delete(s.downstreams, d)
+		d.close(err)
+	}
+}
+
+// replay returns the updates that bring a new downstream up to the current
+// state. The caller must hold s.mutex.
+func (s *subscription) replay() []*destination.Update {
+	if len(s.addrs) > 0 {
+		keys := make([]string, 0, len(s.addrs))
+		for key := range s.addrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		addrs := make([]*destination.WeightedAddr, 0, len(keys))
+		for _, key := range keys {
+			addrs = append(addrs, s.addrs[key])
+		}
+		return []*destination.Update{
+			{
+				Update: &destination.Update_Add{
+					Add: &destination.WeightedAddrSet{
+						Addrs:        addrs,
+						MetricLabels: s.metricLabels,
+					},
+				},
+			},
+		}
+	}
+
+	if s.noEndpoints != nil {
+		return []*destination.Update{
+			{Update: &destination.Update_NoEndpoints{NoEndpoints: s.noEndpoints}},
+		}
+	}
+
+	return nil
+}
+
+// downstream is the end of a subscription that a proxy's Get stream reads
+// from. Once done is closed, err holds the reason, which is nil if the
+// upstream stream ended normally.
+type downstream struct {
+	subscription *subscription
+	updates      chan *destination.Update
+	done         chan struct{}
+	err          error
+}
+
+func newDownstream(sub *subscription) *downstream {
+	return &downstream{
+		subscription: sub,
+		updates:      make(chan *destination.Update, downstreamBufferSize),
+		done:         make(chan struct{}),
+	}
+}
+
+// push queues an update without blocking, and returns false if the queue is
+// full. The caller must hold the subscription's mutex.
+func (d *downstream) push(update *destination.Update) bool {
+	select {
+	case d.updates <- update:
+		return true
+	default:
+		return false
+	}
+}
+
+// close ends the downstream. It is called once, by the subscription, with the
+// subscription's mutex held.
+func (d *downstream) close(err error) {
+	d.err = err
+	close(d.done)
+}
@@ -0,0 +1,260 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	destination "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/pkg/addr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type mockDestination_GetClient struct {
+	ctx     context.Context
+	updates chan *destination.Update
+	err     error
+}
+
+func (m *mockDestination_GetClient) Recv() (*destination.Update, error) {
+	select {
+	case <-m.ctx.Done():
+		return nil, m.ctx.Err()
+	case update, ok := <-m.updates:
+		if !ok {
+			if m.err != nil {
+				return nil, m.err
+			}
+			return nil, io.EOF
+		}
+		return update, nil
+	}
+}
+
+func (m *mockDestination_GetClient) Header() (metadata.MD, error) { return nil, nil }
+func (m *mockDestination_GetClient) Trailer() metadata.MD         { return nil }
+func (m *mockDestination_GetClient) CloseSend() error             { return nil }
+func (m *mockDestination_GetClient) Context() context.Context     { return m.ctx }
+func (m *mockDestination_GetClient) SendMsg(x interface{}) error  { return nil }
+func (m *mockDestination_GetClient) RecvMsg(x interface{}) error  { return nil }
+
+type mockDestinationClient struct {
+	streams  []*mockDestination_GetClient
+	requests []*common.Destination
+	mutex    sync.Mutex
+}
+
+func (m *mockDestinationClient) Get(ctx context.Context, in *common.Destination, opts ...grpc.CallOption) (destination.Destination_GetClient, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stream := &mockDestination_GetClient{ctx: ctx, updates: make(chan *destination.Update, 10)}
+	m.streams = append(m.streams, stream)
+	m.requests = append(m.requests, in)
+	return stream, nil
+}
+
+func (m *mockDestinationClient) GetProfile(ctx context.Context, in *common.Destination, opts ...grpc.CallOption) (destination.Destination_GetProfileClient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDestinationClient) openStreams() []*mockDestination_GetClient {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.streams
+}
+
+func addUpdate(ips ...uint32) *destination.Update {
+	addrs := make([]*destination.WeightedAddr, 0)
+	for _, ip := range ips {
+		addrs = append(addrs, &destination.WeightedAddr{
+			Addr:   &common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: ip}}, Port: 8080},
+			Weight: 1,
+		})
+	}
+	return &destination.Update{Update: &destination.Update_Add{Add: &destination.WeightedAddrSet{Addrs: addrs}}}
+}
+
+func removeUpdate(ips ...uint32) *destination.Update {
+	addrs := make([]*common.TcpAddress, 0)
+	for _, ip := range ips {
+		addrs = append(addrs, &common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: ip}}, Port: 8080})
+	}
+	return &destination.Update{Update: &destination.Update_Remove{Remove: &destination.AddrSet{Addrs: addrs}}}
+}
+
+// nextAddrs returns the addresses of the next update of a downstream, prefixed
+// with "+" for added and "-" for removed addresses.
+func nextAddrs(t *testing.T, d *downstream) []string {
+	select {
+	case update := <-d.updates:
+		addrs := make([]string, 0)
+		switch u := update.Update.(type) {
+		case *destination.Update_Add:
+			for _, weightedAddr := range u.Add.Addrs {
+				addrs = append(addrs, "+"+addr.AddressToString(weightedAddr.Addr))
+			}
+		case *destination.Update_Remove:
+			for _, address := range u.Remove.Addrs {
+				addrs = append(addrs, "-"+addr.AddressToString(address))
+			}
+		case *destination.Update_NoEndpoints:
+			addrs = append(addrs, "none")
+		}
+		return addrs
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected an update, got nothing")
+		return nil
+	}
+}
+
+func expectAddrs(t *testing.T, d *downstream, expected ...string) {
+	actual := nextAddrs(t, d)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected addresses %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected addresses %v, got %v", expected, actual)
+		}
+	}
+}
+
+func expectDone(t *testing.T, d *downstream, expectedErr error) {
+	select {
+	case <-d.done:
+		if d.err != expectedErr {
+			t.Fatalf("Expected error [%v], got [%v]", expectedErr, d.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the downstream to be closed, but it wasn't")
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	web := destinationKey{scheme: "k8s", path: "web.ns.svc.cluster.local:8080"}
+
+	t.Run("Shares one upstream stream and replays the current state to late joiners", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		first, err := subs.subscribe(web)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		upstream := client.openStreams()[0]
+		upstream.updates <- addUpdate(1, 2, 3)
+		upstream.updates <- removeUpdate(2)
+		expectAddrs(t, first, "+0.0.0.1:8080", "+0.0.0.2:8080", "+0.0.0.3:8080")
+		expectAddrs(t, first, "-0.0.0.2:8080")
+
+		second, err := subs.subscribe(web)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectAddrs(t, second, "+0.0.0.1:8080", "+0.0.0.3:8080")
+
+		upstream.updates <- addUpdate(4)
+		expectAddrs(t, first, "+0.0.0.4:8080")
+		expectAddrs(t, second, "+0.0.0.4:8080")
+
+		if len(client.openStreams()) != 1 {
+			t.Fatalf("Expected [1] upstream stream, got [%d]", len(client.openStreams()))
+		}
+	})
+
+	t.Run("Opens separate upstream streams for different destinations", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		keys := []destinationKey{
+			web,
+			{scheme: "k8s", path: "api.ns.svc.cluster.local:8080"},
+			{scheme: "k8s", path: "web:8080", namespace: "ns"},
+			{scheme: "k8s", path: "web:8080", namespace: "ns", node: "node-1"},
+			{scheme: "k8s", path: "web:8080", namespace: "ns", node: "node-2"},
+		}
+		for _, key := range keys {
+			if _, err := subs.subscribe(key); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		if len(client.openStreams()) != len(keys) {
+			t.Fatalf("Expected [%d] upstream streams, got [%d]", len(keys), len(client.openStreams()))
+		}
+	})
+
+	t.Run("Forwards the caller's namespace and node upstream", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		key := destinationKey{scheme: "k8s", path: "web:8080", namespace: "ns", node: "node-1"}
+		if _, err := subs.subscribe(key); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := &common.Destination{Scheme: "k8s", Path: "web:8080", CallerNamespace: "ns", CallerNode: "node-1"}
+		if !reflect.DeepEqual(client.requests[0], expected) {
+			t.Fatalf("Expected upstream request [%v], got [%v]", expected, client.requests[0])
+		}
+	})
+
+	t.Run("Closes the upstream stream when the last downstream leaves", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		first, _ := subs.subscribe(web)
+		second, _ := subs.subscribe(web)
+		upstream := client.openStreams()[0]
+
+		subs.unsubscribe(first)
+		if upstream.ctx.Err() != nil {
+			t.Fatalf("Expected the upstream stream to stay open")
+		}
+
+		subs.unsubscribe(second)
+		if upstream.ctx.Err() == nil {
+			t.Fatalf("Expected the upstream stream to be closed")
+		}
+
+		if _, err := subs.subscribe(web); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(client.openStreams()) != 2 {
+			t.Fatalf("Expected a new upstream stream, got [%d] streams", len(client.openStreams()))
+		}
+	})
+
+	t.Run("Closes the downstreams with the upstream stream's error", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		d, _ := subs.subscribe(web)
+		upstream := client.openStreams()[0]
+		upstream.err = errors.New("upstream failed")
+		close(upstream.updates)
+
+		expectDone(t, d, upstream.err)
+	})
+
+	t.Run("Closes downstreams that fall too far behind", func(t *testing.T) {
+		client := &mockDestinationClient{}
+		subs := newSubscriptions(client)
+
+		slow, _ := subs.subscribe(web)
+		upstream := client.openStreams()[0]
+		for i := 0; i <= downstreamBufferSize; i++ {
+			upstream.updates <- addUpdate(uint32(i))
+		}
+
+		expectDone(t, slow, errSlowDownstream)
+	})
+}
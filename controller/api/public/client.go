@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
 	"github.com/golang/protobuf/proto"
 	common "github.com/runconduit/conduit/controller/gen/common"
 	healthcheckPb "github.com/runconduit/conduit/controller/gen/common/healthcheck"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/pkg/k8s"
 	log "github.com/sirupsen/logrus"
@@ -62,7 +64,27 @@ func (c *grpcOverHttpClient) Tap(ctx context.Context, req *pb.TapRequest, _ ...g
 }
 
 func (c *grpcOverHttpClient) TapByResource(ctx context.Context, req *pb.TapByResourceRequest, _ ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
-	url := c.endpointNameToPublicApiUrl("TapByResource")
+	reader, err := c.streamingApiRequest(ctx, "TapByResource", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tapClient{ctx: ctx, reader: reader}, nil
+}
+
+func (c *grpcOverHttpClient) Endpoints(ctx context.Context, req *common.Destination, _ ...grpc.CallOption) (pb.Api_EndpointsClient, error) {
+	reader, err := c.streamingApiRequest(ctx, "Endpoints", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpointsClient{ctx: ctx, reader: reader}, nil
+}
+
+// streamingApiRequest returns a reader over the stream of messages sent in
+// response to req, which stays open until ctx is done.
+func (c *grpcOverHttpClient) streamingApiRequest(ctx context.Context, endpoint string, req proto.Message) (*bufio.Reader, error) {
+	url := c.endpointNameToPublicApiUrl(endpoint)
 	httpRsp, err := c.post(ctx, url, req)
 	if err != nil {
 		return nil, err
@@ -79,7 +101,7 @@ func (c *grpcOverHttpClient) TapByResource(ctx context.Context, req *pb.TapByRes
 		httpRsp.Body.Close()
 	}()
 
-	return &tapClient{ctx: ctx, reader: bufio.NewReader(httpRsp.Body)}, nil
+	return bufio.NewReader(httpRsp.Body), nil
 }
 
 func (c *grpcOverHttpClient) apiRequest(ctx context.Context, endpoint string, req proto.Message, protoResponse proto.Message) error {
@@ -154,8 +176,30 @@ func (c tapClient) Context() context.Context     { return c.ctx }
 func (c tapClient) SendMsg(interface{}) error    { return nil }
 func (c tapClient) RecvMsg(interface{}) error    { return nil }
 
+type endpointsClient struct {
+	ctx    context.Context
+	reader *bufio.Reader
+}
+
+func (c endpointsClient) Recv() (*destinationPb.Update, error) {
+	var msg destinationPb.Update
+	err := fromByteStreamToProtocolBuffers(c.reader, &msg)
+	return &msg, err
+}
+
+// satisfy the pb.Api_EndpointsClient interface
+func (c endpointsClient) Header() (metadata.MD, error) { return nil, nil }
+func (c endpointsClient) Trailer() metadata.MD         { return nil }
+func (c endpointsClient) CloseSend() error             { return nil }
+func (c endpointsClient) Context() context.Context     { return c.ctx }
+func (c endpointsClient) SendMsg(interface{}) error    { return nil }
+func (c endpointsClient) RecvMsg(interface{}) error    { return nil }
+
 func fromByteStreamToProtocolBuffers(byteStreamContainingMessage *bufio.Reader, out proto.Message) error {
 	messageAsBytes, err := deserializePayloadFromReader(byteStreamContainingMessage)
+	if err == io.EOF {
+		return err
+	}
 	if err != nil {
 		return fmt.Errorf("error reading byte stream header: %v", err)
 	}
@@ -3,13 +3,16 @@ package public
 import (
 	"context"
 	"fmt"
+	"io"
 	"runtime"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	common "github.com/runconduit/conduit/controller/gen/common"
 	healthcheckPb "github.com/runconduit/conduit/controller/gen/common/healthcheck"
 	tapPb "github.com/runconduit/conduit/controller/gen/controller/tap"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
 	pkgK8s "github.com/runconduit/conduit/pkg/k8s"
@@ -25,6 +28,7 @@ type (
 	grpcServer struct {
 		prometheusAPI       promv1.API
 		tapClient           tapPb.TapClient
+		destinationClient   destinationPb.DestinationClient
 		k8sAPI              *k8s.API
 		controllerNamespace string
 		ignoredNamespaces   []string
@@ -47,6 +51,7 @@ const (
 func newGrpcServer(
 	promAPI promv1.API,
 	tapClient tapPb.TapClient,
+	destinationClient destinationPb.DestinationClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
 	ignoredNamespaces []string,
@@ -54,6 +59,7 @@ func newGrpcServer(
 	return &grpcServer{
 		prometheusAPI:       promAPI,
 		tapClient:           tapClient,
+		destinationClient:   destinationClient,
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
 		ignoredNamespaces:   ignoredNamespaces,
@@ -229,6 +235,29 @@ func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_T
 	}
 }
 
+// Pass through to destination service
+func (s *grpcServer) Endpoints(dest *common.Destination, stream pb.Api_EndpointsServer) error {
+	destinationStream, err := s.destinationClient.Get(stream.Context(), dest)
+	if err != nil {
+		log.Errorf("Unexpected error getting endpoints of [%v]: %v", dest, err)
+		return err
+	}
+	for {
+		update, err := destinationStream.Recv()
+		if err != nil {
+			if err == io.EOF || stream.Context().Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		err = stream.Send(update)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (s *grpcServer) shouldIgnore(pod *k8sV1.Pod) bool {
 	for _, namespace := range s.ignoredNamespaces {
 		if pod.Namespace == namespace {
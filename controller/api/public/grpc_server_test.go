@@ -8,6 +8,7 @@ import (
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/prometheus/common/model"
 	tap "github.com/runconduit/conduit/controller/gen/controller/tap"
+	destination "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
 )
@@ -156,6 +157,7 @@ spec:
 			fakeGrpcServer := newGrpcServer(
 				&MockProm{Res: exp.promRes},
 				tap.NewTapClient(nil),
+				destination.NewDestinationClient(nil),
 				k8sAPI,
 				"conduit",
 				[]string{},
@@ -10,6 +10,7 @@ import (
 	common "github.com/runconduit/conduit/controller/gen/common"
 	healthcheckPb "github.com/runconduit/conduit/controller/gen/common/healthcheck"
 	tapPb "github.com/runconduit/conduit/controller/gen/controller/tap"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
 	"github.com/runconduit/conduit/pkg/prometheus"
@@ -23,6 +24,7 @@ var (
 	listPodsPath      = fullUrlPathFor("ListPods")
 	tapByResourcePath = fullUrlPathFor("TapByResource")
 	selfCheckPath     = fullUrlPathFor("SelfCheck")
+	endpointsPath     = fullUrlPathFor("Endpoints")
 )
 
 type handler struct {
@@ -51,6 +53,8 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.handleTapByResource(w, req)
 	case selfCheckPath:
 		h.handleSelfCheck(w, req)
+	case endpointsPath:
+		h.handleEndpoints(w, req)
 	default:
 		http.NotFound(w, req)
 	}
@@ -187,6 +191,52 @@ func (s tapServer) Context() context.Context     { return s.req.Context() }
 func (s tapServer) SendMsg(interface{}) error    { return nil }
 func (s tapServer) RecvMsg(interface{}) error    { return nil }
 
+func (h *handler) handleEndpoints(w http.ResponseWriter, req *http.Request) {
+	flushableWriter, err := newStreamingWriter(w)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	var protoRequest common.Destination
+	err = httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	server := endpointsServer{w: flushableWriter, req: req}
+	err = h.grpcServer.Endpoints(&protoRequest, server)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+type endpointsServer struct {
+	w   flushableResponseWriter
+	req *http.Request
+}
+
+func (s endpointsServer) Send(msg *destinationPb.Update) error {
+	err := writeProtoToHttpResponse(s.w, msg)
+	if err != nil {
+		writeErrorToHttpResponse(s.w, err)
+		return err
+	}
+
+	s.w.Flush()
+	return nil
+}
+
+// satisfy the pb.Api_EndpointsServer interface
+func (s endpointsServer) SetHeader(metadata.MD) error  { return nil }
+func (s endpointsServer) SendHeader(metadata.MD) error { return nil }
+func (s endpointsServer) SetTrailer(metadata.MD)       {}
+func (s endpointsServer) Context() context.Context     { return s.req.Context() }
+func (s endpointsServer) SendMsg(interface{}) error    { return nil }
+func (s endpointsServer) RecvMsg(interface{}) error    { return nil }
+
 func fullUrlPathFor(method string) string {
 	return apiRoot + apiPrefix + method
 }
@@ -195,6 +245,7 @@ func NewServer(
 	addr string,
 	prometheusClient promApi.Client,
 	tapClient tapPb.TapClient,
+	destinationClient destinationPb.DestinationClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
 	ignoredNamespaces []string,
@@ -203,6 +254,7 @@ func NewServer(
 		grpcServer: newGrpcServer(
 			promv1.NewAPI(prometheusClient),
 			tapClient,
+			destinationClient,
 			k8sAPI,
 			controllerNamespace,
 			ignoredNamespaces,
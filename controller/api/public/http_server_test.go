@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	common "github.com/runconduit/conduit/controller/gen/common"
 	healcheckPb "github.com/runconduit/conduit/controller/gen/common/healthcheck"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 )
 
@@ -17,6 +18,7 @@ type mockGrpcServer struct {
 	LastRequestReceived proto.Message
 	ResponseToReturn    proto.Message
 	TapStreamsToReturn  []*common.TapEvent
+	UpdatesToReturn     []*destinationPb.Update
 	ErrorToReturn       error
 }
 
@@ -62,6 +64,17 @@ func (m *mockGrpcServer) TapByResource(req *pb.TapByResourceRequest, tapServer p
 	return m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) Endpoints(req *common.Destination, endpointsServer pb.Api_EndpointsServer) error {
+	m.LastRequestReceived = req
+	if m.ErrorToReturn == nil {
+		for _, msg := range m.UpdatesToReturn {
+			endpointsServer.Send(msg)
+		}
+	}
+
+	return m.ErrorToReturn
+}
+
 type grpcCallTestCase struct {
 	expectedRequest  proto.Message
 	expectedResponse proto.Message
@@ -197,6 +210,72 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("Delegates all streaming endpoints RPC messages to the underlying grpc server", func(t *testing.T) {
+		mockGrpcServer := &mockGrpcServer{}
+
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("Could not start listener: %v", err)
+		}
+
+		go func() {
+			handler := &handler{
+				grpcServer: mockGrpcServer,
+			}
+			err := http.Serve(listener, handler)
+			if err != nil {
+				t.Fatalf("Could not start server: %v", err)
+			}
+		}()
+
+		client, err := NewInternalClient(listener.Addr().String())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedUpdates := []*destinationPb.Update{
+			{
+				Update: &destinationPb.Update_Add{
+					Add: &destinationPb.WeightedAddrSet{
+						Addrs: []*destinationPb.WeightedAddr{
+							{
+								Addr:   &common.TcpAddress{Port: 8080},
+								Weight: 10000,
+							},
+						},
+					},
+				},
+			}, {
+				Update: &destinationPb.Update_NoEndpoints{
+					NoEndpoints: &destinationPb.NoEndpoints{Exists: true},
+				},
+			},
+		}
+		mockGrpcServer.UpdatesToReturn = expectedUpdates
+		mockGrpcServer.ErrorToReturn = nil
+
+		expectedRequest := &common.Destination{Scheme: "k8s", Path: "web.prod.svc.cluster.local:8080"}
+		endpointsClient, err := client.Endpoints(context.TODO(), expectedRequest)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, expectedUpdate := range expectedUpdates {
+			actualUpdate, err := endpointsClient.Recv()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !proto.Equal(actualUpdate, expectedUpdate) {
+				t.Fatalf("Expecting update to be [%v], but was [%v]", expectedUpdate, actualUpdate)
+			}
+		}
+
+		if !proto.Equal(mockGrpcServer.LastRequestReceived, expectedRequest) {
+			t.Fatalf("Expecting request to be [%v], but was [%v]", expectedRequest, mockGrpcServer.LastRequestReceived)
+		}
+	})
+
 	t.Run("Handles errors before opening keep-alive response", func(t *testing.T) {
 		mockGrpcServer := &mockGrpcServer{}
 
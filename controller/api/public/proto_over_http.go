@@ -117,13 +117,22 @@ func serializeAsPayload(messageContentsInBytes []byte) ([]byte, error) {
 	return append(messageLengthInBytes, messageContentsInBytes...), nil
 }
 
+// deserializePayloadFromReader reads the next payload written by
+// serializeAsPayload. It returns io.EOF if the reader ends before the next
+// payload begins.
 func deserializePayloadFromReader(reader *bufio.Reader) ([]byte, error) {
 	messageLengthAsBytes := make([]byte, numBytesForMessageLength)
-	reader.Read(messageLengthAsBytes)
+	_, err := io.ReadFull(reader, messageLengthAsBytes)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading message length: %v", err)
+	}
 	messageLength := int(binary.LittleEndian.Uint32(messageLengthAsBytes))
 
 	messageContentsAsBytes := make([]byte, messageLength)
-	_, err := io.ReadFull(reader, messageContentsAsBytes)
+	_, err = io.ReadFull(reader, messageContentsAsBytes)
 	if err != nil {
 		return nil, fmt.Errorf("error while reading bytes from message: %v", err)
 	}
@@ -131,6 +140,29 @@ func deserializePayloadFromReader(reader *bufio.Reader) ([]byte, error) {
 	return messageContentsAsBytes, nil
 }
 
+// WritePayload writes a message to w with the same length-prefixed framing as
+// the messages streamed by the API, so that a stream of them can be stored
+// and later read back with ReadPayload.
+func WritePayload(w io.Writer, msg proto.Message) error {
+	marshalledProtobufMessage, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	fullPayload, err := serializeAsPayload(marshalledProtobufMessage)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(fullPayload)
+	return err
+}
+
+// ReadPayload reads the next message written by WritePayload. It returns
+// io.EOF once there are no more messages.
+func ReadPayload(reader *bufio.Reader, out proto.Message) error {
+	return fromByteStreamToProtocolBuffers(reader, out)
+}
+
 func checkIfResponseHasConduitError(rsp *http.Response) error {
 	errorMsg := rsp.Header.Get(errorHeader)
 
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -384,6 +385,20 @@ func TestDeserializePayloadFromReader(t *testing.T) {
 		}
 	})
 
+	t.Run("Returns EOF when the stream ends before the next message", func(t *testing.T) {
+		_, err := deserializePayloadFromReader(bufio.NewReader(bytes.NewReader([]byte{})))
+		if err != io.EOF {
+			t.Fatalf("Expecting error to be [%v], got [%v]", io.EOF, err)
+		}
+	})
+
+	t.Run("Returns error when the stream ends within the message size", func(t *testing.T) {
+		_, err := deserializePayloadFromReader(bufio.NewReader(bytes.NewReader([]byte{1, 0})))
+		if err == nil || err == io.EOF {
+			t.Fatalf("Expecting error other than [%v], got [%v]", io.EOF, err)
+		}
+	})
+
 	t.Run("Returns error when message has fewer bytes than declared message size", func(t *testing.T) {
 		expectedMessage := "this is the message"
 
@@ -507,3 +522,38 @@ func assertResponseHasProtobufContentType(t *testing.T, responseWriter *stubResp
 		t.Fatalf("Expected content-type to be [%s], but got [%s]", expectedContentType, actualContentType)
 	}
 }
+
+func TestWriteAndReadPayload(t *testing.T) {
+	t.Run("Reads back the messages written, and then EOF", func(t *testing.T) {
+		expectedMessages := []*pb.VersionInfo{
+			{GoVersion: "1.9.1", BuildDate: "2017.11.17", ReleaseVersion: "1.2.3"},
+			{},
+			{ReleaseVersion: "4.5.6"},
+		}
+
+		buffer := bytes.NewBuffer([]byte{})
+		for _, msg := range expectedMessages {
+			err := WritePayload(buffer, msg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		reader := bufio.NewReader(buffer)
+		for _, expectedMessage := range expectedMessages {
+			actualMessage := &pb.VersionInfo{}
+			err := ReadPayload(reader, actualMessage)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !proto.Equal(actualMessage, expectedMessage) {
+				t.Fatalf("Expecting message [%v], got [%v]", expectedMessage, actualMessage)
+			}
+		}
+
+		err := ReadPayload(reader, &pb.VersionInfo{})
+		if err != io.EOF {
+			t.Fatalf("Expecting error to be [%v], got [%v]", io.EOF, err)
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/prometheus/common/model"
 	tap "github.com/runconduit/conduit/controller/gen/controller/tap"
+	destination "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
 	pkgK8s "github.com/runconduit/conduit/pkg/k8s"
@@ -78,6 +79,7 @@ func testStatSummary(t *testing.T, expectations []statSumExpected) {
 		fakeGrpcServer := newGrpcServer(
 			mockProm,
 			tap.NewTapClient(nil),
+			destination.NewDestinationClient(nil),
 			k8sAPI,
 			"conduit",
 			[]string{},
@@ -782,6 +784,7 @@ status:
 			fakeGrpcServer := newGrpcServer(
 				&MockProm{Res: exp.mockPromResponse},
 				tap.NewTapClient(nil),
+				destination.NewDestinationClient(nil),
 				k8sAPI,
 				"conduit",
 				[]string{},
@@ -806,6 +809,7 @@ status:
 		fakeGrpcServer := newGrpcServer(
 			&MockProm{Res: model.Vector{}},
 			tap.NewTapClient(nil),
+			destination.NewDestinationClient(nil),
 			k8sAPI,
 			"conduit",
 			[]string{},
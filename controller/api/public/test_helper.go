@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/common/model"
 	common "github.com/runconduit/conduit/controller/gen/common"
 	healthcheckPb "github.com/runconduit/conduit/controller/gen/common/healthcheck"
+	destinationPb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	pb "github.com/runconduit/conduit/controller/gen/public"
 	"google.golang.org/grpc"
 )
@@ -26,6 +27,7 @@ type MockConduitApiClient struct {
 	SelfCheckResponseToReturn       *healthcheckPb.SelfCheckResponse
 	Api_TapClientToReturn           pb.Api_TapClient
 	Api_TapByResourceClientToReturn pb.Api_TapByResourceClient
+	Api_EndpointsClientToReturn     pb.Api_EndpointsClient
 }
 
 func (c *MockConduitApiClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
@@ -52,6 +54,10 @@ func (c *MockConduitApiClient) SelfCheck(ctx context.Context, in *healthcheckPb.
 	return c.SelfCheckResponseToReturn, c.ErrorToReturn
 }
 
+func (c *MockConduitApiClient) Endpoints(ctx context.Context, in *common.Destination, opts ...grpc.CallOption) (pb.Api_EndpointsClient, error) {
+	return c.Api_EndpointsClientToReturn, c.ErrorToReturn
+}
+
 type MockApi_TapClient struct {
 	TapEventsToReturn []common.TapEvent
 	ErrorsToReturn    []error
@@ -96,6 +102,28 @@ func (a *MockApi_TapByResourceClient) Recv() (*common.TapEvent, error) {
 	return &eventPopped, errorPopped
 }
 
+type MockApi_EndpointsClient struct {
+	UpdatesToReturn []destinationPb.Update
+	ErrorsToReturn  []error
+	grpc.ClientStream
+}
+
+func (a *MockApi_EndpointsClient) Recv() (*destinationPb.Update, error) {
+	var updatePopped destinationPb.Update
+	var errorPopped error
+	if len(a.UpdatesToReturn) == 0 && len(a.ErrorsToReturn) == 0 {
+		return nil, io.EOF
+	}
+	if len(a.UpdatesToReturn) != 0 {
+		updatePopped, a.UpdatesToReturn = a.UpdatesToReturn[0], a.UpdatesToReturn[1:]
+	}
+	if len(a.ErrorsToReturn) != 0 {
+		errorPopped, a.ErrorsToReturn = a.ErrorsToReturn[0], a.ErrorsToReturn[1:]
+	}
+
+	return &updatePopped, errorPopped
+}
+
 //
 // Prometheus client
 //
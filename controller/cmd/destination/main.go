@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -20,6 +21,9 @@ func main() {
 	k8sDNSZone := flag.String("kubernetes-dns-zone", "", "The DNS suffix for the local Kubernetes zone.")
 	logLevel := flag.String("log-level", log.InfoLevel.String(), "log level, must be one of: panic, fatal, error, warn, info, debug")
 	enableTLS := flag.Bool("enable-tls", false, "Enable TLS connections among pods in the service mesh")
+	zoneAwareWeights := flag.Bool("enable-zone-aware-weights", false, "Give endpoints outside of the requesting pod's zone a fraction of their weight")
+	remoteKubeConfigDir := flag.String("remote-kubeconfig-dir", "", "directory of kubeconfig files for remote clusters whose services are resolved, each named after the cluster's DNS zone")
+	slowStartWindow := flag.Duration("slow-start-window", 0, "time over which the weight of a newly ready pod is ramped up to its full value; 0 disables slow start")
 	printVersion := version.VersionFlag()
 	flag.Parse()
 
@@ -41,28 +45,49 @@ func main() {
 	}
 	k8sAPI := k8s.NewAPI(
 		k8sClient,
+		k8s.CM,
 		k8s.Endpoint,
+		k8s.Node,
 		k8s.Pod,
 		k8s.RS,
 		k8s.Svc,
 	)
 
+	profileInformer, err := k8s.NewServiceProfileInformer(*kubeConfigPath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	remoteClusters := make([]destination.RemoteCluster, 0)
+	if *remoteKubeConfigDir != "" {
+		remoteClusters, err = destination.LoadRemoteClusters(*remoteKubeConfigDir)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
 	done := make(chan struct{})
 	ready := make(chan struct{})
 
-	server, lis, err := destination.NewServer(*addr, *k8sDNSZone, *enableTLS, k8sAPI, done)
+	server, lis, debugHandler, err := destination.NewServer(*addr, *k8sDNSZone, *enableTLS, *slowStartWindow, *zoneAwareWeights, k8sAPI, remoteClusters, profileInformer, done)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	go k8sAPI.Sync(ready)
+	go profileInformer.Run(done)
+	for _, cluster := range remoteClusters {
+		go cluster.K8sAPI.SyncWithRetry(nil)
+	}
 
 	go func() {
 		log.Infof("starting gRPC server on %s", *addr)
 		server.Serve(lis)
 	}()
 
-	go admin.StartServer(*metricsAddr, ready)
+	go admin.StartServerWithHandlers(*metricsAddr, ready, map[string]http.Handler{
+		destination.DebugEndpointsPath: debugHandler,
+	})
 
 	<-stop
 
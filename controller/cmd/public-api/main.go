@@ -10,6 +10,7 @@ import (
 
 	promApi "github.com/prometheus/client_golang/api"
 	"github.com/runconduit/conduit/controller/api/public"
+	"github.com/runconduit/conduit/controller/destination"
 	"github.com/runconduit/conduit/controller/k8s"
 	"github.com/runconduit/conduit/controller/tap"
 	"github.com/runconduit/conduit/pkg/admin"
@@ -23,6 +24,7 @@ func main() {
 	prometheusUrl := flag.String("prometheus-url", "http://127.0.0.1:9090", "prometheus url")
 	metricsAddr := flag.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
 	tapAddr := flag.String("tap-addr", "127.0.0.1:8088", "address of tap service")
+	destinationAddr := flag.String("destination-addr", "127.0.0.1:8089", "address of destination service")
 	controllerNamespace := flag.String("controller-namespace", "conduit", "namespace in which Conduit is installed")
 	ignoredNamespaces := flag.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
 	logLevel := flag.String("log-level", log.InfoLevel.String(), "log level, must be one of: panic, fatal, error, warn, info, debug")
@@ -47,6 +49,12 @@ func main() {
 	}
 	defer tapConn.Close()
 
+	destinationClient, destinationConn, err := destination.NewClient(*destinationAddr)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer destinationConn.Close()
+
 	k8sClient, err := k8s.NewClientSet(*kubeConfigPath)
 	if err != nil {
 		log.Fatal(err.Error())
@@ -70,6 +78,7 @@ func main() {
 		*addr,
 		prometheusClient,
 		tapClient,
+		destinationClient,
 		k8sAPI,
 		*controllerNamespace,
 		strings.Split(*ignoredNamespaces, ","),
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/runconduit/conduit/controller/k8s"
 	"github.com/runconduit/conduit/controller/tap"
@@ -18,6 +19,9 @@ func main() {
 	metricsAddr := flag.String("metrics-addr", ":9998", "address to serve scrapable metrics on")
 	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
 	tapPort := flag.Uint("tap-port", 4190, "proxy tap port to connect to")
+	maxTapsPerPod := flag.Uint("max-taps-per-pod", 10, "maximum number of concurrent taps of a pod; 0 for no limit")
+	maxTapsPerSession := flag.Uint("max-taps-per-session", 0, "maximum number of pods that a tap may include, beyond which the tap is rejected; 0 for no limit")
+	maxTapDuration := flag.Duration("max-tap-duration", time.Hour, "maximum duration of a tap; 0 for no limit")
 	logLevel := flag.String("log-level", log.InfoLevel.String(), "log level, must be one of: panic, fatal, error, warn, info, debug")
 	printVersion := version.VersionFlag()
 	flag.Parse()
@@ -47,7 +51,7 @@ func main() {
 		k8s.Svc,
 	)
 
-	server, lis, err := tap.NewServer(*addr, *tapPort, k8sAPI)
+	server, lis, err := tap.NewServer(*addr, *tapPort, *maxTapsPerPod, *maxTapsPerSession, *maxTapDuration, k8sAPI)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
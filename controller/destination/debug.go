@@ -0,0 +1,32 @@
+package destination
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DebugEndpointsPath is the path of the admin server's endpoint that dumps the
+// state of the destination service's subscriptions.
+const DebugEndpointsPath = "/debug/endpoints"
+
+// debugHandler serves the state of every servicePort that proxies are
+// subscribed to, as JSON, so that it can be compared with the endpoints that
+// the proxies are routing to.
+type debugHandler struct {
+	endpointsWatcher *endpointsWatcher
+}
+
+func (h *debugHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rsp, err := json.MarshalIndent(h.endpointsWatcher.servicePortStates(), "", "  ")
+	if err != nil {
+		log.Error(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(rsp)
+	w.Write([]byte("\n"))
+}
@@ -0,0 +1,93 @@
+package destination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/runconduit/conduit/controller/k8s"
+)
+
+func TestDebugHandler(t *testing.T) {
+	t.Run("dumps the state of every subscribed service port", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name1
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989
+    targetPort: http`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.19
+  - ip: 172.17.0.12
+  ports:
+  - name: http
+    port: 8080`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		watcher := newEndpointsWatcher(k8sAPI, newMockHostResolver(map[string][]string{}))
+
+		k8sAPI.Sync(nil)
+
+		listener1, cancelFn1 := newCollectUpdateListener()
+		defer cancelFn1()
+		listener2, cancelFn2 := newCollectUpdateListener()
+		defer cancelFn2()
+		listener3, cancelFn3 := newCollectUpdateListener()
+		defer cancelFn3()
+
+		watcher.subscribe(&serviceId{namespace: "ns", name: "name1"}, 8989, "", listener1)
+		watcher.subscribe(&serviceId{namespace: "ns", name: "name1"}, 8989, "", listener2)
+		watcher.subscribe(&serviceId{namespace: "ns", name: "missing"}, 80, "", listener3)
+
+		handler := &debugHandler{endpointsWatcher: watcher}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", DebugEndpointsPath, nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status [%d], got [%d]", http.StatusOK, recorder.Code)
+		}
+
+		var actualStates []servicePortState
+		err = json.Unmarshal(recorder.Body.Bytes(), &actualStates)
+		if err != nil {
+			t.Fatalf("Error decoding response [%s]: %s", recorder.Body.String(), err)
+		}
+
+		expectedStates := []servicePortState{
+			servicePortState{
+				Namespace:  "ns",
+				Name:       "missing",
+				Port:       80,
+				TargetPort: "80",
+				Addresses:  []string{},
+				Listeners:  1,
+			},
+			servicePortState{
+				Namespace:  "ns",
+				Name:       "name1",
+				Port:       8989,
+				TargetPort: "http",
+				Addresses:  []string{"172.17.0.12:8080", "172.17.0.19:8080"},
+				Listeners:  2,
+			},
+		}
+		if !reflect.DeepEqual(actualStates, expectedStates) {
+			t.Fatalf("Expected states %+v, got %+v", expectedStates, actualStates)
+		}
+	})
+}
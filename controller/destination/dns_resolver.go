@@ -0,0 +1,109 @@
+package destination
+
+import (
+	"sync"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/pkg/addr"
+	log "github.com/sirupsen/logrus"
+)
+
+// implements the streamingDestinationResolver interface
+//
+// dnsResolver resolves arbitrary host names, such as those of databases or
+// APIs outside of the cluster, by polling DNS. Each subscription re-resolves
+// its host whenever the previous answer expires and publishes the differences
+// between successive answers. It should be the last resolver in the list, so
+// that names with a more specific resolver aren't resolved through DNS.
+type dnsResolver struct {
+	hostResolver hostResolver
+	// the listeners of all active subscriptions, so that they can be stopped
+	listeners map[updateListener]struct{}
+	mutex     sync.Mutex
+}
+
+func newDNSResolver(hostResolver hostResolver) *dnsResolver {
+	return &dnsResolver{
+		hostResolver: hostResolver,
+		listeners:    make(map[updateListener]struct{}),
+	}
+}
+
+func (d *dnsResolver) canResolve(host string, port int) (bool, error) {
+	hostLabels, err := splitDNSName(host)
+	if err != nil {
+		// Not a DNS name, e.g. an IP address; there is nothing to resolve.
+		return false, nil
+	}
+
+	// Names with a single label are only meaningful relative to a search path.
+	// Names relative to the caller's namespace have already been expanded by
+	// the search path when they name a service, and the k8s resolvers come
+	// first, so any other name is looked up as is.
+	return len(hostLabels) > 1, nil
+}
+
+func (d *dnsResolver) streamResolution(host string, port int, listener updateListener) error {
+	watch := d.subscribe(host, port, listener)
+
+	select {
+	case <-listener.ClientClose():
+	case <-listener.ServerClose():
+	}
+
+	d.unsubscribe(watch, listener)
+	return nil
+}
+
+func (d *dnsResolver) stop() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for listener := range d.listeners {
+		listener.Stop()
+	}
+	d.listeners = make(map[updateListener]struct{})
+}
+
+// subscribe resolves host, publishes the result to listener, and starts
+// watching host for changes. The returned watch must be passed to
+// unsubscribe once the listener is done.
+func (d *dnsResolver) subscribe(host string, port int, listener updateListener) *dnsWatch {
+	log.Printf("Establishing DNS watch on %s:%d", host, port)
+
+	// Only accessed by the watch's publish callback, which is never called
+	// concurrently.
+	var addresses []common.TcpAddress
+	watch := newDNSWatch(host, uint32(port), d.hostResolver, func(newAddresses []common.TcpAddress, exists bool) {
+		log.Debugf("Updating %s:%d to %s", host, port, addr.AddressesToString(newAddresses))
+
+		if !exists {
+			listener.NoEndpoints(false)
+		} else if len(newAddresses) == 0 {
+			listener.NoEndpoints(true)
+		} else {
+			add, remove := addr.DiffAddresses(addresses, newAddresses)
+			listener.Update(add, remove)
+		}
+		addresses = newAddresses
+	})
+
+	d.mutex.Lock()
+	d.listeners[listener] = struct{}{}
+	d.mutex.Unlock()
+
+	delay := watch.refresh()
+	go watch.run(delay)
+
+	return watch
+}
+
+func (d *dnsResolver) unsubscribe(watch *dnsWatch, listener updateListener) {
+	log.Printf("Stopping DNS watch on %s:%d", watch.host, watch.port)
+
+	watch.stop()
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.listeners, listener)
+}
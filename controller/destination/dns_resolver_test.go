@@ -0,0 +1,182 @@
+package destination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDNSResolver(t *testing.T) {
+	t.Run("can resolve names with more than one label", func(t *testing.T) {
+		resolver := newDNSResolver(newMockHostResolver(map[string][]string{}))
+
+		resolvableNames := []string{
+			"example.com",
+			"db.example.com",
+			"db.example.com.",
+			"api.some-saas.io",
+		}
+		unresolvableNames := []string{"localhost", "localhost.", "10.0.0.1", "-bad-.example.com", ""}
+
+		for _, name := range resolvableNames {
+			canResolve, err := resolver.canResolve(name, 443)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !canResolve {
+				t.Fatalf("Expected DNS resolver to resolve name [%s] but it didnt", name)
+			}
+		}
+
+		for _, name := range unresolvableNames {
+			canResolve, err := resolver.canResolve(name, 443)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if canResolve {
+				t.Fatalf("Expected DNS resolver to NOT resolve name [%s] but it did", name)
+			}
+		}
+	})
+
+	t.Run("streams the addresses of a host until the client closes", func(t *testing.T) {
+		resolver := newDNSResolver(newMockHostResolver(map[string][]string{
+			"db.example.com": []string{"10.0.0.1", "fd00::1"},
+		}))
+
+		listener, cancelFn := newCollectUpdateListener()
+		cancelFn()
+
+		err := resolver.streamResolution("db.example.com", 5432, listener)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedAdded := []string{"10.0.0.1:5432", "[fd00::1]:5432"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		if len(resolver.listeners) != 0 {
+			t.Fatalf("Expected no listeners after the client closed, got %v", resolver.listeners)
+		}
+	})
+
+	t.Run("publishes the difference between successive answers", func(t *testing.T) {
+		hostResolver := newMockHostResolver(map[string][]string{
+			"api.example.com": []string{"10.0.0.1", "10.0.0.2"},
+		})
+		resolver := newDNSResolver(hostResolver)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watch := resolver.subscribe("api.example.com", 443, listener)
+		defer resolver.unsubscribe(watch, listener)
+
+		hostResolver.setAnswer("api.example.com", "10.0.0.2", "10.0.0.3")
+		watch.refresh()
+
+		expectedAdded := []string{"10.0.0.1:443", "10.0.0.2:443", "10.0.0.3:443"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		expectedRemoved := []string{"10.0.0.1:443"}
+		if actual := addressStrings(listener.removed); !reflect.DeepEqual(actual, expectedRemoved) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemoved, actual)
+		}
+
+		if listener.noEndpointsCalled {
+			t.Fatalf("Expected NoEndpoints not to be called")
+		}
+
+		hostResolver.setAnswer("api.example.com")
+		watch.refresh()
+
+		if !listener.noEndpointsCalled || !listener.noEndpointsExists {
+			t.Fatalf("Expected NoEndpoints(true) to be called, got called=[%t] exists=[%t]",
+				listener.noEndpointsCalled, listener.noEndpointsExists)
+		}
+	})
+
+	t.Run("publishes that a name doesn't exist", func(t *testing.T) {
+		hostResolver := newMockHostResolver(map[string][]string{
+			"api.example.com": []string{"10.0.0.1"},
+		})
+		resolver := newDNSResolver(hostResolver)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watch := resolver.subscribe("api.example.com", 443, listener)
+		defer resolver.unsubscribe(watch, listener)
+
+		hostResolver.mutex.Lock()
+		hostResolver.err = errNoSuchHost
+		hostResolver.mutex.Unlock()
+		watch.refresh()
+
+		if !listener.noEndpointsCalled || listener.noEndpointsExists {
+			t.Fatalf("Expected NoEndpoints(false) to be called, got called=[%t] exists=[%t]",
+				listener.noEndpointsCalled, listener.noEndpointsExists)
+		}
+	})
+
+	t.Run("publishes that there are no addresses when the first lookup fails", func(t *testing.T) {
+		hostResolver := newMockHostResolver(map[string][]string{})
+		hostResolver.err = errors.New("expected for lookup")
+		resolver := newDNSResolver(hostResolver)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watch := resolver.subscribe("api.example.com", 443, listener)
+		defer resolver.unsubscribe(watch, listener)
+
+		if !listener.noEndpointsCalled || listener.noEndpointsExists {
+			t.Fatalf("Expected NoEndpoints(false) to be called, got called=[%t] exists=[%t]",
+				listener.noEndpointsCalled, listener.noEndpointsExists)
+		}
+
+		hostResolver.mutex.Lock()
+		hostResolver.err = nil
+		hostResolver.mutex.Unlock()
+		hostResolver.setAnswer("api.example.com", "10.0.0.1")
+		watch.refresh()
+
+		expectedAdded := []string{"10.0.0.1:443"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+	})
+
+	t.Run("keeps the previous addresses when a lookup fails", func(t *testing.T) {
+		hostResolver := newMockHostResolver(map[string][]string{
+			"api.example.com": []string{"10.0.0.1"},
+		})
+		resolver := newDNSResolver(hostResolver)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watch := resolver.subscribe("api.example.com", 443, listener)
+		defer resolver.unsubscribe(watch, listener)
+
+		hostResolver.mutex.Lock()
+		hostResolver.err = errors.New("expected for lookup")
+		hostResolver.mutex.Unlock()
+
+		delay := watch.refresh()
+		if delay != dnsMinRefreshInterval {
+			t.Fatalf("Expected to retry after [%s], got [%s]", dnsMinRefreshInterval, delay)
+		}
+
+		if len(listener.removed) != 0 || listener.noEndpointsCalled {
+			t.Fatalf("Expected no updates after a failed lookup, got removed=%v noEndpointsCalled=[%t]",
+				listener.removed, listener.noEndpointsCalled)
+		}
+	})
+}
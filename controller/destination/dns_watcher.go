@@ -0,0 +1,202 @@
+package destination
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/pkg/addr"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// dnsLookupTimeout bounds how long a single DNS lookup may take.
+	dnsLookupTimeout = 5 * time.Second
+
+	// dnsMinRefreshInterval is the shortest time we wait between two lookups of
+	// the same name, regardless of the TTL reported by the resolver. It is also
+	// used as the retry interval after a failed lookup.
+	dnsMinRefreshInterval = 5 * time.Second
+
+	// defaultDNSRefreshInterval is how long answers from the system resolver
+	// are considered fresh.
+	defaultDNSRefreshInterval = 30 * time.Second
+)
+
+// errNoSuchHost is returned by a hostResolver when the name doesn't exist, as
+// opposed to existing without any addresses.
+var errNoSuchHost = errors.New("no such host")
+
+// hostResolver resolves DNS names to IP addresses. It is an interface so that
+// tests can substitute canned answers for real DNS lookups.
+type hostResolver interface {
+	// lookupHost returns the IP addresses for host along with how long the
+	// answer may be used before host should be resolved again. If host
+	// doesn't exist, errNoSuchHost is returned along with how long that
+	// answer may be used.
+	lookupHost(host string) ([]net.IP, time.Duration, error)
+}
+
+// implements the hostResolver interface using the system resolver. The Go
+// resolver doesn't expose record TTLs, so every answer is considered fresh for
+// the same fixed interval.
+type netHostResolver struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+}
+
+func newNetHostResolver(ttl time.Duration) *netHostResolver {
+	return &netHostResolver{
+		resolver: net.DefaultResolver,
+		ttl:      ttl,
+	}
+}
+
+func (r *netHostResolver) lookupHost(host string) ([]net.IP, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	// Names are always treated as fully qualified, so that the search path of
+	// the controller's own pod doesn't apply to them.
+	if !strings.HasSuffix(host, ".") {
+		host += "."
+	}
+
+	ipAddrs, err := r.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && !dnsErr.Temporary() && !dnsErr.Timeout() {
+			return nil, r.ttl, errNoSuchHost
+		}
+		return nil, 0, err
+	}
+
+	ips := make([]net.IP, len(ipAddrs))
+	for i, ipAddr := range ipAddrs {
+		ips[i] = ipAddr.IP
+	}
+	return ips, r.ttl, nil
+}
+
+// dnsWatch repeatedly resolves a DNS name and publishes the resulting address
+// set, along with whether the name exists, every time either changes. A failed
+// lookup leaves the previously published addresses in place until the next
+// successful lookup; if nothing was published yet, the name is published as
+// not existing so that subscribers aren't left waiting for the retry.
+type dnsWatch struct {
+	host      string
+	port      uint32
+	resolver  hostResolver
+	publish   func(addresses []common.TcpAddress, exists bool)
+	addresses []common.TcpAddress
+	exists    bool
+	published bool
+	// closed once the first result has been published
+	resolved chan struct{}
+	stopCh   chan struct{}
+	// This mutex serializes lookups so that refresh may be called while run is
+	// in progress.
+	mutex sync.Mutex
+}
+
+func newDNSWatch(host string, port uint32, resolver hostResolver, publish func([]common.TcpAddress, bool)) *dnsWatch {
+	return &dnsWatch{
+		host:     host,
+		port:     port,
+		resolver: resolver,
+		publish:  publish,
+		resolved: make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// resolve performs a single lookup without publishing the result.
+func (w *dnsWatch) resolve() ([]common.TcpAddress, time.Duration, error) {
+	ips, ttl, err := w.resolver.lookupHost(w.host)
+	if ttl < dnsMinRefreshInterval {
+		ttl = dnsMinRefreshInterval
+	}
+	if err != nil {
+		return nil, ttl, err
+	}
+
+	addrs := make([]common.TcpAddress, 0)
+	for _, ip := range ips {
+		pbIP, err := addr.ParseIP(ip.String())
+		if err != nil {
+			log.Printf("%s is not a valid IP address", ip)
+			continue
+		}
+		addrs = append(addrs, common.TcpAddress{
+			Ip:   pbIP,
+			Port: w.port,
+		})
+	}
+	return addrs, ttl, nil
+}
+
+// refresh resolves the watched name and publishes the result if it changed
+// since the last publication. It returns the time to wait before the next
+// refresh.
+func (w *dnsWatch) refresh() time.Duration {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	addrs, ttl, err := w.resolve()
+	exists := true
+	switch {
+	case err == errNoSuchHost:
+		addrs, exists = []common.TcpAddress{}, false
+	case err != nil:
+		log.Errorf("Error resolving %s: %s", w.host, err)
+		if w.published {
+			return ttl
+		}
+		addrs, exists = []common.TcpAddress{}, false
+	}
+
+	add, remove := addr.DiffAddresses(w.addresses, addrs)
+	if len(add) == 0 && len(remove) == 0 && exists == w.exists && w.published {
+		return ttl
+	}
+
+	log.Debugf("Resolved %s to %s", w.host, addr.AddressesToString(addrs))
+	first := !w.published
+	w.addresses = addrs
+	w.exists = exists
+	w.published = true
+	w.publish(addrs, exists)
+	if first {
+		close(w.resolved)
+	}
+	return ttl
+}
+
+// run refreshes the watched name until stop is called, waiting `delay` before
+// the first refresh. It should be called as a go-routine.
+func (w *dnsWatch) run(delay time.Duration) {
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-time.After(delay):
+			delay = w.refresh()
+		}
+	}
+}
+
+func (w *dnsWatch) stop() {
+	close(w.stopCh)
+}
+
+// waitResolved blocks until the first result has been published, or until the
+// watch is stopped.
+func (w *dnsWatch) waitResolved() {
+	select {
+	case <-w.resolved:
+	case <-w.stopCh:
+	}
+}
@@ -2,6 +2,7 @@ package destination
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	common "github.com/runconduit/conduit/controller/gen/common"
@@ -23,23 +24,27 @@ const (
 // endpointsWatcher watches all endpoints and services in the Kubernetes
 // cluster.  Listeners can subscribe to a particular service and port and
 // endpointsWatcher will publish the address set and all future changes for
-// that service:port.
+// that service:port. Listeners may also subscribe to a single endpoint of the
+// service, identified by its hostname.
 type endpointsWatcher struct {
 	serviceLister  corelisters.ServiceLister
 	endpointLister corelisters.EndpointsLister
-	// a map of service -> service port -> servicePort
-	servicePorts map[serviceId]map[uint32]*servicePort
+	// used to resolve the external names of ExternalName services
+	dnsResolver hostResolver
+	// a map of service -> service port and hostname -> servicePort
+	servicePorts map[serviceId]map[portAndHostname]*servicePort
 	// This mutex protects the servicePorts data structure (nested map) itself
 	// and does not protect the servicePort objects themselves.  They are locked
 	// separately.
 	mutex sync.RWMutex
 }
 
-func newEndpointsWatcher(k8sAPI *k8s.API) *endpointsWatcher {
+func newEndpointsWatcher(k8sAPI *k8s.API, dnsResolver hostResolver) *endpointsWatcher {
 	watcher := &endpointsWatcher{
 		serviceLister:  k8sAPI.Svc().Lister(),
 		endpointLister: k8sAPI.Endpoint().Lister(),
-		servicePorts:   make(map[serviceId]map[uint32]*servicePort),
+		dnsResolver:    dnsResolver,
+		servicePorts:   make(map[serviceId]map[portAndHostname]*servicePort),
 		mutex:          sync.RWMutex{},
 	}
 
@@ -47,6 +52,7 @@ func newEndpointsWatcher(k8sAPI *k8s.API) *endpointsWatcher {
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    watcher.addService,
 			UpdateFunc: watcher.updateService,
+			DeleteFunc: watcher.deleteService,
 		},
 	)
 
@@ -73,11 +79,40 @@ func (e *endpointsWatcher) stop() {
 	}
 }
 
+// servicePortStates returns a snapshot of every servicePort, sorted by
+// service, port and hostname.
+func (e *endpointsWatcher) servicePortStates() []servicePortState {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	states := make([]servicePortState, 0)
+	for id, portMap := range e.servicePorts {
+		for _, servicePort := range portMap {
+			states = append(states, servicePort.state(id))
+		}
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Namespace != states[j].Namespace {
+			return states[i].Namespace < states[j].Namespace
+		}
+		if states[i].Name != states[j].Name {
+			return states[i].Name < states[j].Name
+		}
+		if states[i].Port != states[j].Port {
+			return states[i].Port < states[j].Port
+		}
+		return states[i].Hostname < states[j].Hostname
+	})
+	return states
+}
+
 // Subscribe to a service and service port.
 // The provided listener will be updated each time the address set for the
-// given service port is changed.
-func (e *endpointsWatcher) subscribe(service *serviceId, port uint32, listener updateListener) error {
-	log.Printf("Establishing watch on endpoint %s:%d", service, port)
+// given service port is changed. If hostname is not empty, the address set
+// only includes the endpoint with that hostname.
+func (e *endpointsWatcher) subscribe(service *serviceId, port uint32, hostname string, listener updateListener) error {
+	log.Printf("Establishing watch on endpoint %s:%d", hostnameAndService(hostname, service), port)
 
 	svc, err := e.getService(service)
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -85,44 +120,58 @@ func (e *endpointsWatcher) subscribe(service *serviceId, port uint32, listener u
 		return err
 	}
 
+	for {
+		watch, err := e.trySubscribe(svc, service, port, hostname, listener)
+		if err != nil || watch == nil {
+			return err
+		}
+		// The external name of the service hasn't been resolved yet. Wait for
+		// the first answer without holding any locks, so that the listener
+		// starts with it, and try again.
+		watch.waitResolved()
+	}
+}
+
+// trySubscribe subscribes listener to the servicePort, unless the servicePort
+// is waiting for the first DNS answer for its external name, in which case the
+// DNS watch is returned instead.
+func (e *endpointsWatcher) trySubscribe(svc *v1.Service, service *serviceId, port uint32, hostname string, listener updateListener) (*dnsWatch, error) {
 	e.mutex.Lock() // Acquire write-lock on servicePorts data structure.
 	defer e.mutex.Unlock()
 
 	svcPorts, ok := e.servicePorts[*service]
 	if !ok {
-		svcPorts = make(map[uint32]*servicePort)
+		svcPorts = make(map[portAndHostname]*servicePort)
 		e.servicePorts[*service] = svcPorts
 	}
-	svcPort, ok := svcPorts[port]
+	key := portAndHostname{port: port, hostname: hostname}
+	svcPort, ok := svcPorts[key]
 	if !ok {
 		endpoints, err := e.getEndpoints(service)
 		if apierrors.IsNotFound(err) {
 			endpoints = &v1.Endpoints{}
 		} else if err != nil {
 			log.Errorf("Error getting endpoints: %s", err)
-			return err
+			return nil, err
 		}
-		svcPort = newServicePort(svc, endpoints, port)
-		svcPorts[port] = svcPort
+		svcPort = newServicePort(svc, endpoints, port, hostname, e.dnsResolver)
+		svcPorts[key] = svcPort
 	}
 
-	exists := true
-	if svc == nil || svc.Spec.Type == v1.ServiceTypeExternalName {
-		// XXX: The proxy will use DNS to discover the service if it is told
-		// the service doesn't exist. An external service is represented in DNS
-		// as a CNAME, which the proxy will correctly resolve. Thus, there's no
-		// benefit (yet) to distinguishing between "the service exists but it
-		// is an ExternalName service so use DNS anyway" and "the service does
-		// not exist."
-		exists = false
+	if watch := svcPort.pendingDNSWatch(); watch != nil {
+		return watch, nil
 	}
 
+	// ExternalName services exist, and their addresses are resolved from the
+	// service's external name rather than from an Endpoints object.
+	exists := svc != nil
+
 	svcPort.subscribe(exists, listener)
-	return nil
+	return nil, nil
 }
 
-func (e *endpointsWatcher) unsubscribe(service *serviceId, port uint32, listener updateListener) error {
-	log.Printf("Stopping watch on endpoint %s:%d", service, port)
+func (e *endpointsWatcher) unsubscribe(service *serviceId, port uint32, hostname string, listener updateListener) error {
+	log.Printf("Stopping watch on endpoint %s:%d", hostnameAndService(hostname, service), port)
 
 	e.mutex.Lock() // Acquire write-lock on servicePorts data structure.
 	defer e.mutex.Unlock()
@@ -131,7 +180,8 @@ func (e *endpointsWatcher) unsubscribe(service *serviceId, port uint32, listener
 	if !ok {
 		return fmt.Errorf("Cannot unsubscribe from %s: not subscribed", service)
 	}
-	svcPort, ok := svc[port]
+	key := portAndHostname{port: port, hostname: hostname}
+	svcPort, ok := svc[key]
 	if !ok {
 		return fmt.Errorf("Cannot unsubscribe from %s: not subscribed", service)
 	}
@@ -140,7 +190,7 @@ func (e *endpointsWatcher) unsubscribe(service *serviceId, port uint32, listener
 		return fmt.Errorf("Cannot unsubscribe from %s: not subscribed", service)
 	}
 	if numListeners == 0 {
-		delete(svc, port)
+		delete(svc, key)
 		if len(svc) == 0 {
 			delete(e.servicePorts, *service)
 		}
@@ -152,6 +202,32 @@ func (e *endpointsWatcher) getService(service *serviceId) (*v1.Service, error) {
 	return e.serviceLister.Services(service.namespace).Get(service.name)
 }
 
+// exists returns true if the service exists and, if `hostname` is set, it has
+// an endpoint with that hostname.
+func (e *endpointsWatcher) exists(service *serviceId, hostname string) bool {
+	if _, err := e.getService(service); err != nil {
+		return false
+	}
+	if hostname == "" {
+		return true
+	}
+
+	endpoints, err := e.getEndpoints(service)
+	if err != nil {
+		return false
+	}
+	for _, subset := range endpoints.Subsets {
+		for _, addresses := range [][]v1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+			for _, address := range addresses {
+				if address.Hostname == hostname {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (e *endpointsWatcher) addService(obj interface{}) {
 	service := obj.(*v1.Service)
 	if service.Namespace == kubeSystem {
@@ -192,6 +268,26 @@ func (e *endpointsWatcher) updateService(oldObj, newObj interface{}) {
 	}
 }
 
+func (e *endpointsWatcher) deleteService(obj interface{}) {
+	service := obj.(*v1.Service)
+	if service.Namespace == kubeSystem {
+		return
+	}
+	id := serviceId{
+		namespace: service.Namespace,
+		name:      service.Name,
+	}
+
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	svc, ok := e.servicePorts[id]
+	if ok {
+		for _, sp := range svc {
+			sp.deleteService()
+		}
+	}
+}
+
 func (e *endpointsWatcher) getEndpoints(service *serviceId) (*v1.Endpoints, error) {
 	return e.endpointLister.Endpoints(service.namespace).Get(service.name)
 }
@@ -264,28 +360,42 @@ func (e *endpointsWatcher) updateEndpoints(oldObj, newObj interface{}) {
 // updates come from either the endpoints API or the service API.
 type servicePort struct {
 	// these values are immutable properties of the servicePort
-	service serviceId
-	port    uint32 // service port
+	service  serviceId
+	port     uint32 // service port
+	hostname string // if set, only the endpoint with this hostname is included
 	// these values hold the current state of the servicePort and are mutable
 	listeners  []updateListener
 	endpoints  *v1.Endpoints
 	targetPort intstr.IntOrString
 	addresses  []common.TcpAddress
+	// publishNotReady is set iff the service sets publishNotReadyAddresses, in
+	// which case the addresses of endpoints that aren't ready are included.
+	publishNotReady bool
+	// externalName is set iff the service is an ExternalName service, in which
+	// case addresses are published by dnsWatch instead of the endpoints API.
+	externalName string
+	dnsWatch     *dnsWatch
+	dnsResolver  hostResolver
+	// set if the last DNS answer was that externalName doesn't exist, in
+	// which case the service is reported as not existing
+	externalNameMissing bool
 	// This mutex protects against concurrent modification of the listeners slice
 	// as well as prevents updates for occuring while the listeners slice is being
 	// modified.
 	mutex sync.Mutex
 }
 
-func newServicePort(service *v1.Service, endpoints *v1.Endpoints, port uint32) *servicePort {
+func newServicePort(service *v1.Service, endpoints *v1.Endpoints, port uint32, hostname string, dnsResolver hostResolver) *servicePort {
 	// Use the service port as the target port by default.
 	targetPort := intstr.FromInt(int(port))
 
 	id := serviceId{}
+	publishNotReady := false
 
 	if service != nil {
 		id.namespace = service.Namespace
 		id.name = service.Name
+		publishNotReady = service.Spec.PublishNotReadyAddresses
 		// If a port spec exists with a matching service port, use that port spec's
 		// target port.
 		for _, portSpec := range service.Spec.Ports {
@@ -296,16 +406,65 @@ func newServicePort(service *v1.Service, endpoints *v1.Endpoints, port uint32) *
 		}
 	}
 
-	addrs := addresses(endpoints, targetPort)
+	addrs := addresses(endpoints, targetPort, hostname, publishNotReady)
+
+	sp := &servicePort{
+		service:         id,
+		listeners:       make([]updateListener, 0),
+		port:            port,
+		hostname:        hostname,
+		endpoints:       endpoints,
+		targetPort:      targetPort,
+		addresses:       addrs,
+		publishNotReady: publishNotReady,
+		dnsResolver:     dnsResolver,
+		mutex:           sync.Mutex{},
+	}
+
+	if service != nil && service.Spec.Type == v1.ServiceTypeExternalName {
+		// The addresses of an ExternalName service come from DNS alone, and
+		// are unknown until the first lookup completes.
+		sp.addresses = []common.TcpAddress{}
+		sp.startDNSWatch(service.Spec.ExternalName)
+	}
+
+	return sp
+}
+
+// servicePortState is a snapshot of a servicePort, as served by the debug
+// endpoint of the destination service.
+type servicePortState struct {
+	Namespace    string   `json:"namespace"`
+	Name         string   `json:"name"`
+	Port         uint32   `json:"port"`
+	Hostname     string   `json:"hostname,omitempty"`
+	TargetPort   string   `json:"targetPort"`
+	ExternalName string   `json:"externalName,omitempty"`
+	Addresses    []string `json:"addresses"`
+	Listeners    int      `json:"listeners"`
+}
+
+// state takes the service id from the caller, since sp.service isn't set if
+// the service didn't exist when sp was created.
+func (sp *servicePort) state(id serviceId) servicePortState {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	addresses := make([]string, len(sp.addresses))
+	for i := range sp.addresses {
+		addresses[i] = addr.AddressToString(&sp.addresses[i])
+	}
+	sort.Strings(addresses)
 
-	return &servicePort{
-		service:    id,
-		listeners:  make([]updateListener, 0),
-		port:       port,
-		endpoints:  endpoints,
-		targetPort: targetPort,
-		addresses:  addrs,
-		mutex:      sync.Mutex{},
+	return servicePortState{
+		Namespace:    id.namespace,
+		Name:         id.name,
+		Port:         sp.port,
+		Hostname:     sp.hostname,
+		TargetPort:   sp.targetPort.String(),
+		ExternalName: sp.externalName,
+		Addresses:    addresses,
+		Listeners:    len(sp.listeners),
 	}
 }
 
@@ -313,9 +472,13 @@ func (sp *servicePort) updateEndpoints(newEndpoints *v1.Endpoints) {
 	sp.mutex.Lock()
 	defer sp.mutex.Unlock()
 
-	newAddresses := addresses(newEndpoints, sp.targetPort)
-	sp.updateAddresses(newAddresses)
 	sp.endpoints = newEndpoints
+	if sp.externalName != "" {
+		return
+	}
+
+	newAddresses := addresses(newEndpoints, sp.targetPort, sp.hostname, sp.publishNotReady)
+	sp.updateAddresses(newAddresses)
 }
 
 func (sp *servicePort) deleteEndpoints() {
@@ -324,10 +487,33 @@ func (sp *servicePort) deleteEndpoints() {
 
 	log.Debugf("Deleting %s:%d", sp.service, sp.port)
 
+	sp.endpoints = &v1.Endpoints{}
+	if sp.externalName != "" {
+		return
+	}
+
+	for _, listener := range sp.listeners {
+		listener.NoEndpoints(false)
+	}
+	sp.addresses = []common.TcpAddress{}
+}
+
+// deleteService only needs to act on ExternalName services; other services are
+// removed by the deletion of their Endpoints object.
+func (sp *servicePort) deleteService() {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	if sp.externalName == "" {
+		return
+	}
+
+	log.Debugf("Deleting external name %s for %s:%d", sp.externalName, sp.service, sp.port)
+
+	sp.stopDNSWatch()
 	for _, listener := range sp.listeners {
 		listener.NoEndpoints(false)
 	}
-	sp.endpoints = &v1.Endpoints{}
 	sp.addresses = []common.TcpAddress{}
 }
 
@@ -335,6 +521,14 @@ func (sp *servicePort) updateService(newService *v1.Service) {
 	sp.mutex.Lock()
 	defer sp.mutex.Unlock()
 
+	if newService.Spec.Type == v1.ServiceTypeExternalName {
+		if newService.Spec.ExternalName != sp.externalName {
+			sp.stopDNSWatch()
+			sp.startDNSWatch(newService.Spec.ExternalName)
+		}
+		return
+	}
+
 	// Use the service port as the target port by default.
 	newTargetPort := intstr.FromInt(int(sp.port))
 	// If a port spec exists with a matching service port, use that port spec's
@@ -345,13 +539,83 @@ func (sp *servicePort) updateService(newService *v1.Service) {
 			break
 		}
 	}
-	if newTargetPort != sp.targetPort {
-		newAddresses := addresses(sp.endpoints, newTargetPort)
+	newPublishNotReady := newService.Spec.PublishNotReadyAddresses
+	if newTargetPort != sp.targetPort || newPublishNotReady != sp.publishNotReady || sp.externalName != "" {
+		sp.stopDNSWatch()
+		newAddresses := addresses(sp.endpoints, newTargetPort, sp.hostname, newPublishNotReady)
 		sp.updateAddresses(newAddresses)
 		sp.targetPort = newTargetPort
+		sp.publishNotReady = newPublishNotReady
 	}
 }
 
+// startDNSWatch resolves externalName and keeps the address set up to date as
+// the DNS answer changes. Even the first lookup is done by the watch's
+// go-routine, since the caller holds locks that informer handlers and other
+// subscribers wait on; the current addresses are kept until it completes. The
+// caller must hold sp.mutex.
+func (sp *servicePort) startDNSWatch(externalName string) {
+	var watch *dnsWatch
+	watch = newDNSWatch(externalName, sp.port, sp.dnsResolver, func(newAddresses []common.TcpAddress, exists bool) {
+		sp.mutex.Lock()
+		defer sp.mutex.Unlock()
+
+		// Ignore results from a watch that has since been stopped.
+		if sp.dnsWatch == watch {
+			sp.updateExternalAddresses(newAddresses, exists)
+		}
+	})
+	sp.externalName = externalName
+	sp.dnsWatch = watch
+
+	go watch.run(0)
+}
+
+// stopDNSWatch stops resolving the service's external name, if any. The
+// caller must hold sp.mutex.
+func (sp *servicePort) stopDNSWatch() {
+	if sp.dnsWatch != nil {
+		sp.dnsWatch.stop()
+		sp.dnsWatch = nil
+	}
+	sp.externalName = ""
+	sp.externalNameMissing = false
+}
+
+// pendingDNSWatch returns the service's DNS watch if it hasn't published its
+// first answer yet, and nil otherwise.
+func (sp *servicePort) pendingDNSWatch() *dnsWatch {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	if sp.dnsWatch == nil {
+		return nil
+	}
+	select {
+	case <-sp.dnsWatch.resolved:
+		return nil
+	default:
+		return sp.dnsWatch
+	}
+}
+
+// updateExternalAddresses publishes a DNS answer for the service's external
+// name. If the name doesn't exist, the service is reported as not existing
+// either. The caller must hold sp.mutex.
+func (sp *servicePort) updateExternalAddresses(newAddresses []common.TcpAddress, exists bool) {
+	sp.externalNameMissing = !exists
+	if exists {
+		sp.updateAddresses(newAddresses)
+		return
+	}
+
+	log.Debugf("External name %s of %s:%d doesn't exist", sp.externalName, sp.service, sp.port)
+	for _, listener := range sp.listeners {
+		listener.NoEndpoints(false)
+	}
+	sp.addresses = []common.TcpAddress{}
+}
+
 func (sp *servicePort) updateAddresses(newAddresses []common.TcpAddress) {
 	log.Debugf("Updating %s:%d to %s", sp.service, sp.port, addr.AddressesToString(newAddresses))
 
@@ -375,7 +639,7 @@ func (sp *servicePort) subscribe(exists bool, listener updateListener) {
 	defer sp.mutex.Unlock()
 
 	sp.listeners = append(sp.listeners, listener)
-	if !exists {
+	if !exists || sp.externalNameMissing {
 		listener.NoEndpoints(false)
 	} else if len(sp.addresses) == 0 {
 		listener.NoEndpoints(true)
@@ -398,6 +662,9 @@ func (sp *servicePort) unsubscribe(listener updateListener) (bool, int) {
 			sp.listeners[i] = sp.listeners[len(sp.listeners)-1]
 			sp.listeners[len(sp.listeners)-1] = nil
 			sp.listeners = sp.listeners[:len(sp.listeners)-1]
+			if len(sp.listeners) == 0 {
+				sp.stopDNSWatch()
+			}
 			return true, len(sp.listeners)
 		}
 	}
@@ -410,6 +677,7 @@ func (sp *servicePort) unsubscribeAll() {
 	sp.mutex.Lock()
 	defer sp.mutex.Unlock()
 
+	sp.stopDNSWatch()
 	for _, listener := range sp.listeners {
 		listener.Stop()
 	}
@@ -417,11 +685,37 @@ func (sp *servicePort) unsubscribeAll() {
 
 /// helpers ///
 
-func addresses(endpoints *v1.Endpoints, port intstr.IntOrString) []common.TcpAddress {
+// portAndHostname identifies a servicePort within a service.
+type portAndHostname struct {
+	port     uint32
+	hostname string
+}
+
+func hostnameAndService(hostname string, service *serviceId) string {
+	if hostname == "" {
+		return service.String()
+	}
+	return fmt.Sprintf("%s.%s", hostname, service)
+}
+
+// addresses returns the addresses of the endpoints on the given port. If
+// hostname is not empty, only the endpoint with that hostname is returned. The
+// addresses of endpoints that aren't ready are only included if
+// includeNotReady is set.
+func addresses(endpoints *v1.Endpoints, port intstr.IntOrString, hostname string, includeNotReady bool) []common.TcpAddress {
 	ips := make([]common.IPAddress, 0)
 	for _, subset := range endpoints.Subsets {
-		for _, address := range subset.Addresses {
-			ip, err := addr.ParseIPV4(address.IP)
+		subsetAddresses := subset.Addresses
+		if includeNotReady {
+			subsetAddresses = make([]v1.EndpointAddress, 0, len(subset.Addresses)+len(subset.NotReadyAddresses))
+			subsetAddresses = append(subsetAddresses, subset.Addresses...)
+			subsetAddresses = append(subsetAddresses, subset.NotReadyAddresses...)
+		}
+		for _, address := range subsetAddresses {
+			if hostname != "" && address.Hostname != hostname {
+				continue
+			}
+			ip, err := addr.ParseIP(address.IP)
 			if err != nil {
 				log.Printf("%s is not a valid IP address", address.IP)
 				continue
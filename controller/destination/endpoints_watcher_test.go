@@ -5,8 +5,10 @@ import (
 	"sort"
 	"testing"
 
+	common "github.com/runconduit/conduit/controller/gen/common"
 	"github.com/runconduit/conduit/controller/k8s"
 	"github.com/runconduit/conduit/pkg/addr"
+	"k8s.io/api/core/v1"
 )
 
 func TestEndpointsWatcher(t *testing.T) {
@@ -15,6 +17,7 @@ func TestEndpointsWatcher(t *testing.T) {
 		k8sConfigs                       []string
 		service                          *serviceId
 		port                             uint32
+		hostname                         string
 		expectedAddresses                []string
 		expectedNoEndpoints              bool
 		expectedNoEndpointsServiceExists bool
@@ -56,6 +59,42 @@ subsets:
 			expectedNoEndpointsServiceExists: false,
 		},
 
+		{
+			serviceType: "local services with IPv6 endpoints",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name6
+  namespace: ns
+spec:
+  type: LoadBalancer
+  ports:
+  - port: 8989`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: name6
+  namespace: ns
+subsets:
+- addresses:
+  - ip: fd00::12
+  - ip: 172.17.0.19
+  - ip: fd00::20
+  ports:
+  - port: 8989`,
+			},
+			service: &serviceId{namespace: "ns", name: "name6"},
+			port:    uint32(8989),
+			expectedAddresses: []string{
+				"172.17.0.19:8989",
+				"[fd00::12]:8989",
+				"[fd00::20]:8989",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
 		{
 			serviceType: "local services with no endpoints",
 			k8sConfigs: []string{`
@@ -87,12 +126,175 @@ spec:
   type: ExternalName
   externalName: foo`,
 			},
-			service:                          &serviceId{namespace: "ns", name: "name3"},
+			service: &serviceId{namespace: "ns", name: "name3"},
+			port:    uint32(6969),
+			expectedAddresses: []string{
+				"10.1.2.3:6969",
+				"[fd00::3]:6969",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
+		{
+			serviceType: "external name services that do not resolve",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: name5
+  namespace: ns
+spec:
+  type: ExternalName
+  externalName: nxdomain`,
+			},
+			service:                          &serviceId{namespace: "ns", name: "name5"},
 			port:                             uint32(6969),
 			expectedAddresses:                []string{},
 			expectedNoEndpoints:              true,
 			expectedNoEndpointsServiceExists: false,
 		},
+		{
+			serviceType: "single endpoints of headless services",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: None
+  ports:
+  - port: 8080`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.30
+    hostname: web-0
+  - ip: 172.17.0.31
+    hostname: web-1
+  ports:
+  - port: 8080`,
+			},
+			service:  &serviceId{namespace: "ns", name: "web"},
+			port:     uint32(8080),
+			hostname: "web-1",
+			expectedAddresses: []string{
+				"172.17.0.31:8080",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
+		{
+			serviceType: "single endpoints of headless services that do not exist",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: None
+  ports:
+  - port: 8080`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.30
+    hostname: web-0
+  ports:
+  - port: 8080`,
+			},
+			service:                          &serviceId{namespace: "ns", name: "web"},
+			port:                             uint32(8080),
+			hostname:                         "web-2",
+			expectedAddresses:                []string{},
+			expectedNoEndpoints:              true,
+			expectedNoEndpointsServiceExists: true,
+		},
+		{
+			serviceType: "services that publish not-ready addresses",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: peers
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: None
+  publishNotReadyAddresses: true
+  ports:
+  - port: 2380`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: peers
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.40
+  notReadyAddresses:
+  - ip: 172.17.0.41
+  ports:
+  - port: 2380`,
+			},
+			service: &serviceId{namespace: "ns", name: "peers"},
+			port:    uint32(2380),
+			expectedAddresses: []string{
+				"172.17.0.40:2380",
+				"172.17.0.41:2380",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
+		{
+			serviceType: "services that do not publish not-ready addresses",
+			k8sConfigs: []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: peers
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: None
+  ports:
+  - port: 2380`,
+				`
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: peers
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.40
+  notReadyAddresses:
+  - ip: 172.17.0.41
+  ports:
+  - port: 2380`,
+			},
+			service: &serviceId{namespace: "ns", name: "peers"},
+			port:    uint32(2380),
+			expectedAddresses: []string{
+				"172.17.0.40:2380",
+			},
+			expectedNoEndpoints:              false,
+			expectedNoEndpointsServiceExists: false,
+		},
 		{
 			serviceType:                      "services that do not yet exist",
 			k8sConfigs:                       []string{},
@@ -109,14 +311,16 @@ spec:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			watcher := newEndpointsWatcher(k8sAPI)
+			watcher := newEndpointsWatcher(k8sAPI, newMockHostResolver(map[string][]string{
+				"foo": []string{"10.1.2.3", "fd00::3"},
+			}))
 
 			k8sAPI.Sync(nil)
 
 			listener, cancelFn := newCollectUpdateListener()
 			defer cancelFn()
 
-			err = watcher.subscribe(tt.service, tt.port, listener)
+			err = watcher.subscribe(tt.service, tt.port, tt.hostname, listener)
 			if err != nil {
 				t.Fatalf("subscribe returned an error: %s", err)
 			}
@@ -143,3 +347,199 @@ spec:
 		})
 	}
 }
+
+// dnsWatchFor returns the DNS watch of a service port, holding the locks that
+// guard it.
+func dnsWatchFor(watcher *endpointsWatcher, service *serviceId, port uint32) *dnsWatch {
+	watcher.mutex.RLock()
+	defer watcher.mutex.RUnlock()
+
+	sp := watcher.servicePorts[*service][portAndHostname{port: port}]
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	return sp.dnsWatch
+}
+
+func TestEndpointsWatcherExternalName(t *testing.T) {
+	externalNameService := `
+apiVersion: v1
+kind: Service
+metadata:
+  name: external
+  namespace: ns
+spec:
+  type: ExternalName
+  externalName: foo.example.com`
+
+	t.Run("publishes changes to the DNS answer for an external name", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(externalNameService)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		resolver := newMockHostResolver(map[string][]string{
+			"foo.example.com": []string{"10.0.0.1", "10.0.0.2"},
+		})
+		watcher := newEndpointsWatcher(k8sAPI, resolver)
+
+		k8sAPI.Sync(nil)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		service := &serviceId{namespace: "ns", name: "external"}
+		err = watcher.subscribe(service, 80, "", listener)
+		if err != nil {
+			t.Fatalf("subscribe returned an error: %s", err)
+		}
+
+		watch := dnsWatchFor(watcher, service, 80)
+		resolver.setAnswer("foo.example.com", "10.0.0.2", "10.0.0.3")
+		watch.refresh()
+
+		expectedAdded := []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		expectedRemoved := []string{"10.0.0.1:80"}
+		if actual := addressStrings(listener.removed); !reflect.DeepEqual(actual, expectedRemoved) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemoved, actual)
+		}
+	})
+
+	t.Run("switches between endpoints and DNS when the service type changes", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(externalNameService, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: external
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.12
+  ports:
+  - port: 80`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		resolver := newMockHostResolver(map[string][]string{
+			"foo.example.com": []string{"10.0.0.1"},
+			"bar.example.com": []string{"10.0.0.2"},
+		})
+		watcher := newEndpointsWatcher(k8sAPI, resolver)
+
+		k8sAPI.Sync(nil)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		service := &serviceId{namespace: "ns", name: "external"}
+		err = watcher.subscribe(service, 80, "", listener)
+		if err != nil {
+			t.Fatalf("subscribe returned an error: %s", err)
+		}
+		svc, err := watcher.getService(service)
+		if err != nil {
+			t.Fatalf("getService returned an error: %s", err)
+		}
+
+		renamed := svc.DeepCopy()
+		renamed.Spec.ExternalName = "bar.example.com"
+		watcher.updateService(svc, renamed)
+		<-dnsWatchFor(watcher, service, 80).resolved
+
+		clusterIP := renamed.DeepCopy()
+		clusterIP.Spec.Type = v1.ServiceTypeClusterIP
+		clusterIP.Spec.ExternalName = ""
+		watcher.updateService(renamed, clusterIP)
+
+		expectedAdded := []string{"10.0.0.1:80", "10.0.0.2:80", "172.17.0.12:80"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		expectedRemoved := []string{"10.0.0.1:80", "10.0.0.2:80"}
+		if actual := addressStrings(listener.removed); !reflect.DeepEqual(actual, expectedRemoved) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemoved, actual)
+		}
+
+		if dnsWatchFor(watcher, service, 80) != nil {
+			t.Fatalf("Expected DNS watch to be stopped after switching to a ClusterIP service")
+		}
+	})
+}
+
+func TestEndpointsWatcherHostname(t *testing.T) {
+	t.Run("follows a single endpoint when its pod is rescheduled", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: kafka
+  namespace: ns
+spec:
+  type: ClusterIP
+  clusterIP: None
+  ports:
+  - port: 9092`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: kafka
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 172.17.0.40
+    hostname: kafka-0
+  - ip: 172.17.0.41
+    hostname: kafka-1
+  ports:
+  - port: 9092`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		watcher := newEndpointsWatcher(k8sAPI, newMockHostResolver(map[string][]string{}))
+
+		k8sAPI.Sync(nil)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		service := &serviceId{namespace: "ns", name: "kafka"}
+		err = watcher.subscribe(service, 9092, "kafka-0", listener)
+		if err != nil {
+			t.Fatalf("subscribe returned an error: %s", err)
+		}
+
+		endpoints, err := watcher.getEndpoints(service)
+		if err != nil {
+			t.Fatalf("getEndpoints returned an error: %s", err)
+		}
+
+		rescheduled := endpoints.DeepCopy()
+		rescheduled.Subsets[0].Addresses[0].IP = "172.17.0.42"
+		watcher.updateEndpoints(endpoints, rescheduled)
+
+		expectedAdded := []string{"172.17.0.40:9092", "172.17.0.42:9092"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		expectedRemoved := []string{"172.17.0.40:9092"}
+		if actual := addressStrings(listener.removed); !reflect.DeepEqual(actual, expectedRemoved) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemoved, actual)
+		}
+	})
+}
+
+func addressStrings(addrs []common.TcpAddress) []string {
+	strs := make([]string, len(addrs))
+	for i := range addrs {
+		strs[i] = addr.AddressToString(&addrs[i])
+	}
+	sort.Strings(strs)
+	return strs
+}
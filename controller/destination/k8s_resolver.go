@@ -16,17 +16,35 @@ var containsAlphaRegexp = regexp.MustCompile("[a-zA-Z]")
 
 // implements the streamingDestinationResolver interface
 type k8sResolver struct {
-	k8sDNSZoneLabels []string
-	endpointsWatcher *endpointsWatcher
+	k8sDNSZoneLabels    []string
+	k8sAPI              *k8s.API
+	endpointsWatcher    *endpointsWatcher
+	trafficSplitWatcher *trafficSplitWatcher
+	// set if the resolver serves a remote cluster, whose services are only
+	// resolved under the cluster's own DNS zone
+	remote bool
 }
 
-func newK8sResolver(k8sDNSZoneLabels []string, k8sAPI *k8s.API) *k8sResolver {
+func newK8sResolver(k8sDNSZoneLabels []string, k8sAPI *k8s.API, dnsResolver hostResolver) *k8sResolver {
+	endpointsWatcher := newEndpointsWatcher(k8sAPI, dnsResolver)
 	return &k8sResolver{
-		k8sDNSZoneLabels: k8sDNSZoneLabels,
-		endpointsWatcher: newEndpointsWatcher(k8sAPI),
+		k8sDNSZoneLabels:    k8sDNSZoneLabels,
+		k8sAPI:              k8sAPI,
+		endpointsWatcher:    endpointsWatcher,
+		trafficSplitWatcher: newTrafficSplitWatcher(k8sAPI, endpointsWatcher),
 	}
 }
 
+// newRemoteK8sResolver returns a resolver for the services of a remote
+// cluster, e.g. "web.prod.svc.cluster-east" if the cluster's DNS zone is
+// "cluster-east". Its names aren't resolved until the informers of `k8sAPI`
+// have synced.
+func newRemoteK8sResolver(k8sDNSZoneLabels []string, k8sAPI *k8s.API, dnsResolver hostResolver) *k8sResolver {
+	resolver := newK8sResolver(k8sDNSZoneLabels, k8sAPI, dnsResolver)
+	resolver.remote = true
+	return resolver
+}
+
 type serviceId struct {
 	namespace string
 	name      string
@@ -37,7 +55,7 @@ func (s serviceId) String() string {
 }
 
 func (k *k8sResolver) canResolve(host string, port int) (bool, error) {
-	id, err := k.localKubernetesServiceIdFromDNSName(host)
+	id, _, err := k.localKubernetesServiceIdFromDNSName(host)
 	if err != nil {
 		return false, err
 	}
@@ -46,7 +64,7 @@ func (k *k8sResolver) canResolve(host string, port int) (bool, error) {
 }
 
 func (k *k8sResolver) streamResolution(host string, port int, listener updateListener) error {
-	id, err := k.localKubernetesServiceIdFromDNSName(host)
+	id, hostname, err := k.localKubernetesServiceIdFromDNSName(host)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -58,34 +76,97 @@ func (k *k8sResolver) streamResolution(host string, port int, listener updateLis
 		return err
 	}
 
+	if k.remote && !k.k8sAPI.HasSynced() {
+		err = fmt.Errorf("cannot resolve service of a remote cluster that hasn't synced yet: %s", host)
+		log.Error(err)
+		return err
+	}
+
 	listener.SetServiceId(id)
 
-	return k.resolveKubernetesService(id, port, listener)
+	return k.resolveKubernetesService(id, port, hostname, listener)
 }
 
 func (k *k8sResolver) stop() {
 	k.endpointsWatcher.stop()
 }
 
-func (k *k8sResolver) resolveKubernetesService(id *serviceId, port int, listener updateListener) error {
-	k.endpointsWatcher.subscribe(id, uint32(port), listener)
+func (k *k8sResolver) resolveKubernetesService(id *serviceId, port int, hostname string, listener updateListener) error {
+	if hostname == "" {
+		return k.resolveSplitKubernetesService(id, port, listener)
+	}
+
+	k.endpointsWatcher.subscribe(id, uint32(port), hostname, listener)
 
+	// The listener is closed by the server when it is dropped as well as when
+	// the server stops, so it is unsubscribed either way.
 	select {
 	case <-listener.ClientClose():
-		return k.endpointsWatcher.unsubscribe(id, uint32(port), listener)
 	case <-listener.ServerClose():
-		return nil
 	}
+	return k.endpointsWatcher.unsubscribe(id, uint32(port), hostname, listener)
+}
+
+// resolveSplitKubernetesService resolves a service whose traffic may be split
+// between several backing services.
+func (k *k8sResolver) resolveSplitKubernetesService(id *serviceId, port int, listener updateListener) error {
+	sub := k.trafficSplitWatcher.subscribe(id, uint32(port), listener)
+
+	select {
+	case <-listener.ClientClose():
+	case <-listener.ServerClose():
+	}
+	k.trafficSplitWatcher.unsubscribe(id, sub)
+	return nil
+}
+
+// applySearchPath returns the name that `host` refers to when it is looked up
+// from a pod in `namespace`, following the search path that Kubernetes sets
+// up for pods: "$host.$namespace.svc", then "$host.svc", then `host` itself.
+// The first of the names that is a known service, or an endpoint of one, is
+// returned. A name with a single label is always taken to be a service in
+// `namespace`, even if it doesn't exist yet. Fully qualified names, which end
+// with a dot, are returned unchanged.
+func (k *k8sResolver) applySearchPath(host, namespace string) string {
+	if strings.HasSuffix(host, ".") || namespace == "" {
+		return host
+	}
+
+	searchNames := []string{
+		fmt.Sprintf("%s.%s.svc", host, namespace),
+		fmt.Sprintf("%s.svc", host),
+	}
+	for _, name := range searchNames {
+		if k.exists(name) {
+			return name
+		}
+	}
+	if !strings.Contains(host, ".") {
+		return searchNames[0]
+	}
+	return host
+}
+
+// exists returns true if `host` is the name of a known service, or of an
+// endpoint of one.
+func (k *k8sResolver) exists(host string) bool {
+	id, hostname, err := k.localKubernetesServiceIdFromDNSName(host)
+	if err != nil || id == nil {
+		return false
+	}
+	return k.endpointsWatcher.exists(id, hostname)
 }
 
 // localKubernetesServiceIdFromDNSName returns the name of the service in
 // "namespace-name/service-name" form if `host` is a DNS name in a form used
 // for local Kubernetes services. It returns nil if `host` isn't in such a
-// form.
-func (k *k8sResolver) localKubernetesServiceIdFromDNSName(host string) (*serviceId, error) {
+// form. If `host` is the name of a single endpoint of the service, in
+// "hostname.service-name.namespace-name.svc" form, the endpoint's hostname is
+// returned as well; otherwise the returned hostname is empty.
+func (k *k8sResolver) localKubernetesServiceIdFromDNSName(host string) (*serviceId, string, error) {
 	hostLabels, err := splitDNSName(host)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// Verify that `host` ends with ".svc.$zone", ".svc.cluster.local," or ".svc".
@@ -93,6 +174,10 @@ func (k *k8sResolver) localKubernetesServiceIdFromDNSName(host string) (*service
 	if len(k.k8sDNSZoneLabels) > 0 {
 		hostLabels, matched = maybeStripSuffixLabels(hostLabels, k.k8sDNSZoneLabels)
 	}
+	// The services of remote clusters are only known by their own zone.
+	if k.remote && !matched {
+		return nil, "", nil
+	}
 	// Accept "cluster.local" as an alias for "$zone". The Kubernetes DNS
 	// specification
 	// (https://github.com/kubernetes/dns/blob/master/docs/specification.md)
@@ -114,20 +199,28 @@ func (k *k8sResolver) localKubernetesServiceIdFromDNSName(host string) (*service
 	// workaround until the proxies are configured to know "$zone."
 	hostLabels, matched = maybeStripSuffixLabels(hostLabels, []string{"svc"})
 	if !matched {
-		return nil, nil
+		return nil, "", nil
 	}
 
-	// Extract the service name and namespace. TODO: Federated services have
-	// *three* components before "svc"; see
+	// Extract the service name and namespace, and the hostname of the
+	// endpoint if there is one, e.g. the hostname of a StatefulSet pod behind
+	// a headless service. TODO: Federated services also have *three*
+	// components before "svc"; see
 	// https://github.com/runconduit/conduit/issues/156.
-	if len(hostLabels) != 2 {
-		return nil, fmt.Errorf("not a service: %s", host)
+	hostname := ""
+	switch len(hostLabels) {
+	case 2:
+	case 3:
+		hostname = hostLabels[0]
+		hostLabels = hostLabels[1:]
+	default:
+		return nil, "", fmt.Errorf("not a service: %s", host)
 	}
 
 	return &serviceId{
 		namespace: hostLabels[1],
 		name:      hostLabels[0],
-	}, nil
+	}, hostname, nil
 }
 
 func splitDNSName(dnsName string) ([]string, error) {
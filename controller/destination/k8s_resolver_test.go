@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/runconduit/conduit/controller/k8s"
 )
 
 func TestK8sResolver(t *testing.T) {
@@ -84,7 +86,7 @@ func TestLocalKubernetesServiceIdFromDNSName(t *testing.T) {
 	t.Run("Accepts 'cluster.local' as an alias for '$zone'", func(t *testing.T) {
 		resolver := &k8sResolver{k8sDNSZoneLabels: someKubernetesDNSZone}
 		nameWithClusterLocal := "name.ns.svc.cluster.local"
-		resolvedNameWithClusterLocal, err := resolver.localKubernetesServiceIdFromDNSName(nameWithClusterLocal)
+		resolvedNameWithClusterLocal, _, err := resolver.localKubernetesServiceIdFromDNSName(nameWithClusterLocal)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -94,7 +96,7 @@ func TestLocalKubernetesServiceIdFromDNSName(t *testing.T) {
 		}
 
 		nameWithZone := fmt.Sprintf("name.ns.svc.%s", strings.Join(someKubernetesDNSZone, "."))
-		resolvedNameWithZone, err := resolver.localKubernetesServiceIdFromDNSName(nameWithZone)
+		resolvedNameWithZone, _, err := resolver.localKubernetesServiceIdFromDNSName(nameWithZone)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -129,10 +131,118 @@ func TestLocalKubernetesServiceIdFromDNSName(t *testing.T) {
 		validServiceNames := map[string]string{"name.ns.svc": "name.ns"}
 		assertIsResolved(t, resolver, validServiceNames)
 
-		invalidServiceNames := []string{"", "something.name.ns.svc.cluster.local", "a.svc", "svc", "a.b.c.svc", "a.b.c.d.svc"}
+		invalidServiceNames := []string{"", "a.svc", "svc", "a.b.c.d.svc", "something.else.name.ns.svc.cluster.local"}
 		assertReturnError(t, resolver, invalidServiceNames)
 	})
 
+	t.Run("Resolves names of single endpoints of services", func(t *testing.T) {
+		resolver := &k8sResolver{k8sDNSZoneLabels: someKubernetesDNSZone}
+		endpointNames := map[string]string{
+			"web-0.web.ns.svc":                    "web-0.web.ns",
+			"web-1.web.ns.svc.cluster.local":      "web-1.web.ns",
+			"kafka-2.kafka.ns.svc.some.namespace": "kafka-2.kafka.ns",
+		}
+		assertIsResolved(t, resolver, endpointNames)
+	})
+
+	t.Run("Resolves names of remote services only if they end with '.svc.$zone'", func(t *testing.T) {
+		zone, err := splitDNSName("cluster-east")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resolver := &k8sResolver{k8sDNSZoneLabels: zone, remote: true}
+
+		resolvableServiceNames := map[string]string{
+			"web.prod.svc.cluster-east":       "web.prod",
+			"web.prod.svc.cluster-east.":      "web.prod",
+			"web-0.web.prod.svc.cluster-east": "web-0.web.prod",
+		}
+		assertIsResolved(t, resolver, resolvableServiceNames)
+
+		unresolvableServiceNames := []string{
+			"web.prod.svc",
+			"web.prod.svc.cluster.local",
+			"web.prod.svc.cluster-west",
+			"web.prod.cluster-east",
+		}
+		assertIsntResolved(t, resolver, unresolvableServiceNames)
+	})
+
+}
+
+func TestApplySearchPath(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: prod
+spec:
+  type: ClusterIP
+  ports:
+  - port: 80`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: default
+spec:
+  type: ClusterIP
+  ports:
+  - port: 80`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: kafka
+  namespace: prod
+spec:
+  type: ClusterIP
+  clusterIP: None
+  ports:
+  - port: 9092`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: kafka
+  namespace: prod
+subsets:
+- addresses:
+  - ip: 172.17.0.40
+    hostname: kafka-0
+  ports:
+  - port: 9092`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	resolver := newK8sResolver([]string{}, k8sAPI, newMockHostResolver(map[string][]string{}))
+
+	k8sAPI.Sync(nil)
+
+	for _, tt := range []struct {
+		host         string
+		namespace    string
+		expectedHost string
+	}{
+		{"web", "prod", "web.prod.svc"},
+		{"web", "default", "web.default.svc"},
+		{"missing", "prod", "missing.prod.svc"},
+		{"web.prod", "default", "web.prod.svc"},
+		{"web.default", "prod", "web.default.svc"},
+		{"kafka-0.kafka", "prod", "kafka-0.kafka.prod.svc"},
+		{"kafka-1.kafka", "prod", "kafka-1.kafka"},
+		{"web.prod.svc", "default", "web.prod.svc"},
+		{"web.prod.svc.cluster.local", "default", "web.prod.svc.cluster.local"},
+		{"web.", "prod", "web."},
+		{"api.example.com", "prod", "api.example.com"},
+	} {
+		t.Run(fmt.Sprintf("%s from %s", tt.host, tt.namespace), func(t *testing.T) {
+			actualHost := resolver.applySearchPath(tt.host, tt.namespace)
+			if actualHost != tt.expectedHost {
+				t.Fatalf("Expected [%s] to be looked up as [%s], got [%s]", tt.host, tt.expectedHost, actualHost)
+			}
+		})
+	}
 }
 
 func TestSplitDNSName(t *testing.T) {
@@ -216,7 +326,7 @@ func TestMaybeStripSuffixLabels(t *testing.T) {
 
 func assertReturnError(t *testing.T, resolver *k8sResolver, nameToExpectedError []string) {
 	for _, name := range nameToExpectedError {
-		resolvedName, err := resolver.localKubernetesServiceIdFromDNSName(name)
+		resolvedName, _, err := resolver.localKubernetesServiceIdFromDNSName(name)
 		if err == nil {
 			t.Fatalf("Expecting error, got resovled name [%s]", *resolvedName)
 		}
@@ -225,7 +335,7 @@ func assertReturnError(t *testing.T, resolver *k8sResolver, nameToExpectedError
 
 func assertIsResolved(t *testing.T, resolver *k8sResolver, nameToExpectedResolved map[string]string) {
 	for name, expectedResolvedName := range nameToExpectedResolved {
-		resolvedName, err := resolver.localKubernetesServiceIdFromDNSName(name)
+		resolvedName, hostname, err := resolver.localKubernetesServiceIdFromDNSName(name)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -234,15 +344,16 @@ func assertIsResolved(t *testing.T, resolver *k8sResolver, nameToExpectedResolve
 			t.Fatalf("Expected name [%s] to resolve to [%s], but got [%v]", name, expectedResolvedName, resolvedName)
 		}
 
-		if resolvedName.String() != expectedResolvedName {
-			t.Fatalf("Expected name [%s] to resolve to [%s], but got [%s]", name, expectedResolvedName, *resolvedName)
+		actualResolvedName := hostnameAndService(hostname, resolvedName)
+		if actualResolvedName != expectedResolvedName {
+			t.Fatalf("Expected name [%s] to resolve to [%s], but got [%s]", name, expectedResolvedName, actualResolvedName)
 		}
 	}
 }
 
 func assertIsntResolved(t *testing.T, resolver *k8sResolver, nameToExpectedNotResolved []string) {
 	for _, name := range nameToExpectedNotResolved {
-		resolvedName, err := resolver.localKubernetesServiceIdFromDNSName(name)
+		resolvedName, _, err := resolver.localKubernetesServiceIdFromDNSName(name)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
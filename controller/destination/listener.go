@@ -1,6 +1,11 @@
 package destination
 
 import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 	common "github.com/runconduit/conduit/controller/gen/common"
 	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	"github.com/runconduit/conduit/pkg/addr"
@@ -9,8 +14,59 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 )
 
+const (
+	// fullWeight is the weight of an endpoint that is ready and warmed up.
+	fullWeight = uint32(10000)
+	// minWeight is the weight of an endpoint that isn't ready or has only just
+	// become ready.
+	minWeight = uint32(1)
+	// weightRefreshSteps is the number of times the weights of warming
+	// endpoints are re-sent over the slow start window.
+	weightRefreshSteps = 10
+	// minWeightRefreshInterval bounds how often weights are re-sent.
+	minWeightRefreshInterval = time.Second
+	// crossZoneWeightPercent is the percentage of their weight that endpoints
+	// outside of the caller's zone get when zone-aware weights are enabled.
+	crossZoneWeightPercent = uint32(10)
+	// maxQueueDelay is how long updates may wait to be sent to a listener
+	// before it is dropped because its stream isn't keeping up. As queued
+	// updates are coalesced, a burst of updates only holds up a listener for
+	// as long as it takes to send the addresses that changed.
+	maxQueueDelay = 30 * time.Second
+	// maxQueuedUpdates is how many coalesced updates may be queued for a
+	// listener before it is dropped, whatever their delay, so that a stream
+	// that isn't keeping up with a large service can't hold on to an
+	// unbounded amount of memory.
+	maxQueuedUpdates = 10000
+
+	dropReasonQueueFull = "queue_full"
+	dropReasonSendError = "send_error"
+)
+
+var (
+	queuedUpdates = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "destination_queued_updates",
+			Help: "The number of coalesced address updates that are waiting to be sent to destination subscribers.",
+		},
+	)
+
+	droppedListeners = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "destination_dropped_subscribers_total",
+			Help: "A counter of destination subscribers that were dropped, by reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(queuedUpdates, droppedListeners)
+}
+
 type podsByIpFn func(string) ([]*coreV1.Pod, error)
 type ownerKindAndNameFn func(*coreV1.Pod) (string, string)
+type nodeZoneFn func(string) string
 
 type updateListener interface {
 	Update(add []common.TcpAddress, remove []common.TcpAddress)
@@ -21,28 +77,76 @@ type updateListener interface {
 	Stop()
 }
 
+// weightedUpdateListener is an updateListener whose addresses can be given
+// different weights, e.g. when traffic is split between several services. The
+// weight of each added address is scaled by its factor in weightFactors, keyed
+// by address.
+type weightedUpdateListener interface {
+	updateListener
+	UpdateWithWeightFactors(add []common.TcpAddress, remove []common.TcpAddress, weightFactors map[string]float64)
+}
+
 // implements the updateListener interface
 type endpointListener struct {
 	stream           pb.Destination_GetServer
 	podsByIp         podsByIpFn
 	ownerKindAndName ownerKindAndNameFn
+	nodeZone         nodeZoneFn
 	labels           map[string]string
 	enableTLS        bool
-	stopCh           chan struct{}
+	// the zone of the pod that subscribed, if known; endpoints in other zones
+	// get a fraction of their weight if it is set
+	callerZone string
+	// the time over which the weight of a newly ready pod is ramped up from
+	// minWeight to fullWeight; slow start is disabled if zero
+	slowStartWindow time.Duration
+	// addresses whose pods are warming up or not ready, keyed by address, with
+	// the weights they were last sent with; their weights are re-sent when
+	// they change
+	warming map[string]warmingAddress
+	// set while a go-routine is re-sending the weights of warming addresses
+	refreshingWeights bool
+	// factors by which the weights of addresses are scaled, keyed by address
+	weightFactors map[string]float64
+	// updates that are waiting to be sent by sendUpdates, which is signalled
+	// through pending whenever an update is queued
+	queue   updateQueue
+	pending chan struct{}
+	// when the oldest update in the queue was queued, and the length of the
+	// queue that was last added to the queuedUpdates gauge
+	queuedSince time.Time
+	queuedLen   int
+	// set once the listener has been dropped, after which no more updates are
+	// queued
+	dropped  bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	// This mutex guards the state above, which is changed by watch updates as
+	// well as by the go-routines that refresh weights and send updates.
+	mutex sync.Mutex
 }
 
 func newEndpointListener(
 	stream pb.Destination_GetServer,
 	podsByIp podsByIpFn,
 	ownerKindAndName ownerKindAndNameFn,
+	nodeZone nodeZoneFn,
 	enableTLS bool,
+	slowStartWindow time.Duration,
+	callerZone string,
 ) *endpointListener {
 	return &endpointListener{
 		stream:           stream,
 		podsByIp:         podsByIp,
 		ownerKindAndName: ownerKindAndName,
+		nodeZone:         nodeZone,
 		labels:           make(map[string]string),
 		enableTLS:        enableTLS,
+		callerZone:       callerZone,
+		slowStartWindow:  slowStartWindow,
+		warming:          make(map[string]warmingAddress),
+		weightFactors:    make(map[string]float64),
+		pending:          make(chan struct{}, 1),
 		stopCh:           make(chan struct{}),
 	}
 }
@@ -56,7 +160,16 @@ func (l *endpointListener) ServerClose() <-chan struct{} {
 }
 
 func (l *endpointListener) Stop() {
-	close(l.stopCh)
+	l.stopOnce.Do(func() { close(l.stopCh) })
+}
+
+// Dropped returns true if the listener was dropped because its stream failed
+// or couldn't keep up with updates.
+func (l *endpointListener) Dropped() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.dropped
 }
 
 func (l *endpointListener) SetServiceId(id *serviceId) {
@@ -69,45 +182,242 @@ func (l *endpointListener) SetServiceId(id *serviceId) {
 }
 
 func (l *endpointListener) Update(add []common.TcpAddress, remove []common.TcpAddress) {
-	if len(add) > 0 {
-		update := &pb.Update{
-			Update: &pb.Update_Add{
-				Add: l.toWeightedAddrSet(add),
-			},
-		}
-		err := l.stream.Send(update)
-		if err != nil {
-			log.Error(err)
+	l.UpdateWithWeightFactors(add, remove, nil)
+}
+
+func (l *endpointListener) UpdateWithWeightFactors(add []common.TcpAddress, remove []common.TcpAddress, weightFactors map[string]float64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.dropped {
+		return
+	}
+	if l.weightFactors == nil {
+		l.weightFactors = make(map[string]float64)
+	}
+	for i := range add {
+		key := addr.AddressToString(&add[i])
+		if factor, ok := weightFactors[key]; ok {
+			l.weightFactors[key] = factor
+		} else {
+			delete(l.weightFactors, key)
 		}
 	}
+
+	if len(add) > 0 {
+		l.queue.pushAdd(l.toWeightedAddrSet(add, time.Now()))
+		l.queued()
+	}
 	if len(remove) > 0 {
-		update := &pb.Update{
-			Update: &pb.Update_Remove{
-				Remove: l.toAddrSet(remove),
-			},
+		for i := range remove {
+			key := addr.AddressToString(&remove[i])
+			delete(l.warming, key)
+			delete(l.weightFactors, key)
 		}
+		l.queue.pushRemove(remove)
+		l.queued()
+	}
+}
+
+func (l *endpointListener) NoEndpoints(exists bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.dropped {
+		return
+	}
+	l.warming = make(map[string]warmingAddress)
+	l.weightFactors = make(map[string]float64)
+	l.queue.pushNoEndpoints(exists)
+	l.queued()
+}
+
+// queued accounts for an update that was just pushed onto the queue, and
+// wakes up sendUpdates. If more than maxQueuedUpdates updates are queued, or
+// they have been waiting for longer than maxQueueDelay, the stream has fallen
+// too far behind and the listener is dropped instead. The caller must hold
+// l.mutex.
+func (l *endpointListener) queued() {
+	now := time.Now()
+	if l.queuedSince.IsZero() {
+		l.queuedSince = now
+	}
+	l.resetQueuedLen(l.queue.len())
+
+	if l.queuedLen > maxQueuedUpdates {
+		log.Errorf("Dropping listener with %d updates queued", l.queuedLen)
+		l.drop(dropReasonQueueFull)
+		return
+	}
+	if delay := now.Sub(l.queuedSince); delay > maxQueueDelay {
+		log.Errorf("Dropping listener with %d updates queued for %s", l.queuedLen, delay)
+		l.drop(dropReasonQueueFull)
+		return
+	}
+
+	select {
+	case l.pending <- struct{}{}:
+	default:
+	}
+}
+
+// drop discards the queued updates and closes the listener, so that it is
+// unsubscribed from its destination. The caller must hold l.mutex.
+func (l *endpointListener) drop(reason string) {
+	if l.dropped {
+		return
+	}
+	l.dropped = true
+	l.queue = updateQueue{}
+	l.queuedSince = time.Time{}
+	l.resetQueuedLen(0)
+	droppedListeners.WithLabelValues(reason).Inc()
+	l.Stop()
+}
+
+// resetQueuedLen updates the queuedUpdates gauge with the listener's new queue
+// length. The caller must hold l.mutex.
+func (l *endpointListener) resetQueuedLen(n int) {
+	queuedUpdates.Add(float64(n - l.queuedLen))
+	l.queuedLen = n
+}
+
+// sendUpdates sends the queued updates whenever there are any, until the
+// listener is closed. If a send fails the listener is dropped. It should be
+// called as a go-routine.
+func (l *endpointListener) sendUpdates() {
+	for {
+		select {
+		case <-l.ClientClose():
+			return
+		case <-l.ServerClose():
+			return
+		case <-l.pending:
+			err := l.sendQueuedUpdates()
+			if err != nil {
+				log.Errorf("Dropping listener after failing to send update: %s", err)
+				l.mutex.Lock()
+				l.drop(dropReasonSendError)
+				l.mutex.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// sendQueuedUpdates empties the queue and sends its updates on the stream.
+// The stream is not sent to while l.mutex is held, so that a slow stream
+// doesn't hold up new updates.
+func (l *endpointListener) sendQueuedUpdates() error {
+	l.mutex.Lock()
+	updates := l.queue.pop()
+	l.queuedSince = time.Time{}
+	l.resetQueuedLen(0)
+	l.mutex.Unlock()
+
+	for _, update := range updates {
 		err := l.stream.Send(update)
 		if err != nil {
-			log.Error(err)
+			return err
 		}
 	}
+	return nil
 }
 
-func (l *endpointListener) NoEndpoints(exists bool) {
-	update := &pb.Update{
-		Update: &pb.Update_NoEndpoints{
-			NoEndpoints: &pb.NoEndpoints{
-				Exists: exists,
-			},
-		},
+// refreshWeights periodically re-sends the weights of the addresses whose pods
+// are warming up or not ready, until there are no such addresses left or the
+// listener is closed. It should be called as a go-routine.
+func (l *endpointListener) refreshWeights() {
+	interval := l.slowStartWindow / weightRefreshSteps
+	if interval < minWeightRefreshInterval {
+		interval = minWeightRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ClientClose():
+			return
+		case <-l.ServerClose():
+			return
+		case now := <-ticker.C:
+			l.resendWeights(now)
+			if l.doneRefreshingWeights() {
+				return
+			}
+		}
 	}
-	l.stream.Send(update)
 }
 
-func (l *endpointListener) toWeightedAddrSet(endpoints []common.TcpAddress) *pb.WeightedAddrSet {
+// doneRefreshingWeights returns true, and lets the next warming address start
+// a new refreshWeights go-routine, once there are no warming addresses left.
+func (l *endpointListener) doneRefreshingWeights() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.warming) > 0 {
+		return false
+	}
+	l.refreshingWeights = false
+	return true
+}
+
+// resendWeights sends the weights of the warming addresses whose weights
+// changed since they were last sent, as of `now`. Addresses that have reached
+// the full weight are no longer tracked.
+func (l *endpointListener) resendWeights(now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.warming) == 0 {
+		return
+	}
+
+	previous := l.warming
+	addrs := make([]common.TcpAddress, 0, len(previous))
+	for _, warming := range previous {
+		addrs = append(addrs, warming.address)
+	}
+	addrSet := l.toWeightedAddrSet(addrs, now)
+
+	changed := make([]*pb.WeightedAddr, 0)
+	for _, weightedAddr := range addrSet.Addrs {
+		if weightedAddr.Weight != previous[addr.AddressToString(weightedAddr.Addr)].weight {
+			changed = append(changed, weightedAddr)
+		}
+	}
+	if len(changed) > 0 && !l.dropped {
+		addrSet.Addrs = changed
+		l.queue.pushAdd(addrSet)
+		l.queued()
+	}
+}
+
+// toWeightedAddrSet also records which of the addresses haven't reached their
+// full weight yet, so that their weights are re-sent as they change. The
+// caller must hold l.mutex.
+func (l *endpointListener) toWeightedAddrSet(endpoints []common.TcpAddress, now time.Time) *pb.WeightedAddrSet {
+	warmingAddrs := make(map[string]warmingAddress)
+	for key, warming := range l.warming {
+		warmingAddrs[key] = warming
+	}
+
 	addrs := make([]*pb.WeightedAddr, 0)
 	for _, address := range endpoints {
-		addrs = append(addrs, l.toWeightedAddr(address))
+		weightedAddr, warming := l.toWeightedAddr(address, now)
+		if warming {
+			warmingAddrs[addr.AddressToString(&address)] = warmingAddress{address: address, weight: weightedAddr.Weight}
+		} else {
+			delete(warmingAddrs, addr.AddressToString(&address))
+		}
+		addrs = append(addrs, weightedAddr)
+	}
+	l.warming = warmingAddrs
+
+	if len(l.warming) > 0 && !l.refreshingWeights {
+		l.refreshingWeights = true
+		go l.refreshWeights()
 	}
 
 	return &pb.WeightedAddrSet{
@@ -116,9 +426,13 @@ func (l *endpointListener) toWeightedAddrSet(endpoints []common.TcpAddress) *pb.
 	}
 }
 
-func (l *endpointListener) toWeightedAddr(address common.TcpAddress) *pb.WeightedAddr {
+// toWeightedAddr returns the address with its weight and metric labels, and
+// whether its weight is expected to change as its pod warms up.
+func (l *endpointListener) toWeightedAddr(address common.TcpAddress, now time.Time) (*pb.WeightedAddr, bool) {
 	var tlsIdentity *pb.TlsIdentity
 	metricLabelsForPod := map[string]string{}
+	weight := fullWeight
+	warming := false
 	ipAsString := addr.IPToString(address.Ip)
 
 	resultingPods, err := l.podsByIp(ipAsString)
@@ -132,6 +446,18 @@ func (l *endpointListener) toWeightedAddr(address common.TcpAddress) *pb.Weighte
 				metricLabelsForPod = pkgK8s.GetOwnerLabels(pod.ObjectMeta)
 				metricLabelsForPod["pod"] = pod.Name
 				tlsIdentity = l.toTlsIdentity(pod)
+				weight = podWeight(pod, now, l.slowStartWindow)
+				warming = weight < fullWeight
+				if _, ready := podReadySince(pod); !ready {
+					metricLabelsForPod["not_ready"] = "true"
+				}
+				if pod.Spec.NodeName != "" {
+					metricLabelsForPod["node"] = pod.Spec.NodeName
+					if zone := l.zoneForNode(pod.Spec.NodeName); zone != "" {
+						metricLabelsForPod["zone"] = zone
+						weight = l.zoneWeight(weight, zone)
+					}
+				}
 				break
 			}
 		}
@@ -140,20 +466,55 @@ func (l *endpointListener) toWeightedAddr(address common.TcpAddress) *pb.Weighte
 		}
 	}
 
+	if factor, ok := l.weightFactors[addr.AddressToString(&address)]; ok {
+		weight = scaleWeight(weight, factor)
+	}
+
 	return &pb.WeightedAddr{
 		Addr:         &address,
-		Weight:       1,
+		Weight:       weight,
 		MetricLabels: metricLabelsForPod,
 		TlsIdentity:  tlsIdentity,
+	}, warming
+}
+
+// warmingAddress is an address whose pod is warming up or not ready, along
+// with the weight it was last sent with.
+type warmingAddress struct {
+	address common.TcpAddress
+	weight  uint32
+}
+
+func (l *endpointListener) zoneForNode(nodeName string) string {
+	if l.nodeZone == nil {
+		return ""
 	}
+	return l.nodeZone(nodeName)
 }
 
-func (l *endpointListener) toAddrSet(endpoints []common.TcpAddress) *pb.AddrSet {
-	addrs := make([]*common.TcpAddress, 0)
-	for i := range endpoints {
-		addrs = append(addrs, &endpoints[i])
+// zoneWeight scales down the weight of endpoints outside of the caller's zone,
+// if the caller's zone is known.
+func (l *endpointListener) zoneWeight(weight uint32, zone string) uint32 {
+	if l.callerZone == "" || zone == l.callerZone {
+		return weight
 	}
-	return &pb.AddrSet{Addrs: addrs}
+
+	weight = weight * crossZoneWeightPercent / 100
+	if weight < minWeight {
+		return minWeight
+	}
+	return weight
+}
+
+func scaleWeight(weight uint32, factor float64) uint32 {
+	scaled := float64(weight) * factor
+	if scaled < float64(minWeight) {
+		return minWeight
+	}
+	if scaled > float64(math.MaxUint32) {
+		return math.MaxUint32
+	}
+	return uint32(scaled)
 }
 
 func (l *endpointListener) toTlsIdentity(pod *coreV1.Pod) *pb.TlsIdentity {
@@ -180,3 +541,45 @@ func (l *endpointListener) toTlsIdentity(pod *coreV1.Pod) *pb.TlsIdentity {
 		},
 	}
 }
+
+// podWeight returns the weight of an endpoint backed by `pod` at time `now`.
+// Pods that aren't ready get the minimum weight. Ready pods ramp up linearly
+// from the minimum to the full weight over the slow start window, starting
+// from when they became ready.
+func podWeight(pod *coreV1.Pod, now time.Time, slowStartWindow time.Duration) uint32 {
+	readySince, ready := podReadySince(pod)
+	if !ready {
+		return minWeight
+	}
+	if slowStartWindow <= 0 {
+		return fullWeight
+	}
+
+	elapsed := now.Sub(readySince)
+	if elapsed >= slowStartWindow {
+		return fullWeight
+	}
+	if elapsed <= 0 {
+		return minWeight
+	}
+
+	weight := uint32(int64(fullWeight) * int64(elapsed) / int64(slowStartWindow))
+	if weight < minWeight {
+		return minWeight
+	}
+	return weight
+}
+
+// podReadySince returns whether the pod is ready and since when. Pods without a
+// readiness condition are considered ready since they started.
+func podReadySince(pod *coreV1.Pod) (time.Time, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == coreV1.PodReady {
+			return condition.LastTransitionTime.Time, condition.Status == coreV1.ConditionTrue
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time, true
+	}
+	return time.Time{}, true
+}
@@ -2,8 +2,10 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	common "github.com/runconduit/conduit/controller/gen/common"
 	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
@@ -35,6 +37,10 @@ func defaultOwnerKindAndName(pod *v1.Pod) (string, string) {
 	return "", ""
 }
 
+func noNodeZone(nodeName string) string {
+	return ""
+}
+
 func TestEndpointListener(t *testing.T) {
 	t.Run("Sends one update for add and another for remove", func(t *testing.T) {
 		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
@@ -50,6 +56,7 @@ func TestEndpointListener(t *testing.T) {
 		removedAddress1 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 100}}, Port: 100}
 
 		listener.Update([]common.TcpAddress{addedAddress1, addedAddress2}, []common.TcpAddress{removedAddress1})
+		listener.sendQueuedUpdates()
 
 		expectedNumUpdates := 2
 		actualNumUpdates := len(mockGetServer.updatesReceived)
@@ -72,6 +79,7 @@ func TestEndpointListener(t *testing.T) {
 		removedAddress1 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 100}}, Port: 100}
 
 		listener.Update([]common.TcpAddress{addedAddress1, addedAddress2}, []common.TcpAddress{removedAddress1})
+		listener.sendQueuedUpdates()
 
 		addressesAdded := mockGetServer.updatesReceived[0].GetAdd().Addrs
 		actualNumberOfAdded := len(addressesAdded)
@@ -158,6 +166,7 @@ func TestEndpointListener(t *testing.T) {
 		}
 
 		listener.Update([]common.TcpAddress{addedAddress1, addedAddress2}, nil)
+		listener.sendQueuedUpdates()
 
 		actualGlobalMetricLabels := mockGetServer.updatesReceived[0].GetAdd().MetricLabels
 		expectedGlobalMetricLabels := map[string]string{"namespace": expectedNamespace, "service": expectedServiceName}
@@ -218,6 +227,7 @@ func TestEndpointListener(t *testing.T) {
 		}
 
 		listener.Update([]common.TcpAddress{addedAddress}, nil)
+		listener.sendQueuedUpdates()
 
 		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
 		if len(addrs) != 1 {
@@ -268,6 +278,7 @@ func TestEndpointListener(t *testing.T) {
 		}
 
 		listener.Update([]common.TcpAddress{addedAddress}, nil)
+		listener.sendQueuedUpdates()
 
 		addrs := mockGetServer.updatesReceived[0].GetAdd().GetAddrs()
 		if len(addrs) != 1 {
@@ -363,6 +374,7 @@ func TestEndpointListener(t *testing.T) {
 			}
 
 			listener.Update([]common.TcpAddress{exp.address}, nil)
+			listener.sendQueuedUpdates()
 
 			actualGlobalMetricLabels := mockGetServer.updatesReceived[0].GetAdd().MetricLabels
 			if !reflect.DeepEqual(actualGlobalMetricLabels, exp.listenerLabels) {
@@ -377,10 +389,407 @@ func TestEndpointListener(t *testing.T) {
 	})
 }
 
+func TestEndpointListenerZones(t *testing.T) {
+	nodeZones := func(nodeName string) string {
+		return map[string]string{
+			"node-a": "zone-a",
+			"node-b": "zone-b",
+		}[nodeName]
+	}
+
+	addressA := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 1}}, Port: 1}
+	addressB := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 2}}, Port: 1}
+	podIndex := func(ip string) ([]*v1.Pod, error) {
+		nodeName := map[string]string{
+			addr.IPToString(addressA.Ip): "node-a",
+			addr.IPToString(addressB.Ip): "node-b",
+		}[ip]
+		return []*v1.Pod{
+			&v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-" + nodeName},
+				Spec:       v1.PodSpec{NodeName: nodeName},
+				Status:     v1.PodStatus{Phase: v1.PodRunning},
+			},
+		}, nil
+	}
+
+	t.Run("Sends node and zone metric labels with added addresses", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, podIndex, defaultOwnerKindAndName, nodeZones, false, 0, "")
+
+		listener.Update([]common.TcpAddress{addressA}, nil)
+		listener.sendQueuedUpdates()
+
+		actualMetricLabels := mockGetServer.updatesReceived[0].GetAdd().Addrs[0].MetricLabels
+		expectedMetricLabels := map[string]string{
+			"pod":  "pod-node-a",
+			"node": "node-a",
+			"zone": "zone-a",
+		}
+		if !reflect.DeepEqual(actualMetricLabels, expectedMetricLabels) {
+			t.Fatalf("Expected metric labels sent to be [%v] but was [%v]", expectedMetricLabels, actualMetricLabels)
+		}
+	})
+
+	t.Run("Biases weights toward the caller's zone", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, podIndex, defaultOwnerKindAndName, nodeZones, false, 0, "zone-b")
+
+		listener.Update([]common.TcpAddress{addressA, addressB}, nil)
+		listener.sendQueuedUpdates()
+
+		addrs := mockGetServer.updatesReceived[0].GetAdd().Addrs
+		expectedWeights := []uint32{fullWeight * crossZoneWeightPercent / 100, fullWeight}
+		actualWeights := []uint32{addrs[0].Weight, addrs[1].Weight}
+		if !reflect.DeepEqual(actualWeights, expectedWeights) {
+			t.Fatalf("Expected weights to be %v, got %v", expectedWeights, actualWeights)
+		}
+
+		if len(listener.warming) != 0 {
+			t.Fatalf("Expected no warming addresses, got %v", listener.warming)
+		}
+	})
+}
+
+func TestPodWeight(t *testing.T) {
+	readySince := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	window := 100 * time.Second
+
+	readyPod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				v1.PodCondition{
+					Type:               v1.PodReady,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(readySince),
+				},
+			},
+		},
+	}
+	notReadyPod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				v1.PodCondition{
+					Type:               v1.PodReady,
+					Status:             v1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(readySince),
+				},
+			},
+		},
+	}
+	startTime := metav1.NewTime(readySince)
+	startedPod := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase:     v1.PodRunning,
+			StartTime: &startTime,
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		pod            *v1.Pod
+		now            time.Time
+		window         time.Duration
+		expectedWeight uint32
+	}{
+		{"ready pods get the full weight without slow start", readyPod, readySince, 0, fullWeight},
+		{"pods that aren't ready get the minimum weight", notReadyPod, readySince.Add(time.Hour), window, minWeight},
+		{"pods that just became ready get the minimum weight", readyPod, readySince, window, minWeight},
+		{"pods ramp up linearly over the window", readyPod, readySince.Add(window / 4), window, fullWeight / 4},
+		{"pods get the full weight after the window", readyPod, readySince.Add(window), window, fullWeight},
+		{"pods without readiness ramp up from their start time", startedPod, readySince.Add(window / 2), window, fullWeight / 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actualWeight := podWeight(tt.pod, tt.now, tt.window)
+			if actualWeight != tt.expectedWeight {
+				t.Fatalf("Expected weight to be [%d], got [%d]", tt.expectedWeight, actualWeight)
+			}
+		})
+	}
+}
+
+func TestEndpointListenerSlowStart(t *testing.T) {
+	t.Run("Re-sends weights of warming pods until they are fully warm", func(t *testing.T) {
+		window := 100 * time.Second
+		readySince := time.Now()
+
+		address := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 666}}, Port: 1}
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod1",
+				Namespace: "this-namespace",
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					v1.PodCondition{
+						Type:               v1.PodReady,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(readySince),
+					},
+				},
+			},
+		}
+		podIndex := func(ip string) ([]*v1.Pod, error) {
+			return []*v1.Pod{pod}, nil
+		}
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}, contextToReturn: ctx}
+		listener := newEndpointListener(mockGetServer, podIndex, defaultOwnerKindAndName, noNodeZone, false, window, "")
+
+		listener.Update([]common.TcpAddress{address}, nil)
+		listener.sendQueuedUpdates()
+
+		initialWeight := mockGetServer.updatesReceived[0].GetAdd().Addrs[0].Weight
+		if initialWeight >= fullWeight {
+			t.Fatalf("Expected initial weight to be less than [%d], got [%d]", fullWeight, initialWeight)
+		}
+
+		listener.resendWeights(readySince.Add(window / 2))
+		listener.sendQueuedUpdates()
+		listener.resendWeights(readySince.Add(window))
+		listener.sendQueuedUpdates()
+		listener.resendWeights(readySince.Add(2 * window))
+		listener.sendQueuedUpdates()
+
+		expectedWeights := []uint32{fullWeight / 2, fullWeight}
+		actualWeights := make([]uint32, 0)
+		for _, update := range mockGetServer.updatesReceived[1:] {
+			actualWeights = append(actualWeights, update.GetAdd().Addrs[0].Weight)
+		}
+		if !reflect.DeepEqual(actualWeights, expectedWeights) {
+			t.Fatalf("Expected re-sent weights to be %v, got %v", expectedWeights, actualWeights)
+		}
+	})
+
+	t.Run("Stops re-sending weights of removed addresses", func(t *testing.T) {
+		window := 100 * time.Second
+		readySince := time.Now()
+
+		address := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 666}}, Port: 1}
+		pod := &v1.Pod{
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					v1.PodCondition{
+						Type:               v1.PodReady,
+						Status:             v1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(readySince),
+					},
+				},
+			},
+		}
+		podIndex := func(ip string) ([]*v1.Pod, error) {
+			return []*v1.Pod{pod}, nil
+		}
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}, contextToReturn: ctx}
+		listener := newEndpointListener(mockGetServer, podIndex, defaultOwnerKindAndName, noNodeZone, false, window, "")
+
+		listener.Update([]common.TcpAddress{address}, nil)
+		listener.sendQueuedUpdates()
+		listener.Update(nil, []common.TcpAddress{address})
+		listener.sendQueuedUpdates()
+		listener.resendWeights(readySince.Add(window / 2))
+		listener.sendQueuedUpdates()
+
+		expectedNumUpdates := 2
+		actualNumUpdates := len(mockGetServer.updatesReceived)
+		if actualNumUpdates != expectedNumUpdates {
+			t.Fatalf("Expecting [%d] updates, got [%d]. Updates: %v", expectedNumUpdates, actualNumUpdates, mockGetServer.updatesReceived)
+		}
+	})
+}
+
+func TestEndpointListenerNotReady(t *testing.T) {
+	t.Run("Labels not-ready pods and re-sends their weights once they are ready", func(t *testing.T) {
+		address := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 666}}, Port: 1}
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod1",
+				Namespace: "this-namespace",
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					v1.PodCondition{
+						Type:   v1.PodReady,
+						Status: v1.ConditionFalse,
+					},
+				},
+			},
+		}
+		podIndex := func(ip string) ([]*v1.Pod, error) {
+			return []*v1.Pod{pod}, nil
+		}
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}, contextToReturn: ctx}
+		listener := newEndpointListener(mockGetServer, podIndex, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		listener.Update([]common.TcpAddress{address}, nil)
+		listener.sendQueuedUpdates()
+
+		notReadyAddr := mockGetServer.updatesReceived[0].GetAdd().Addrs[0]
+		if notReadyAddr.Weight != minWeight {
+			t.Fatalf("Expected not-ready address to have weight [%d], got [%d]", minWeight, notReadyAddr.Weight)
+		}
+		if notReadyAddr.MetricLabels["not_ready"] != "true" {
+			t.Fatalf("Expected not-ready address to be labeled, got %v", notReadyAddr.MetricLabels)
+		}
+
+		// Re-sending without a change in readiness sends nothing.
+		listener.resendWeights(time.Now())
+		listener.sendQueuedUpdates()
+
+		pod = pod.DeepCopy()
+		pod.Status.Conditions[0].Status = v1.ConditionTrue
+		listener.resendWeights(time.Now())
+		listener.sendQueuedUpdates()
+
+		expectedNumUpdates := 2
+		actualNumUpdates := len(mockGetServer.updatesReceived)
+		if actualNumUpdates != expectedNumUpdates {
+			t.Fatalf("Expecting [%d] updates, got [%d]. Updates: %v", expectedNumUpdates, actualNumUpdates, mockGetServer.updatesReceived)
+		}
+
+		readyAddr := mockGetServer.updatesReceived[1].GetAdd().Addrs[0]
+		checkAddress(t, readyAddr, &address)
+		if _, ok := readyAddr.MetricLabels["not_ready"]; ok {
+			t.Fatalf("Expected ready address not to be labeled, got %v", readyAddr.MetricLabels)
+		}
+	})
+}
+
+func TestEndpointListenerQueue(t *testing.T) {
+	address1 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 1}}, Port: 1}
+	address2 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 2}}, Port: 2}
+
+	t.Run("Coalesces updates that are queued before they are sent", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, noPodsByIp, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		listener.Update([]common.TcpAddress{address1}, nil)
+		listener.Update([]common.TcpAddress{address2}, []common.TcpAddress{address1})
+		listener.sendQueuedUpdates()
+
+		expectedUpdates := []string{"add 0.0.0.2:2", "remove 0.0.0.1:1"}
+		actualUpdates := updateStrings(mockGetServer.updatesReceived)
+		if !reflect.DeepEqual(actualUpdates, expectedUpdates) {
+			t.Fatalf("Expected updates %v, got %v", expectedUpdates, actualUpdates)
+		}
+	})
+
+	t.Run("Doesn't drop the listener for a burst of coalesced updates", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, noPodsByIp, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		for i := 0; i < 1000; i++ {
+			listener.Update([]common.TcpAddress{address1}, nil)
+			listener.Update(nil, []common.TcpAddress{address1})
+		}
+
+		if listener.Dropped() {
+			t.Fatalf("Expected the listener not to be dropped")
+		}
+		if listener.queuedLen != 1 {
+			t.Fatalf("Expected [1] queued update, got [%d]", listener.queuedLen)
+		}
+	})
+
+	t.Run("Drops the listener when its updates have waited too long", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, noPodsByIp, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		listener.NoEndpoints(true)
+		listener.queuedSince = listener.queuedSince.Add(-maxQueueDelay - time.Second)
+		listener.NoEndpoints(true)
+
+		select {
+		case <-listener.ServerClose():
+		default:
+			t.Fatalf("Expected the listener to be closed")
+		}
+		if !listener.Dropped() {
+			t.Fatalf("Expected the listener to be dropped")
+		}
+
+		listener.Update([]common.TcpAddress{address1}, nil)
+		listener.sendQueuedUpdates()
+		if len(mockGetServer.updatesReceived) != 0 {
+			t.Fatalf("Expected no updates to be sent, got %v", mockGetServer.updatesReceived)
+		}
+	})
+
+	t.Run("Drops the listener when too many updates are queued", func(t *testing.T) {
+		mockGetServer := &mockDestination_GetServer{updatesReceived: []*pb.Update{}}
+		listener := newEndpointListener(mockGetServer, noPodsByIp, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		addresses := make([]common.TcpAddress, maxQueuedUpdates+1)
+		for i := range addresses {
+			addresses[i] = common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: uint32(i)}}, Port: 1}
+		}
+		listener.Update(addresses[:maxQueuedUpdates], nil)
+		if listener.Dropped() {
+			t.Fatalf("Expected the listener not to be dropped at [%d] queued updates", maxQueuedUpdates)
+		}
+
+		listener.Update(addresses[maxQueuedUpdates:], nil)
+		if !listener.Dropped() {
+			t.Fatalf("Expected the listener to be dropped")
+		}
+		select {
+		case <-listener.ServerClose():
+		default:
+			t.Fatalf("Expected the listener to be closed")
+		}
+	})
+
+	t.Run("Drops the listener when a send fails", func(t *testing.T) {
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+		mockGetServer := &mockDestination_GetServer{
+			updatesReceived: []*pb.Update{},
+			contextToReturn: ctx,
+			errorToReturn:   errors.New("stream closed"),
+		}
+		listener := newEndpointListener(mockGetServer, noPodsByIp, defaultOwnerKindAndName, noNodeZone, false, 0, "")
+
+		done := make(chan struct{})
+		go func() {
+			listener.sendUpdates()
+			close(done)
+		}()
+
+		listener.Update([]common.TcpAddress{address1}, nil)
+
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Timed out waiting for the update to be sent")
+		}
+		if !listener.Dropped() {
+			t.Fatalf("Expected the listener to be dropped")
+		}
+		select {
+		case <-listener.ServerClose():
+		default:
+			t.Fatalf("Expected the listener to be closed")
+		}
+	})
+}
+
 func checkAddress(t *testing.T, addr *pb.WeightedAddr, expectedAddress *common.TcpAddress) {
 	actualAddress := addr.Addr
 	actualWeight := addr.Weight
-	expectedWeight := uint32(1)
+	expectedWeight := fullWeight
 
 	if !reflect.DeepEqual(actualAddress, expectedAddress) || actualWeight != expectedWeight {
 		t.Fatalf("Expected added address to be [%+v] and weight to be [%d], but it was [%+v] and [%d]", expectedAddress, expectedWeight, actualAddress, actualWeight)
@@ -0,0 +1,252 @@
+package destination
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/controller/k8s"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+)
+
+// profileWatcher watches ServiceProfiles and sends the routes of each service
+// to the listeners subscribed to it. The ServiceProfile of a service has the
+// same name and namespace as the service:
+//
+//	kind: ServiceProfile
+//	apiVersion: conduit.io/v1alpha1
+//	metadata:
+//	  name: web
+//	  namespace: ns
+//	spec:
+//	  routes:
+//	  - name: get-user
+//	    method: GET
+//	    pathRegex: /users/[^/]+
+//	    timeout: 2s
+//	    isRetryable: true
+//
+// Services without a ServiceProfile have no routes.
+type profileWatcher struct {
+	store cache.Store
+	// a map of service -> listeners subscribed to its profile
+	subscriptions map[serviceId][]*profileListener
+	// This mutex protects the subscriptions map and serializes the updates
+	// sent to listeners, so that they always end with the current profile.
+	mutex sync.Mutex
+}
+
+func newProfileWatcher(informer cache.SharedIndexInformer) *profileWatcher {
+	watcher := &profileWatcher{
+		store:         informer.GetStore(),
+		subscriptions: make(map[serviceId][]*profileListener),
+	}
+
+	informer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    watcher.addProfile,
+			UpdateFunc: watcher.updateProfile,
+			DeleteFunc: watcher.deleteProfile,
+		},
+	)
+
+	return watcher
+}
+
+// Subscribe to the profile of a service. The listener is given the current
+// profile right away, and then each time it changes.
+func (p *profileWatcher) subscribe(id *serviceId, listener *profileListener) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.subscriptions[*id] = append(p.subscriptions[*id], listener)
+	listener.Update(p.getProfile(id))
+}
+
+func (p *profileWatcher) unsubscribe(id *serviceId, listener *profileListener) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	listeners := p.subscriptions[*id]
+	for i, item := range listeners {
+		if item == listener {
+			listeners[i] = listeners[len(listeners)-1]
+			listeners[len(listeners)-1] = nil
+			listeners = listeners[:len(listeners)-1]
+			break
+		}
+	}
+	if len(listeners) == 0 {
+		delete(p.subscriptions, *id)
+	} else {
+		p.subscriptions[*id] = listeners
+	}
+}
+
+// stop closes all of the listeners, which ends their streams.
+func (p *profileWatcher) stop() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, listeners := range p.subscriptions {
+		for _, listener := range listeners {
+			listener.Stop()
+		}
+	}
+}
+
+// getProfile returns the current profile of a service, which has no routes if
+// the service has no ServiceProfile. The caller must hold p.mutex.
+func (p *profileWatcher) getProfile(id *serviceId) *pb.DestinationProfile {
+	obj, exists, err := p.store.GetByKey(id.namespace + "/" + id.name)
+	if err != nil {
+		log.Errorf("Error getting service profile for %s: %s", id, err)
+		return &pb.DestinationProfile{}
+	}
+	if !exists {
+		return &pb.DestinationProfile{}
+	}
+	return toDestinationProfile(obj.(*k8s.ServiceProfile))
+}
+
+func (p *profileWatcher) addProfile(obj interface{}) {
+	profile := obj.(*k8s.ServiceProfile)
+	p.updateSubscribers(&serviceId{namespace: profile.Namespace, name: profile.Name})
+}
+
+func (p *profileWatcher) updateProfile(oldObj, newObj interface{}) {
+	profile := newObj.(*k8s.ServiceProfile)
+	p.updateSubscribers(&serviceId{namespace: profile.Namespace, name: profile.Name})
+}
+
+func (p *profileWatcher) deleteProfile(obj interface{}) {
+	profile, ok := obj.(*k8s.ServiceProfile)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			log.Errorf("Couldn't get object from tombstone %+v", obj)
+			return
+		}
+		profile, ok = tombstone.Obj.(*k8s.ServiceProfile)
+		if !ok {
+			log.Errorf("Tombstone contained object that is not a service profile %+v", obj)
+			return
+		}
+	}
+	p.updateSubscribers(&serviceId{namespace: profile.Namespace, name: profile.Name})
+}
+
+// updateSubscribers sends the current profile of a service to its listeners.
+// The profile is read from the store rather than taken from the event, so
+// that listeners never go back to an older version.
+func (p *profileWatcher) updateSubscribers(id *serviceId) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	listeners, ok := p.subscriptions[*id]
+	if !ok {
+		return
+	}
+
+	profile := p.getProfile(id)
+	for _, listener := range listeners {
+		listener.Update(profile)
+	}
+}
+
+// toDestinationProfile converts a ServiceProfile to the profile that is sent to
+// proxies. Routes with an invalid path regex or timeout are left out, since
+// the proxies couldn't apply them.
+func toDestinationProfile(profile *k8s.ServiceProfile) *pb.DestinationProfile {
+	routes := make([]*pb.Route, 0)
+	for _, spec := range profile.Spec.Routes {
+		_, err := regexp.Compile(spec.PathRegex)
+		if err != nil {
+			log.Errorf("Skipping route %s of service profile %s/%s with invalid path regex: %s", spec.Name, profile.Namespace, profile.Name, err)
+			continue
+		}
+
+		route := &pb.Route{
+			Name:        spec.Name,
+			Method:      spec.Method,
+			PathRegex:   spec.PathRegex,
+			IsRetryable: spec.IsRetryable,
+		}
+		if spec.Timeout != "" {
+			timeout, err := time.ParseDuration(spec.Timeout)
+			if err != nil {
+				log.Errorf("Skipping route %s of service profile %s/%s with invalid timeout: %s", spec.Name, profile.Namespace, profile.Name, err)
+				continue
+			}
+			route.Timeout = ptypes.DurationProto(timeout)
+		}
+		routes = append(routes, route)
+	}
+	return &pb.DestinationProfile{Routes: routes}
+}
+
+// profileListener sends the profiles of a service on a GetProfile stream. Only
+// the latest profile is kept, so a stream that falls behind skips straight to
+// the current profile.
+type profileListener struct {
+	stream pb.Destination_GetProfileServer
+	// the profile that is waiting to be sent by sendUpdates, which is
+	// signalled through pending whenever it changes
+	latest   *pb.DestinationProfile
+	pending  chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	mutex    sync.Mutex
+}
+
+func newProfileListener(stream pb.Destination_GetProfileServer) *profileListener {
+	return &profileListener{
+		stream:  stream,
+		pending: make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+func (l *profileListener) Update(profile *pb.DestinationProfile) {
+	l.mutex.Lock()
+	l.latest = profile
+	l.mutex.Unlock()
+
+	select {
+	case l.pending <- struct{}{}:
+	default:
+	}
+}
+
+func (l *profileListener) Stop() {
+	l.stopOnce.Do(func() { close(l.stopCh) })
+}
+
+// sendUpdates sends each new profile until the client or the server closes the
+// stream, or a send fails, in which case the error is returned.
+func (l *profileListener) sendUpdates() error {
+	for {
+		select {
+		case <-l.stream.Context().Done():
+			return nil
+		case <-l.stopCh:
+			return nil
+		case <-l.pending:
+			l.mutex.Lock()
+			profile := l.latest
+			l.latest = nil
+			l.mutex.Unlock()
+
+			if profile == nil {
+				continue
+			}
+			err := l.stream.Send(profile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package destination
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/controller/k8s"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+type mockDestination_GetProfileServer struct {
+	errorToReturn   error
+	contextToReturn context.Context
+	profiles        chan *pb.DestinationProfile
+}
+
+func (m *mockDestination_GetProfileServer) Send(profile *pb.DestinationProfile) error {
+	m.profiles <- profile
+	return m.errorToReturn
+}
+
+func (m *mockDestination_GetProfileServer) SetHeader(metadata.MD) error  { return m.errorToReturn }
+func (m *mockDestination_GetProfileServer) SendHeader(metadata.MD) error { return m.errorToReturn }
+func (m *mockDestination_GetProfileServer) SetTrailer(metadata.MD)       {}
+func (m *mockDestination_GetProfileServer) Context() context.Context     { return m.contextToReturn }
+func (m *mockDestination_GetProfileServer) SendMsg(x interface{}) error  { return m.errorToReturn }
+func (m *mockDestination_GetProfileServer) RecvMsg(x interface{}) error  { return m.errorToReturn }
+
+func newServiceProfile(namespace, name string, routes ...k8s.RouteSpec) *k8s.ServiceProfile {
+	return &k8s.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: "1"},
+		Spec:       k8s.ServiceProfileSpec{Routes: routes},
+	}
+}
+
+func TestToDestinationProfile(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		routes          []k8s.RouteSpec
+		expectedProfile *pb.DestinationProfile
+	}{
+		{
+			"converts all fields of a route",
+			[]k8s.RouteSpec{
+				{Name: "get-user", Method: "GET", PathRegex: "/users/[^/]+", Timeout: "2500ms", IsRetryable: true},
+			},
+			&pb.DestinationProfile{Routes: []*pb.Route{
+				{Name: "get-user", Method: "GET", PathRegex: "/users/[^/]+", Timeout: &duration.Duration{Seconds: 2, Nanos: 500000000}, IsRetryable: true},
+			}},
+		},
+		{
+			"leaves the timeout unset if the route doesn't have one",
+			[]k8s.RouteSpec{
+				{Name: "list-users", PathRegex: "/users"},
+			},
+			&pb.DestinationProfile{Routes: []*pb.Route{
+				{Name: "list-users", PathRegex: "/users"},
+			}},
+		},
+		{
+			"skips routes with an invalid path regex or timeout",
+			[]k8s.RouteSpec{
+				{Name: "bad-regex", PathRegex: "/users/[a-"},
+				{Name: "bad-timeout", PathRegex: "/users", Timeout: "2 seconds"},
+				{Name: "good", PathRegex: "/"},
+			},
+			&pb.DestinationProfile{Routes: []*pb.Route{
+				{Name: "good", PathRegex: "/"},
+			}},
+		},
+		{
+			"returns a profile without routes if there are none",
+			nil,
+			&pb.DestinationProfile{Routes: []*pb.Route{}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actualProfile := toDestinationProfile(newServiceProfile("ns", "web", tt.routes...))
+			if !proto.Equal(actualProfile, tt.expectedProfile) {
+				t.Fatalf("Expected profile [%v], got [%v]", tt.expectedProfile, actualProfile)
+			}
+		})
+	}
+}
+
+func TestProfileWatcher(t *testing.T) {
+	fakeWatch := watch.NewFake()
+	informer := k8s.NewServiceProfileInformerFor(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return &k8s.ServiceProfileList{Items: []k8s.ServiceProfile{
+				*newServiceProfile("ns", "web", k8s.RouteSpec{Name: "index", PathRegex: "/"}),
+			}}, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return fakeWatch, nil
+		},
+	})
+	watcher := newProfileWatcher(informer)
+
+	done := make(chan struct{})
+	defer close(done)
+	go informer.Run(done)
+	if !cache.WaitForCacheSync(done, informer.HasSynced) {
+		t.Fatalf("Informer failed to sync")
+	}
+
+	subscribe := func(id *serviceId) (*mockDestination_GetProfileServer, chan error, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &mockDestination_GetProfileServer{
+			contextToReturn: ctx,
+			profiles:        make(chan *pb.DestinationProfile, 10),
+		}
+		listener := newProfileListener(stream)
+		watcher.subscribe(id, listener)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- listener.sendUpdates()
+			watcher.unsubscribe(id, listener)
+		}()
+		return stream, errCh, cancel
+	}
+
+	expectRoutes := func(t *testing.T, stream *mockDestination_GetProfileServer, expectedRoutes []string) {
+		select {
+		case profile := <-stream.profiles:
+			actualRoutes := make([]string, 0)
+			for _, route := range profile.Routes {
+				actualRoutes = append(actualRoutes, route.Name)
+			}
+			if !reflect.DeepEqual(actualRoutes, expectedRoutes) {
+				t.Fatalf("Expected routes [%v], got [%v]", expectedRoutes, actualRoutes)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected routes [%v], got nothing", expectedRoutes)
+		}
+	}
+
+	t.Run("Sends the current profile, and then each change to it", func(t *testing.T) {
+		stream, errCh, cancel := subscribe(&serviceId{namespace: "ns", name: "web"})
+		expectRoutes(t, stream, []string{"index"})
+
+		fakeWatch.Modify(newServiceProfile("ns", "web",
+			k8s.RouteSpec{Name: "index", PathRegex: "/"},
+			k8s.RouteSpec{Name: "users", PathRegex: "/users"},
+		))
+		expectRoutes(t, stream, []string{"index", "users"})
+
+		fakeWatch.Delete(newServiceProfile("ns", "web"))
+		expectRoutes(t, stream, []string{})
+
+		cancel()
+		if err := <-errCh; err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Sends a profile without routes to services without a ServiceProfile", func(t *testing.T) {
+		stream, errCh, cancel := subscribe(&serviceId{namespace: "ns", name: "api"})
+		expectRoutes(t, stream, []string{})
+
+		fakeWatch.Add(newServiceProfile("ns", "api", k8s.RouteSpec{Name: "health", PathRegex: "/health"}))
+		expectRoutes(t, stream, []string{"health"})
+
+		cancel()
+		if err := <-errCh; err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Ends the stream when the watcher is stopped", func(t *testing.T) {
+		stream, errCh, cancel := subscribe(&serviceId{namespace: "ns", name: "web"})
+		defer cancel()
+		expectRoutes(t, stream, []string{})
+
+		watcher.stop()
+		select {
+		case err := <-errCh:
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the stream to end, but it didn't")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package destination
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/runconduit/conduit/controller/k8s"
+)
+
+// RemoteCluster is another Kubernetes cluster whose services are resolved by
+// the Destination service under the cluster's own DNS zone, e.g.
+// "web.prod.svc.cluster-east" for the "web" service in the "prod" namespace of
+// the cluster with the "cluster-east" zone.
+type RemoteCluster struct {
+	Zone   string
+	K8sAPI *k8s.API
+}
+
+// LoadRemoteClusters returns a RemoteCluster for each kubeconfig file in `dir`,
+// which is typically a mounted Secret. The name of each file is the DNS zone
+// of its cluster. The informers of the clusters' APIs still need to be
+// started with SyncWithRetry.
+func LoadRemoteClusters(dir string) ([]RemoteCluster, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]RemoteCluster, 0)
+	for _, file := range files {
+		// Skip the hidden files and directories that Kubernetes uses to
+		// update mounted Secrets atomically.
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		zone := file.Name()
+		if _, err := splitDNSName(zone); err != nil {
+			return nil, fmt.Errorf("invalid zone for remote cluster [%s]: %s", zone, err)
+		}
+
+		k8sClient, err := k8s.NewClientSet(filepath.Join(dir, zone))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig of remote cluster [%s]: %s", zone, err)
+		}
+
+		clusters = append(clusters, RemoteCluster{
+			Zone: zone,
+			K8sAPI: k8s.NewAPI(
+				k8sClient,
+				k8s.CM,
+				k8s.Endpoint,
+				k8s.Pod,
+				k8s.RS,
+				k8s.Svc,
+			),
+		})
+	}
+	return clusters, nil
+}
@@ -0,0 +1,88 @@
+package destination
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const remoteKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: east
+  cluster:
+    server: https://east.example.com:6443
+contexts:
+- name: east
+  context:
+    cluster: east
+    user: east
+current-context: east
+users:
+- name: east
+  user:
+    token: secret
+`
+
+func TestLoadRemoteClusters(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir, err := ioutil.TempDir("", "remote-clusters")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for name, content := range files {
+			err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		return dir
+	}
+
+	t.Run("Loads a cluster for each kubeconfig, named after its zone", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"cluster-east": remoteKubeConfig,
+			"cluster-west": remoteKubeConfig,
+			".hidden-file": "not a kubeconfig",
+		})
+		defer os.RemoveAll(dir)
+
+		clusters, err := LoadRemoteClusters(dir)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(clusters) != 2 {
+			t.Fatalf("Expected [2] clusters, got [%d]: %v", len(clusters), clusters)
+		}
+		for i, expectedZone := range []string{"cluster-east", "cluster-west"} {
+			if clusters[i].Zone != expectedZone {
+				t.Fatalf("Expected cluster [%d] to have zone [%s], got [%s]", i, expectedZone, clusters[i].Zone)
+			}
+			if clusters[i].K8sAPI == nil {
+				t.Fatalf("Expected cluster [%s] to have an API", clusters[i].Zone)
+			}
+		}
+	})
+
+	t.Run("Returns an error if a file isn't named after a valid zone", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"cluster_east-": remoteKubeConfig})
+		defer os.RemoveAll(dir)
+
+		_, err := LoadRemoteClusters(dir)
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+
+	t.Run("Returns an error if a file isn't a kubeconfig", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{"cluster-east": "clusters: ["})
+		defer os.RemoveAll(dir)
+
+		_, err := LoadRemoteClusters(dir)
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+}
@@ -3,8 +3,10 @@ package destination
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	common "github.com/runconduit/conduit/controller/gen/common"
 	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
@@ -19,9 +21,12 @@ import (
 const podIpIndexName = "ip"
 
 type server struct {
-	k8sAPI    *k8s.API
-	resolvers []streamingDestinationResolver
-	enableTLS bool
+	k8sAPI           *k8s.API
+	resolvers        []streamingDestinationResolver
+	profileWatcher   *profileWatcher
+	enableTLS        bool
+	slowStartWindow  time.Duration
+	zoneAwareWeights bool
 }
 
 // The Destination service serves service discovery information to the proxy.
@@ -29,27 +34,65 @@ type server struct {
 // destination paths to be of the form:
 // <service>.<namespace>.svc.cluster.local:<port>
 //
-// If the port is omitted, 80 is used as a default.  If the namespace is
-// omitted, "default" is used as a default.append
+// If the port is omitted, 80 is used as a default. Names that aren't fully
+// qualified are looked up in the same way that Kubernetes DNS looks them up
+// for the requesting pod, so that "web" is the "web" service in the pod's
+// namespace, as given by `caller_namespace`; if the request doesn't set it,
+// "default" is used.
 //
 // Addresses for the given destination are fetched from the Kubernetes Endpoints
-// API.
-func NewServer(addr, k8sDNSZone string, enableTLS bool, k8sAPI *k8s.API, done chan struct{}) (*grpc.Server, net.Listener, error) {
+// API. Destinations that aren't Kubernetes services are resolved by polling
+// DNS.
+//
+// The weights of newly ready pods are ramped up over `slowStartWindow`; if it
+// is zero, all ready pods get the same weight. If `zoneAwareWeights` is set,
+// endpoints outside of the zone of the requesting pod get a fraction of their
+// weight, provided the request gives the pod's node in `caller_node`.
+//
+// The services of each of `remoteClusters` are resolved in the same way as
+// local services, with paths of the form
+// <service>.<namespace>.svc.<zone>:<port>
+// where <zone> is the DNS zone of the remote cluster.
+//
+// The routes of each local service are taken from the ServiceProfile with the
+// same name and namespace, as found by `profileInformer`, and served by
+// GetProfile.
+//
+// The returned http.Handler dumps the state of all subscriptions to Kubernetes
+// services as JSON; it is meant to be served at DebugEndpointsPath on the admin
+// server.
+func NewServer(addr, k8sDNSZone string, enableTLS bool, slowStartWindow time.Duration, zoneAwareWeights bool, k8sAPI *k8s.API, remoteClusters []RemoteCluster, profileInformer cache.SharedIndexInformer, done chan struct{}) (*grpc.Server, net.Listener, http.Handler, error) {
 	k8sAPI.Pod().Informer().AddIndexers(cache.Indexers{podIpIndexName: indexPodByIp})
-	resolvers, err := buildResolversList(k8sDNSZone, k8sAPI)
+	for _, cluster := range remoteClusters {
+		cluster.K8sAPI.Pod().Informer().AddIndexers(cache.Indexers{podIpIndexName: indexPodByIp})
+	}
+
+	resolvers, err := buildResolversList(k8sDNSZone, k8sAPI, remoteClusters)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	profileWatcher := newProfileWatcher(profileInformer)
+
+	debug := &debugHandler{}
+	for _, resolver := range resolvers {
+		if k8sResolver, ok := resolver.(*k8sResolver); ok && !k8sResolver.remote {
+			debug.endpointsWatcher = k8sResolver.endpointsWatcher
+		}
 	}
 
 	srv := server{
-		k8sAPI:    k8sAPI,
-		resolvers: resolvers,
-		enableTLS: enableTLS,
+		k8sAPI:           k8sAPI,
+		resolvers:        resolvers,
+		profileWatcher:   profileWatcher,
+		enableTLS:        enableTLS,
+		slowStartWindow:  slowStartWindow,
+		zoneAwareWeights: zoneAwareWeights,
 	}
 
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	s := prometheus.NewGrpcServer()
@@ -60,37 +103,83 @@ func NewServer(addr, k8sDNSZone string, enableTLS bool, k8sAPI *k8s.API, done ch
 		for _, resolver := range resolvers {
 			resolver.stop()
 		}
+		profileWatcher.stop()
 	}()
 
-	return s, lis, nil
+	return s, lis, debug, nil
 }
 
 func (s *server) Get(dest *common.Destination, stream pb.Destination_GetServer) error {
 	log.Debugf("Get %v", dest)
-	if dest.Scheme != "k8s" {
-		err := fmt.Errorf("Unsupported scheme %v", dest.Scheme)
+	host, port, err := parseDestination(dest)
+	if err != nil {
 		log.Error(err)
 		return err
 	}
-	hostPort := strings.Split(dest.Path, ":")
-	if len(hostPort) > 2 {
-		err := fmt.Errorf("Invalid destination %s", dest.Path)
+
+	host = s.applySearchPath(host, callerNamespace(dest))
+
+	callerZone := ""
+	if s.zoneAwareWeights {
+		callerZone = s.callerZone(dest)
+	}
+	return s.streamResolutionUsingCorrectResolverFor(host, port, callerZone, stream)
+}
+
+// GetProfile streams the routes of a local Kubernetes service, which are sent
+// again whenever its ServiceProfile changes. Destinations that aren't local
+// services get a single profile without routes.
+func (s *server) GetProfile(dest *common.Destination, stream pb.Destination_GetProfileServer) error {
+	log.Debugf("GetProfile %v", dest)
+	host, _, err := parseDestination(dest)
+	if err != nil {
 		log.Error(err)
 		return err
 	}
+
+	host = s.applySearchPath(host, callerNamespace(dest))
+
+	var id *serviceId
+	if resolver := s.localK8sResolver(); resolver != nil {
+		id, _, err = resolver.localKubernetesServiceIdFromDNSName(host)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	listener := newProfileListener(stream)
+	if id == nil {
+		listener.Update(&pb.DestinationProfile{})
+		return listener.sendUpdates()
+	}
+
+	s.profileWatcher.subscribe(id, listener)
+	defer s.profileWatcher.unsubscribe(id, listener)
+
+	return listener.sendUpdates()
+}
+
+// parseDestination returns the host and port of a "k8s" destination. If the
+// port is omitted, 80 is used as a default.
+func parseDestination(dest *common.Destination) (string, int, error) {
+	if dest.Scheme != "k8s" {
+		return "", 0, fmt.Errorf("Unsupported scheme %v", dest.Scheme)
+	}
+	hostPort := strings.Split(dest.Path, ":")
+	if len(hostPort) > 2 {
+		return "", 0, fmt.Errorf("Invalid destination %s", dest.Path)
+	}
 	host := hostPort[0]
 	port := 80
 	if len(hostPort) == 2 {
 		var err error
 		port, err = strconv.Atoi(hostPort[1])
 		if err != nil {
-			err = fmt.Errorf("Invalid port %s", hostPort[1])
-			log.Error(err)
-			return err
+			return "", 0, fmt.Errorf("Invalid port %s", hostPort[1])
 		}
 	}
-
-	return s.streamResolutionUsingCorrectResolverFor(host, port, stream)
+	return host, port, nil
 }
 
 func indexPodByIp(obj interface{}) ([]string, error) {
@@ -100,8 +189,17 @@ func indexPodByIp(obj interface{}) ([]string, error) {
 	return []string{""}, fmt.Errorf("object is not a pod")
 }
 
-func (s *server) podsByIp(ip string) ([]*v1.Pod, error) {
-	objs, err := s.k8sAPI.Pod().Informer().GetIndexer().ByIndex(podIpIndexName, ip)
+// podsByIpIn returns a function that looks up the pods with a given IP in the
+// cluster of `k8sAPI`. Pod IPs are only unique within a cluster, so endpoints
+// are only looked up in the cluster they were resolved from.
+func podsByIpIn(k8sAPI *k8s.API) podsByIpFn {
+	return func(ip string) ([]*v1.Pod, error) {
+		return podsByIp(k8sAPI, ip)
+	}
+}
+
+func podsByIp(k8sAPI *k8s.API, ip string) ([]*v1.Pod, error) {
+	objs, err := k8sAPI.Pod().Informer().GetIndexer().ByIndex(podIpIndexName, ip)
 	if err != nil {
 		return nil, err
 	}
@@ -116,22 +214,96 @@ func (s *server) podsByIp(ip string) ([]*v1.Pod, error) {
 	return pods, nil
 }
 
-func (s *server) streamResolutionUsingCorrectResolverFor(host string, port int, stream pb.Destination_GetServer) error {
-	listener := newEndpointListener(stream, s.podsByIp, s.k8sAPI.GetOwnerKindAndName, s.enableTLS)
+// nodeZone returns the failure domain zone of the node with the given name, or
+// an empty string if it isn't known.
+func (s *server) nodeZone(nodeName string) string {
+	node, err := s.k8sAPI.Node().Lister().Get(nodeName)
+	if err != nil {
+		log.Debugf("Error getting node %s: %s", nodeName, err)
+		return ""
+	}
+	return node.Labels[v1.LabelZoneFailureDomain]
+}
+
+// callerZone returns the zone of the node that the pod that made the request
+// runs on, if the request gives the node. Otherwise it returns an empty
+// string.
+func (s *server) callerZone(dest *common.Destination) string {
+	if dest.GetCallerNode() == "" {
+		return ""
+	}
+	return s.nodeZone(dest.GetCallerNode())
+}
+
+// callerNamespace returns the namespace of the pod that made the request, as
+// given by the request, or "default" if the request doesn't give one.
+func callerNamespace(dest *common.Destination) string {
+	if namespace := dest.GetCallerNamespace(); namespace != "" {
+		return namespace
+	}
+	return v1.NamespaceDefault
+}
+
+// applySearchPath returns the name that `host` refers to when it is looked up
+// from `namespace`, if the local Kubernetes resolver knows it by a longer
+// name. Otherwise it returns `host` unchanged.
+func (s *server) applySearchPath(host, namespace string) string {
+	if resolver := s.localK8sResolver(); resolver != nil {
+		return resolver.applySearchPath(host, namespace)
+	}
+	return host
+}
+
+// localK8sResolver returns the resolver for the services of the local cluster,
+// or nil if there isn't one.
+func (s *server) localK8sResolver() *k8sResolver {
+	for _, resolver := range s.resolvers {
+		if k8sResolver, ok := resolver.(*k8sResolver); ok && !k8sResolver.remote {
+			return k8sResolver
+		}
+	}
+	return nil
+}
 
+func (s *server) streamResolutionUsingCorrectResolverFor(host string, port int, callerZone string, stream pb.Destination_GetServer) error {
 	for _, resolver := range s.resolvers {
 		resolverCanResolve, err := resolver.canResolve(host, port)
 		if err != nil {
 			return fmt.Errorf("resolver [%+v] found error resolving host [%s] port[%d]: %v", resolver, host, port, err)
 		}
 		if resolverCanResolve {
-			return resolver.streamResolution(host, port, listener)
+			// Nodes are only looked up for zone-aware weights.
+			var nodeZone nodeZoneFn
+			if s.zoneAwareWeights {
+				nodeZone = s.nodeZone
+			}
+			k8sAPI := s.clusterOf(resolver)
+			listener := newEndpointListener(stream, podsByIpIn(k8sAPI), k8sAPI.GetOwnerKindAndName, nodeZone, s.enableTLS, s.slowStartWindow, callerZone)
+
+			go listener.sendUpdates()
+			defer listener.Stop()
+
+			err := resolver.streamResolution(host, port, listener)
+			if listener.Dropped() {
+				return fmt.Errorf("dropped subscription to host [%s] port [%d]: updates could not be sent", host, port)
+			}
+			return err
 		}
 	}
 	return fmt.Errorf("cannot find resolver for host [%s] port [%d]", host, port)
 }
 
-func buildResolversList(k8sDNSZone string, k8sAPI *k8s.API) ([]streamingDestinationResolver, error) {
+// clusterOf returns the API of the cluster whose pods back the endpoints that
+// `resolver` resolves: the remote cluster for the resolver of a remote
+// cluster's services, and the local cluster otherwise.
+func (s *server) clusterOf(resolver streamingDestinationResolver) *k8s.API {
+	if k8sResolver, ok := resolver.(*k8sResolver); ok && k8sResolver.remote {
+		return k8sResolver.k8sAPI
+	}
+	return s.k8sAPI
+}
+
+func buildResolversList(k8sDNSZone string, k8sAPI *k8s.API, remoteClusters []RemoteCluster) ([]streamingDestinationResolver, error) {
 	var k8sDNSZoneLabels []string
 	if k8sDNSZone == "" {
 		k8sDNSZoneLabels = []string{}
@@ -143,9 +315,26 @@ func buildResolversList(k8sDNSZone string, k8sAPI *k8s.API) ([]streamingDestinat
 		}
 	}
 
-	k8sResolver := newK8sResolver(k8sDNSZoneLabels, k8sAPI)
+	hostResolver := newNetHostResolver(defaultDNSRefreshInterval)
+	k8sResolver := newK8sResolver(k8sDNSZoneLabels, k8sAPI, hostResolver)
 
 	log.Infof("Adding k8s name resolver")
 
-	return []streamingDestinationResolver{k8sResolver}, nil
+	resolvers := []streamingDestinationResolver{k8sResolver}
+
+	for _, cluster := range remoteClusters {
+		zoneLabels, err := splitDNSName(cluster.Zone)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, newRemoteK8sResolver(zoneLabels, cluster.K8sAPI, hostResolver))
+
+		log.Infof("Adding k8s name resolver for remote zone %s", cluster.Zone)
+	}
+
+	dnsResolver := newDNSResolver(hostResolver)
+
+	log.Infof("Adding DNS name resolver")
+
+	return append(resolvers, dnsResolver), nil
 }
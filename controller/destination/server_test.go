@@ -5,9 +5,11 @@ import (
 	"errors"
 	"testing"
 
+	common "github.com/runconduit/conduit/controller/gen/common"
 	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
 	"github.com/runconduit/conduit/controller/k8s"
 	"google.golang.org/grpc/metadata"
+	"k8s.io/client-go/tools/cache"
 )
 
 type mockDestination_GetServer struct {
@@ -37,27 +39,87 @@ func TestBuildResolversList(t *testing.T) {
 	t.Run("Doesn't build a list if Kubernetes DNS zone isnt valid", func(t *testing.T) {
 		invalidK8sDNSZones := []string{"1", "-a", "a-", "-"}
 		for _, dsnZone := range invalidK8sDNSZones {
-			resolvers, err := buildResolversList(dsnZone, k8sAPI)
+			resolvers, err := buildResolversList(dsnZone, k8sAPI, nil)
 			if err == nil {
 				t.Fatalf("Expecting error when k8s zone is [%s], got nothing. Resolvers: %v", dsnZone, resolvers)
 			}
 		}
 	})
 
-	t.Run("Builds list with echo IP first, then K8s resolver", func(t *testing.T) {
-		resolvers, err := buildResolversList("some.zone", k8sAPI)
+	t.Run("Builds list with K8s resolver first, then DNS resolver", func(t *testing.T) {
+		resolvers, err := buildResolversList("some.zone", k8sAPI, nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 
 		actualNumResolvers := len(resolvers)
-		expectedNumResolvers := 1
+		expectedNumResolvers := 2
 		if actualNumResolvers != expectedNumResolvers {
 			t.Fatalf("Expecting [%d] resolvers, got [%d]: %v", expectedNumResolvers, actualNumResolvers, resolvers)
 		}
 
 		if _, ok := resolvers[0].(*k8sResolver); !ok {
-			t.Fatalf("Expecting second resolver to be k8s, got [%+v]. List: %v", resolvers[0], resolvers)
+			t.Fatalf("Expecting first resolver to be k8s, got [%+v]. List: %v", resolvers[0], resolvers)
+		}
+
+		if _, ok := resolvers[1].(*dnsResolver); !ok {
+			t.Fatalf("Expecting second resolver to be DNS, got [%+v]. List: %v", resolvers[1], resolvers)
+		}
+	})
+
+	t.Run("Leaves external names to the DNS resolver", func(t *testing.T) {
+		resolvers, err := buildResolversList("some.zone", k8sAPI, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, name := range []string{"api.some-saas.io", "db.example.com"} {
+			if canResolve, _ := resolvers[0].canResolve(name, 443); canResolve {
+				t.Fatalf("Expecting k8s resolver NOT to resolve name [%s], but it did", name)
+			}
+			if canResolve, _ := resolvers[1].canResolve(name, 443); !canResolve {
+				t.Fatalf("Expecting DNS resolver to resolve name [%s], but it didn't", name)
+			}
+		}
+	})
+
+	t.Run("Adds a K8s resolver for each remote cluster before the DNS resolver", func(t *testing.T) {
+		remoteK8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		remoteClusters := []RemoteCluster{{Zone: "cluster-east", K8sAPI: remoteK8sAPI}}
+
+		resolvers, err := buildResolversList("some.zone", k8sAPI, remoteClusters)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		actualNumResolvers := len(resolvers)
+		expectedNumResolvers := 3
+		if actualNumResolvers != expectedNumResolvers {
+			t.Fatalf("Expecting [%d] resolvers, got [%d]: %v", expectedNumResolvers, actualNumResolvers, resolvers)
+		}
+
+		if resolver, ok := resolvers[0].(*k8sResolver); !ok || resolver.remote {
+			t.Fatalf("Expecting first resolver to be local k8s, got [%+v]. List: %v", resolvers[0], resolvers)
+		}
+
+		if resolver, ok := resolvers[1].(*k8sResolver); !ok || !resolver.remote {
+			t.Fatalf("Expecting second resolver to be remote k8s, got [%+v]. List: %v", resolvers[1], resolvers)
+		}
+
+		if _, ok := resolvers[2].(*dnsResolver); !ok {
+			t.Fatalf("Expecting third resolver to be DNS, got [%+v]. List: %v", resolvers[2], resolvers)
+		}
+	})
+
+	t.Run("Doesn't build a list if the zone of a remote cluster isn't valid", func(t *testing.T) {
+		remoteClusters := []RemoteCluster{{Zone: "-", K8sAPI: k8sAPI}}
+
+		resolvers, err := buildResolversList("some.zone", k8sAPI, remoteClusters)
+		if err == nil {
+			t.Fatalf("Expecting error, got nothing. Resolvers: %v", resolvers)
 		}
 	})
 }
@@ -86,7 +148,7 @@ func (m *mockStreamingDestinationResolver) streamResolution(host string, port in
 func (m *mockStreamingDestinationResolver) stop() {}
 
 func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
-	stream := &mockDestination_GetServer{}
+	stream := &mockDestination_GetServer{contextToReturn: context.Background()}
 	host := "something"
 	port := 666
 	k8sAPI, err := k8s.NewFakeAPI()
@@ -104,7 +166,7 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 			resolvers: []streamingDestinationResolver{no, no, yes, no, no, otherYes},
 		}
 
-		err := server.streamResolutionUsingCorrectResolverFor(host, port, stream)
+		err := server.streamResolutionUsingCorrectResolverFor(host, port, "", stream)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -130,7 +192,7 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 			resolvers: []streamingDestinationResolver{no, no, no, no},
 		}
 
-		err := server.streamResolutionUsingCorrectResolverFor(host, port, stream)
+		err := server.streamResolutionUsingCorrectResolverFor(host, port, "", stream)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing")
 		}
@@ -144,7 +206,7 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 			resolvers: []streamingDestinationResolver{resolver},
 		}
 
-		err := server.streamResolutionUsingCorrectResolverFor(host, port, stream)
+		err := server.streamResolutionUsingCorrectResolverFor(host, port, "", stream)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing")
 		}
@@ -158,9 +220,105 @@ func TestStreamResolutionUsingCorrectResolverFor(t *testing.T) {
 			resolvers: []streamingDestinationResolver{resolver},
 		}
 
-		err := server.streamResolutionUsingCorrectResolverFor(host, port, stream)
+		err := server.streamResolutionUsingCorrectResolverFor(host, port, "", stream)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing")
 		}
 	})
 }
+
+func TestCallerNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		dest              *common.Destination
+		expectedNamespace string
+	}{
+		{"uses the namespace in the request", &common.Destination{Scheme: "k8s", Path: "web:80", CallerNamespace: "staging"}, "staging"},
+		{"uses the default namespace if the request doesn't give one", &common.Destination{Scheme: "k8s", Path: "web:80"}, "default"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actualNamespace := callerNamespace(tt.dest)
+			if actualNamespace != tt.expectedNamespace {
+				t.Fatalf("Expected namespace [%s], got [%s]", tt.expectedNamespace, actualNamespace)
+			}
+		})
+	}
+}
+
+func TestCallerZone(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-1
+  labels:
+    failure-domain.beta.kubernetes.io/zone: us-east-1a`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	k8sAPI.Sync(nil)
+
+	server := server{k8sAPI: k8sAPI}
+
+	for _, tt := range []struct {
+		name         string
+		dest         *common.Destination
+		expectedZone string
+	}{
+		{"uses the zone of the node in the request", &common.Destination{Scheme: "k8s", Path: "web:80", CallerNode: "node-1"}, "us-east-1a"},
+		{"returns no zone if the node isn't known", &common.Destination{Scheme: "k8s", Path: "web:80", CallerNode: "node-2"}, ""},
+		{"returns no zone if the request doesn't give a node", &common.Destination{Scheme: "k8s", Path: "web:80"}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actualZone := server.callerZone(tt.dest)
+			if actualZone != tt.expectedZone {
+				t.Fatalf("Expected zone [%s], got [%s]", tt.expectedZone, actualZone)
+			}
+		})
+	}
+}
+
+func TestClusterOf(t *testing.T) {
+	pod := func(name string) string {
+		return `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: ` + name + `
+  namespace: ns
+status:
+  podIP: 10.1.0.1`
+	}
+
+	k8sAPI, err := k8s.NewFakeAPI(pod("local-pod"))
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	remoteK8sAPI, err := k8s.NewFakeAPI(pod("remote-pod"))
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	remoteClusters := []RemoteCluster{{Zone: "cluster-east", K8sAPI: remoteK8sAPI}}
+
+	for _, api := range []*k8s.API{k8sAPI, remoteK8sAPI} {
+		api.Pod().Informer().AddIndexers(cache.Indexers{podIpIndexName: indexPodByIp})
+		api.Sync(nil)
+	}
+
+	resolvers, err := buildResolversList("cluster.local", k8sAPI, remoteClusters)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server := server{k8sAPI: k8sAPI, resolvers: resolvers}
+
+	for i, expectedPod := range []string{"local-pod", "remote-pod", "local-pod"} {
+		pods, err := podsByIpIn(server.clusterOf(resolvers[i]))("10.1.0.1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(pods) != 1 || pods[0].Name != expectedPod {
+			t.Fatalf("Expected resolver [%d] to find pod [%s], got %v", i, expectedPod, pods)
+		}
+	}
+}
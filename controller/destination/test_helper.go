@@ -2,6 +2,9 @@ package destination
 
 import (
 	"context"
+	"net"
+	"sync"
+	"time"
 
 	common "github.com/runconduit/conduit/controller/gen/common"
 )
@@ -12,6 +15,7 @@ type collectUpdateListener struct {
 	removed           []common.TcpAddress
 	noEndpointsCalled bool
 	noEndpointsExists bool
+	weightFactors     map[string]float64
 	context           context.Context
 	stopCh            chan struct{}
 }
@@ -21,6 +25,16 @@ func (c *collectUpdateListener) Update(add []common.TcpAddress, remove []common.
 	c.removed = append(c.removed, remove...)
 }
 
+func (c *collectUpdateListener) UpdateWithWeightFactors(add []common.TcpAddress, remove []common.TcpAddress, weightFactors map[string]float64) {
+	c.Update(add, remove)
+	if c.weightFactors == nil {
+		c.weightFactors = make(map[string]float64)
+	}
+	for k, v := range weightFactors {
+		c.weightFactors[k] = v
+	}
+}
+
 func (c *collectUpdateListener) ClientClose() <-chan struct{} {
 	return c.context.Done()
 }
@@ -44,3 +58,46 @@ func newCollectUpdateListener() (*collectUpdateListener, context.CancelFunc) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	return &collectUpdateListener{context: ctx}, cancelFn
 }
+
+// implements the hostResolver interface
+type mockHostResolver struct {
+	answers map[string][]string
+	ttl     time.Duration
+	err     error
+	mutex   sync.Mutex
+}
+
+func (m *mockHostResolver) lookupHost(host string) ([]net.IP, time.Duration, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	answer, ok := m.answers[host]
+	if !ok {
+		return nil, m.ttl, errNoSuchHost
+	}
+	ips := make([]net.IP, 0)
+	for _, ip := range answer {
+		ips = append(ips, net.ParseIP(ip))
+	}
+	return ips, m.ttl, nil
+}
+
+func (m *mockHostResolver) setAnswer(host string, ips ...string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.answers[host] = ips
+}
+
+// newMockHostResolver returns a resolver whose answers never expire during a
+// test, so that lookups only happen when a test triggers them. Hosts without
+// an answer don't exist.
+func newMockHostResolver(answers map[string][]string) *mockHostResolver {
+	return &mockHostResolver{
+		answers: answers,
+		ttl:     time.Hour,
+	}
+}
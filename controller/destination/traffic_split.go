@@ -0,0 +1,424 @@
+package destination
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	"github.com/runconduit/conduit/controller/k8s"
+	"github.com/runconduit/conduit/pkg/addr"
+	pkgK8s "github.com/runconduit/conduit/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// trafficSplitWatcher watches ConfigMaps that split the traffic of an apex
+// service between backing services. Such a ConfigMap is annotated with
+// pkgK8s.TrafficSplitAnnotation set to the name of the apex service, and maps
+// the names of the backing services in the same namespace to their weights:
+//
+//	kind: ConfigMap
+//	apiVersion: v1
+//	metadata:
+//	  name: web-split
+//	  namespace: ns
+//	  annotations:
+//	    conduit.io/traffic-split: web
+//	data:
+//	  web-v1: "90"
+//	  web-v2: "10"
+//
+// Subscribers to the apex service get the union of the addresses of the
+// backing services, weighted so that each backing service receives its share
+// of the traffic. Services without a split are backed by themselves.
+type trafficSplitWatcher struct {
+	configMapLister  corelisters.ConfigMapLister
+	endpointsWatcher *endpointsWatcher
+	// a map of apex service -> subscriptions to it
+	subscriptions map[serviceId][]*splitSubscription
+	// This mutex protects the subscriptions map and serializes changes to the
+	// backing services of subscriptions.
+	mutex sync.Mutex
+}
+
+func newTrafficSplitWatcher(k8sAPI *k8s.API, endpointsWatcher *endpointsWatcher) *trafficSplitWatcher {
+	watcher := &trafficSplitWatcher{
+		configMapLister:  k8sAPI.CM().Lister(),
+		endpointsWatcher: endpointsWatcher,
+		subscriptions:    make(map[serviceId][]*splitSubscription),
+	}
+
+	k8sAPI.CM().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    watcher.addConfigMap,
+			UpdateFunc: watcher.updateConfigMap,
+			DeleteFunc: watcher.deleteConfigMap,
+		},
+	)
+
+	return watcher
+}
+
+// Subscribe to an apex service and service port. The provided listener will be
+// updated each time the address set of any of the backing services changes,
+// or when the split itself changes.
+func (t *trafficSplitWatcher) subscribe(apex *serviceId, port uint32, listener updateListener) *splitSubscription {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sub := newSplitSubscription(port, listener, t.endpointsWatcher)
+	t.subscriptions[*apex] = append(t.subscriptions[*apex], sub)
+	sub.setBackends(t.getSplit(apex))
+	return sub
+}
+
+func (t *trafficSplitWatcher) unsubscribe(apex *serviceId, sub *splitSubscription) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	subs := t.subscriptions[*apex]
+	for i, item := range subs {
+		if item == sub {
+			subs[i] = subs[len(subs)-1]
+			subs[len(subs)-1] = nil
+			subs = subs[:len(subs)-1]
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(t.subscriptions, *apex)
+	} else {
+		t.subscriptions[*apex] = subs
+	}
+
+	sub.close()
+}
+
+// getSplit returns the weights of the services backing `apex`.
+func (t *trafficSplitWatcher) getSplit(apex *serviceId) map[serviceId]uint32 {
+	configMaps, err := t.configMapLister.ConfigMaps(apex.namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("Error listing config maps in %s: %s", apex.namespace, err)
+		configMaps = nil
+	}
+
+	// If several ConfigMaps split the same service, use the first by name.
+	sort.Slice(configMaps, func(i, j int) bool { return configMaps[i].Name < configMaps[j].Name })
+	for _, configMap := range configMaps {
+		if configMap.Annotations[pkgK8s.TrafficSplitAnnotation] != apex.name {
+			continue
+		}
+
+		split := make(map[serviceId]uint32)
+		for name, value := range configMap.Data {
+			weight, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				log.Errorf("Invalid weight %q for %s in config map %s/%s", value, name, configMap.Namespace, configMap.Name)
+				continue
+			}
+			split[serviceId{namespace: apex.namespace, name: name}] = uint32(weight)
+		}
+		return split
+	}
+
+	return map[serviceId]uint32{*apex: 1}
+}
+
+func (t *trafficSplitWatcher) addConfigMap(obj interface{}) {
+	configMap := obj.(*v1.ConfigMap)
+	t.updateSplit(configMap)
+}
+
+func (t *trafficSplitWatcher) updateConfigMap(oldObj, newObj interface{}) {
+	oldConfigMap := oldObj.(*v1.ConfigMap)
+	newConfigMap := newObj.(*v1.ConfigMap)
+	t.updateSplit(oldConfigMap)
+	if oldConfigMap.Annotations[pkgK8s.TrafficSplitAnnotation] != newConfigMap.Annotations[pkgK8s.TrafficSplitAnnotation] {
+		t.updateSplit(newConfigMap)
+	}
+}
+
+func (t *trafficSplitWatcher) deleteConfigMap(obj interface{}) {
+	configMap := obj.(*v1.ConfigMap)
+	t.updateSplit(configMap)
+}
+
+// updateSplit recomputes the split of the apex service named in configMap's
+// annotation, if any, and updates all subscriptions to that service.
+func (t *trafficSplitWatcher) updateSplit(configMap *v1.ConfigMap) {
+	if configMap.Namespace == kubeSystem {
+		return
+	}
+	apexName, ok := configMap.Annotations[pkgK8s.TrafficSplitAnnotation]
+	if !ok {
+		return
+	}
+	apex := serviceId{
+		namespace: configMap.Namespace,
+		name:      apexName,
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	subs, ok := t.subscriptions[apex]
+	if !ok {
+		return
+	}
+	split := t.getSplit(&apex)
+	log.Debugf("Updating traffic split of %s to %v", apex, split)
+	for _, sub := range subs {
+		sub.setBackends(split)
+	}
+}
+
+/// splitSubscription ///
+
+// splitSubscription is a single subscription to an apex service. It subscribes
+// to each backing service and publishes the union of their addresses to the
+// subscriber, with weight factors that give each backing service its share of
+// the traffic regardless of how many endpoints it has.
+type splitSubscription struct {
+	port             uint32
+	listener         updateListener
+	endpointsWatcher *endpointsWatcher
+	// these values hold the current state of the subscription and are mutable
+	weights   map[serviceId]uint32
+	backends  map[serviceId]*splitBackendListener
+	addresses map[serviceId]map[string]common.TcpAddress
+	exists    map[serviceId]bool
+	// the addresses last published to the listener, keyed by address
+	published map[string]splitAddress
+	// set while the backing services are being changed, so that the listener
+	// is only updated once all of them have been subscribed to
+	changingBackends bool
+	stopOnce         sync.Once
+	// This mutex protects the subscription's state against concurrent updates
+	// from its backing services.
+	mutex sync.Mutex
+}
+
+func newSplitSubscription(port uint32, listener updateListener, endpointsWatcher *endpointsWatcher) *splitSubscription {
+	return &splitSubscription{
+		port:             port,
+		listener:         listener,
+		endpointsWatcher: endpointsWatcher,
+		weights:          make(map[serviceId]uint32),
+		backends:         make(map[serviceId]*splitBackendListener),
+		addresses:        make(map[serviceId]map[string]common.TcpAddress),
+		exists:           make(map[serviceId]bool),
+		published:        make(map[string]splitAddress),
+	}
+}
+
+// setBackends changes the backing services and their weights, and publishes
+// the resulting address set. It must not be called with s.mutex held, since
+// subscribing to a backing service publishes its addresses synchronously.
+func (s *splitSubscription) setBackends(weights map[serviceId]uint32) {
+	s.mutex.Lock()
+	s.changingBackends = true
+	s.mutex.Unlock()
+
+	s.changeBackends(weights)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.changingBackends = false
+	s.publish()
+}
+
+// close unsubscribes from all backing services without notifying the
+// listener.
+func (s *splitSubscription) close() {
+	s.mutex.Lock()
+	s.changingBackends = true
+	s.mutex.Unlock()
+
+	s.changeBackends(map[serviceId]uint32{})
+}
+
+// changeBackends subscribes to new backing services and unsubscribes from
+// removed ones.
+func (s *splitSubscription) changeBackends(weights map[serviceId]uint32) {
+	s.mutex.Lock()
+	added := make(map[serviceId]*splitBackendListener)
+	removed := make(map[serviceId]*splitBackendListener)
+	for id, backend := range s.backends {
+		if _, ok := weights[id]; !ok {
+			removed[id] = backend
+			delete(s.backends, id)
+			delete(s.addresses, id)
+			delete(s.exists, id)
+		}
+	}
+	for id := range weights {
+		if _, ok := s.backends[id]; !ok {
+			backend := &splitBackendListener{id: id, sub: s}
+			added[id] = backend
+			s.backends[id] = backend
+			s.addresses[id] = make(map[string]common.TcpAddress)
+			s.exists[id] = true
+		}
+	}
+	s.weights = weights
+	s.mutex.Unlock()
+
+	for id, backend := range removed {
+		backendId := id
+		err := s.endpointsWatcher.unsubscribe(&backendId, s.port, "", backend)
+		if err != nil {
+			log.Errorf("Error unsubscribing from %s: %s", id, err)
+		}
+	}
+	for id, backend := range added {
+		backendId := id
+		err := s.endpointsWatcher.subscribe(&backendId, s.port, "", backend)
+		if err != nil {
+			log.Errorf("Error subscribing to %s: %s", id, err)
+		}
+	}
+}
+
+func (s *splitSubscription) updateBackend(id serviceId, add []common.TcpAddress, remove []common.TcpAddress) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	addresses, ok := s.addresses[id]
+	if !ok {
+		// the backing service was removed from the split
+		return
+	}
+	for i := range remove {
+		delete(addresses, addr.AddressToString(&remove[i]))
+	}
+	for i := range add {
+		addresses[addr.AddressToString(&add[i])] = add[i]
+	}
+	s.exists[id] = true
+	if !s.changingBackends {
+		s.publish()
+	}
+}
+
+func (s *splitSubscription) noBackendEndpoints(id serviceId, exists bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.addresses[id]; !ok {
+		return
+	}
+	s.addresses[id] = make(map[string]common.TcpAddress)
+	s.exists[id] = exists
+	if !s.changingBackends {
+		s.publish()
+	}
+}
+
+// publish sends the changes between the current union of the backing
+// services' addresses and the last published one. Addresses whose weight
+// factor changed are sent again. The caller must hold s.mutex.
+func (s *splitSubscription) publish() {
+	totalWeight := uint32(0)
+	totalAddresses := 0
+	for id, weight := range s.weights {
+		if weight > 0 && len(s.addresses[id]) > 0 {
+			totalWeight += weight
+			totalAddresses += len(s.addresses[id])
+		}
+	}
+
+	current := make(map[string]splitAddress)
+	factors := make(map[string]float64)
+	add := make([]common.TcpAddress, 0)
+	for id, weight := range s.weights {
+		addresses := s.addresses[id]
+		if weight == 0 || len(addresses) == 0 {
+			continue
+		}
+
+		// Each backing service gets its share of the traffic, spread evenly
+		// over its endpoints. The factors average to 1, so that a service
+		// without a split keeps its usual weights.
+		share := float64(weight) / float64(totalWeight)
+		factor := share * float64(totalAddresses) / float64(len(addresses))
+		for key, address := range addresses {
+			current[key] = splitAddress{address: address, factor: factor}
+			factors[key] = factor
+			if previous, ok := s.published[key]; !ok || previous.factor != factor {
+				add = append(add, address)
+			}
+		}
+	}
+
+	remove := make([]common.TcpAddress, 0)
+	for key, previous := range s.published {
+		if _, ok := current[key]; !ok {
+			remove = append(remove, previous.address)
+		}
+	}
+	s.published = current
+
+	if len(current) == 0 {
+		exists := false
+		for _, e := range s.exists {
+			exists = exists || e
+		}
+		s.listener.NoEndpoints(exists)
+		return
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return
+	}
+
+	if weighted, ok := s.listener.(weightedUpdateListener); ok {
+		weighted.UpdateWithWeightFactors(add, remove, factors)
+	} else {
+		s.listener.Update(add, remove)
+	}
+}
+
+func (s *splitSubscription) stop() {
+	s.stopOnce.Do(s.listener.Stop)
+}
+
+// splitAddress is an address published by a splitSubscription along with its
+// weight factor.
+type splitAddress struct {
+	address common.TcpAddress
+	factor  float64
+}
+
+// implements the updateListener interface
+//
+// splitBackendListener forwards updates for one backing service to the split
+// subscription it belongs to.
+type splitBackendListener struct {
+	id  serviceId
+	sub *splitSubscription
+}
+
+func (b *splitBackendListener) Update(add []common.TcpAddress, remove []common.TcpAddress) {
+	b.sub.updateBackend(b.id, add, remove)
+}
+
+func (b *splitBackendListener) ClientClose() <-chan struct{} {
+	return b.sub.listener.ClientClose()
+}
+
+func (b *splitBackendListener) ServerClose() <-chan struct{} {
+	return b.sub.listener.ServerClose()
+}
+
+func (b *splitBackendListener) NoEndpoints(exists bool) {
+	b.sub.noBackendEndpoints(b.id, exists)
+}
+
+func (b *splitBackendListener) SetServiceId(id *serviceId) {}
+
+func (b *splitBackendListener) Stop() {
+	b.sub.stop()
+}
@@ -0,0 +1,190 @@
+package destination
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/runconduit/conduit/controller/k8s"
+)
+
+var trafficSplitServices = []string{`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-v1
+  namespace: ns
+spec:
+  type: ClusterIP
+  ports:
+  - port: 8080`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web-v1
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 10.0.1.1
+  - ip: 10.0.1.2
+  - ip: 10.0.1.3
+  ports:
+  - port: 8080`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-v2
+  namespace: ns
+spec:
+  type: ClusterIP
+  ports:
+  - port: 8080`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web-v2
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 10.0.2.1
+  ports:
+  - port: 8080`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: ns
+spec:
+  type: ClusterIP
+  ports:
+  - port: 8080`, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: web
+  namespace: ns
+subsets:
+- addresses:
+  - ip: 10.0.0.1
+  ports:
+  - port: 8080`,
+}
+
+const webSplit = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-split
+  namespace: ns
+  annotations:
+    conduit.io/traffic-split: web
+data:
+  web-v1: "90"
+  web-v2: "10"`
+
+func newTestTrafficSplitWatcher(t *testing.T, configs ...string) (*trafficSplitWatcher, *k8s.API) {
+	k8sAPI, err := k8s.NewFakeAPI(configs...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+
+	endpointsWatcher := newEndpointsWatcher(k8sAPI, newMockHostResolver(map[string][]string{}))
+	watcher := newTrafficSplitWatcher(k8sAPI, endpointsWatcher)
+
+	k8sAPI.Sync(nil)
+
+	return watcher, k8sAPI
+}
+
+func TestTrafficSplitWatcher(t *testing.T) {
+	apex := &serviceId{namespace: "ns", name: "web"}
+
+	t.Run("Publishes the addresses of services without a split unweighted", func(t *testing.T) {
+		watcher, _ := newTestTrafficSplitWatcher(t, trafficSplitServices...)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watcher.subscribe(apex, 8080, listener)
+
+		expectedAdded := []string{"10.0.0.1:8080"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		expectedFactors := map[string]float64{"10.0.0.1:8080": 1}
+		if !reflect.DeepEqual(listener.weightFactors, expectedFactors) {
+			t.Fatalf("Expected weight factors %v, got %v", expectedFactors, listener.weightFactors)
+		}
+	})
+
+	t.Run("Publishes the union of the backing services weighted by the split", func(t *testing.T) {
+		watcher, _ := newTestTrafficSplitWatcher(t, append(trafficSplitServices, webSplit)...)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watcher.subscribe(apex, 8080, listener)
+
+		expectedAdded := []string{"10.0.1.1:8080", "10.0.1.2:8080", "10.0.1.3:8080", "10.0.2.1:8080"}
+		if actual := addressStrings(listener.added); !reflect.DeepEqual(actual, expectedAdded) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdded, actual)
+		}
+
+		// web-v1 gets 90% of the traffic over 3 endpoints and web-v2 gets 10%
+		// over 1 endpoint, so each web-v1 endpoint gets 3 times the weight of
+		// the web-v2 endpoint.
+		v1Factor := listener.weightFactors["10.0.1.1:8080"]
+		v2Factor := listener.weightFactors["10.0.2.1:8080"]
+		if v1Factor/v2Factor < 2.999 || v1Factor/v2Factor > 3.001 {
+			t.Fatalf("Expected web-v1 endpoints to get 3 times the weight of web-v2 endpoints, got factors %v", listener.weightFactors)
+		}
+	})
+
+	t.Run("Updates subscribers when the split changes", func(t *testing.T) {
+		watcher, k8sAPI := newTestTrafficSplitWatcher(t, append(trafficSplitServices, webSplit)...)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		watcher.subscribe(apex, 8080, listener)
+
+		configMap, err := watcher.configMapLister.ConfigMaps("ns").Get("web-split")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		updated := configMap.DeepCopy()
+		updated.Data = map[string]string{"web-v2": "100"}
+		err = k8sAPI.CM().Informer().GetIndexer().Update(updated)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		watcher.updateConfigMap(configMap, updated)
+
+		expectedRemoved := []string{"10.0.1.1:8080", "10.0.1.2:8080", "10.0.1.3:8080"}
+		if actual := addressStrings(listener.removed); !reflect.DeepEqual(actual, expectedRemoved) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemoved, actual)
+		}
+
+		if factor := listener.weightFactors["10.0.2.1:8080"]; factor != 1 {
+			t.Fatalf("Expected web-v2 endpoint to have weight factor 1, got %v", factor)
+		}
+	})
+
+	t.Run("Stops updating unsubscribed listeners", func(t *testing.T) {
+		watcher, _ := newTestTrafficSplitWatcher(t, append(trafficSplitServices, webSplit)...)
+
+		listener, cancelFn := newCollectUpdateListener()
+		defer cancelFn()
+
+		sub := watcher.subscribe(apex, 8080, listener)
+		watcher.unsubscribe(apex, sub)
+
+		if len(watcher.subscriptions) != 0 {
+			t.Fatalf("Expected no subscriptions, got %v", watcher.subscriptions)
+		}
+
+		if len(watcher.endpointsWatcher.servicePorts) != 0 {
+			t.Fatalf("Expected no watched service ports, got %v", watcher.endpointsWatcher.servicePorts)
+		}
+	})
+}
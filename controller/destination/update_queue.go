@@ -0,0 +1,154 @@
+package destination
+
+import (
+	common "github.com/runconduit/conduit/controller/gen/common"
+	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/pkg/addr"
+)
+
+// updateQueue holds the updates that are waiting to be sent to a listener.
+// Pending updates are coalesced, so that only the latest change to each
+// address is sent: an address that is added and then removed before the queue
+// is popped is only removed, and vice versa, and a NoEndpoints update discards
+// all of the addresses queued before it.
+//
+// The zero value is an empty queue. It isn't safe for concurrent use.
+type updateQueue struct {
+	noEndpoints *pb.NoEndpoints
+
+	// pending additions keyed by address, and the order in which they were
+	// queued; the order may contain addresses that are no longer pending
+	adds         map[string]*pb.WeightedAddr
+	addOrder     []string
+	metricLabels map[string]string
+
+	// pending removals keyed by address, and the order in which they were
+	// queued; the order may contain addresses that are no longer pending
+	removes     map[string]*common.TcpAddress
+	removeOrder []string
+}
+
+func (q *updateQueue) pushAdd(addrSet *pb.WeightedAddrSet) {
+	if q.adds == nil {
+		q.adds = make(map[string]*pb.WeightedAddr)
+	}
+	for _, weightedAddr := range addrSet.Addrs {
+		key := addr.AddressToString(weightedAddr.Addr)
+		delete(q.removes, key)
+		if _, ok := q.adds[key]; !ok {
+			q.addOrder = append(q.addOrder, key)
+		}
+		q.adds[key] = weightedAddr
+	}
+	q.addOrder = compactOrder(q.addOrder, len(q.adds), func(key string) bool {
+		_, ok := q.adds[key]
+		return ok
+	})
+	q.metricLabels = addrSet.MetricLabels
+}
+
+func (q *updateQueue) pushRemove(addrs []common.TcpAddress) {
+	if q.removes == nil {
+		q.removes = make(map[string]*common.TcpAddress)
+	}
+	for i := range addrs {
+		key := addr.AddressToString(&addrs[i])
+		delete(q.adds, key)
+		if _, ok := q.removes[key]; !ok {
+			q.removeOrder = append(q.removeOrder, key)
+		}
+		q.removes[key] = &addrs[i]
+	}
+	q.removeOrder = compactOrder(q.removeOrder, len(q.removes), func(key string) bool {
+		_, ok := q.removes[key]
+		return ok
+	})
+}
+
+// compactOrder drops the addresses that are no longer pending from `order`
+// once they make up most of it, so that an address that keeps flapping
+// doesn't grow the queue without bound. The first position of each pending
+// address is kept, as that's where pop finds it.
+func compactOrder(order []string, pending int, isPending func(string) bool) []string {
+	if len(order) <= 2*pending+1 {
+		return order
+	}
+	compacted := make([]string, 0, pending)
+	seen := make(map[string]bool)
+	for _, key := range order {
+		if isPending(key) && !seen[key] {
+			seen[key] = true
+			compacted = append(compacted, key)
+		}
+	}
+	return compacted
+}
+
+func (q *updateQueue) pushNoEndpoints(exists bool) {
+	q.noEndpoints = &pb.NoEndpoints{Exists: exists}
+	q.adds = nil
+	q.addOrder = nil
+	q.removes = nil
+	q.removeOrder = nil
+}
+
+// len returns the number of coalesced updates in the queue: one per pending
+// address, plus one for a pending NoEndpoints update.
+func (q *updateQueue) len() int {
+	n := len(q.adds) + len(q.removes)
+	if q.noEndpoints != nil {
+		n++
+	}
+	return n
+}
+
+// pop empties the queue and returns the coalesced updates: a NoEndpoints
+// update, if one is pending, followed by the pending additions and then the
+// pending removals.
+func (q *updateQueue) pop() []*pb.Update {
+	updates := make([]*pb.Update, 0)
+
+	if q.noEndpoints != nil {
+		updates = append(updates, &pb.Update{
+			Update: &pb.Update_NoEndpoints{
+				NoEndpoints: q.noEndpoints,
+			},
+		})
+	}
+
+	addrs := make([]*pb.WeightedAddr, 0)
+	for _, key := range q.addOrder {
+		if weightedAddr, ok := q.adds[key]; ok {
+			addrs = append(addrs, weightedAddr)
+			delete(q.adds, key)
+		}
+	}
+	if len(addrs) > 0 {
+		updates = append(updates, &pb.Update{
+			Update: &pb.Update_Add{
+				Add: &pb.WeightedAddrSet{
+					Addrs:        addrs,
+					MetricLabels: q.metricLabels,
+				},
+			},
+		})
+	}
+
+	removed := make([]*common.TcpAddress, 0)
+	for _, key := range q.removeOrder {
+		if address, ok := q.removes[key]; ok {
+			removed = append(removed, address)
+			delete(q.removes, key)
+		}
+	}
+	if len(removed) > 0 {
+		updates = append(updates, &pb.Update{
+			Update: &pb.Update_Remove{
+				Remove: &pb.AddrSet{Addrs: removed},
+			},
+		})
+	}
+
+	*q = updateQueue{}
+	return updates
+}
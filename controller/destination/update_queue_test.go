@@ -0,0 +1,139 @@
+package destination
+
+import (
+	"reflect"
+	"testing"
+
+	common "github.com/runconduit/conduit/controller/gen/common"
+	pb "github.com/runconduit/conduit/controller/gen/proxy/destination"
+	"github.com/runconduit/conduit/pkg/addr"
+)
+
+func TestUpdateQueue(t *testing.T) {
+	address1 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 1}}, Port: 1}
+	address2 := common.TcpAddress{Ip: &common.IPAddress{Ip: &common.IPAddress_Ipv4{Ipv4: 2}}, Port: 2}
+
+	add := func(q *updateQueue, addrs ...common.TcpAddress) {
+		weightedAddrs := make([]*pb.WeightedAddr, 0)
+		for i := range addrs {
+			weightedAddrs = append(weightedAddrs, &pb.WeightedAddr{Addr: &addrs[i], Weight: fullWeight})
+		}
+		q.pushAdd(&pb.WeightedAddrSet{Addrs: weightedAddrs})
+	}
+	remove := func(q *updateQueue, addrs ...common.TcpAddress) {
+		q.pushRemove(addrs)
+	}
+
+	for _, tt := range []struct {
+		name            string
+		push            func(q *updateQueue)
+		expectedUpdates []string
+	}{
+		{
+			name:            "an empty queue has no updates",
+			push:            func(q *updateQueue) {},
+			expectedUpdates: []string{},
+		},
+		{
+			name: "adds and removes of different addresses are batched",
+			push: func(q *updateQueue) {
+				add(q, address1)
+				remove(q, address2)
+				add(q, address2)
+				remove(q, address1)
+				add(q, address1)
+			},
+			expectedUpdates: []string{"add 0.0.0.1:1 0.0.0.2:2"},
+		},
+		{
+			name: "an address that is added and then removed is only removed",
+			push: func(q *updateQueue) {
+				add(q, address1, address2)
+				remove(q, address1)
+			},
+			expectedUpdates: []string{"add 0.0.0.2:2", "remove 0.0.0.1:1"},
+		},
+		{
+			name: "an address that is added twice is added once",
+			push: func(q *updateQueue) {
+				add(q, address1)
+				add(q, address1)
+			},
+			expectedUpdates: []string{"add 0.0.0.1:1"},
+		},
+		{
+			name: "no endpoints discards the addresses queued before it",
+			push: func(q *updateQueue) {
+				add(q, address1)
+				remove(q, address2)
+				q.pushNoEndpoints(true)
+				add(q, address2)
+			},
+			expectedUpdates: []string{"no_endpoints true", "add 0.0.0.2:2"},
+		},
+		{
+			name: "an address that keeps flapping keeps its first position",
+			push: func(q *updateQueue) {
+				add(q, address2)
+				for i := 0; i < 100; i++ {
+					add(q, address1)
+					remove(q, address1)
+				}
+				add(q, address1)
+			},
+			expectedUpdates: []string{"add 0.0.0.2:2 0.0.0.1:1"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &updateQueue{}
+			tt.push(q)
+
+			actualUpdates := updateStrings(q.pop())
+			if !reflect.DeepEqual(actualUpdates, tt.expectedUpdates) {
+				t.Fatalf("Expected updates %v, got %v", tt.expectedUpdates, actualUpdates)
+			}
+
+			if q.len() != 0 || len(q.pop()) != 0 {
+				t.Fatalf("Expected the queue to be empty after it was popped")
+			}
+		})
+	}
+	t.Run("doesn't grow while an address flaps", func(t *testing.T) {
+		q := &updateQueue{}
+		for i := 0; i < 1000; i++ {
+			add(q, address1)
+			remove(q, address1)
+		}
+
+		if len(q.addOrder) > 3 || len(q.removeOrder) > 3 {
+			t.Fatalf("Expected the queue to be compacted, got [%d] additions and [%d] removals", len(q.addOrder), len(q.removeOrder))
+		}
+	})
+}
+
+func updateStrings(updates []*pb.Update) []string {
+	lines := make([]string, 0)
+	for _, update := range updates {
+		switch u := update.GetUpdate().(type) {
+		case *pb.Update_Add:
+			s := "add"
+			for _, weightedAddr := range u.Add.Addrs {
+				s += " " + addr.AddressToString(weightedAddr.Addr)
+			}
+			lines = append(lines, s)
+		case *pb.Update_Remove:
+			s := "remove"
+			for _, address := range u.Remove.Addrs {
+				s += " " + addr.AddressToString(address)
+			}
+			lines = append(lines, s)
+		case *pb.Update_NoEndpoints:
+			if u.NoEndpoints.Exists {
+				lines = append(lines, "no_endpoints true")
+			} else {
+				lines = append(lines, "no_endpoints false")
+			}
+		}
+	}
+	return lines
+}
@@ -143,6 +143,36 @@ func (x TapEvent_ProxyDirection) String() string {
 }
 func (TapEvent_ProxyDirection) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 0} }
 
+type TapEvent_TapStatus_State int32
+
+const (
+	TapEvent_TapStatus_UNKNOWN TapEvent_TapStatus_State = 0
+	// Events are being observed from the proxy.
+	TapEvent_TapStatus_CONNECTED TapEvent_TapStatus_State = 1
+	// The tap failed, and will be retried after a backoff.
+	TapEvent_TapStatus_RETRYING TapEvent_TapStatus_State = 2
+	// The proxy refused the tap, which won't be retried.
+	TapEvent_TapStatus_FAILED TapEvent_TapStatus_State = 3
+)
+
+var TapEvent_TapStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CONNECTED",
+	2: "RETRYING",
+	3: "FAILED",
+}
+var TapEvent_TapStatus_State_value = map[string]int32{
+	"UNKNOWN":   0,
+	"CONNECTED": 1,
+	"RETRYING":  2,
+	"FAILED":    3,
+}
+
+func (x TapEvent_TapStatus_State) String() string {
+	return proto.EnumName(TapEvent_TapStatus_State_name, int32(x))
+}
+func (TapEvent_TapStatus_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 2, 0} }
+
 type HttpMethod struct {
 	// Types that are valid to be assigned to Type:
 	//	*HttpMethod_Registered_
@@ -528,6 +558,14 @@ func (m *TcpAddress) GetPort() uint32 {
 type Destination struct {
 	Scheme string `protobuf:"bytes,1,opt,name=scheme" json:"scheme,omitempty"`
 	Path   string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	// The namespace of the pod that is asking, which names that aren't fully
+	// qualified are relative to. If it is empty, the "default" namespace is
+	// used.
+	CallerNamespace string `protobuf:"bytes,3,opt,name=caller_namespace,json=callerNamespace" json:"caller_namespace,omitempty"`
+	// The name of the node that the pod that is asking runs on, if it is known.
+	// When zone-aware weights are enabled, endpoints outside of the node's zone
+	// are given a fraction of their weight.
+	CallerNode string `protobuf:"bytes,4,opt,name=caller_node,json=callerNode" json:"caller_node,omitempty"`
 }
 
 func (m *Destination) Reset()                    { *m = Destination{} }
@@ -549,6 +587,20 @@ func (m *Destination) GetPath() string {
 	return ""
 }
 
+func (m *Destination) GetCallerNamespace() string {
+	if m != nil {
+		return m.CallerNamespace
+	}
+	return ""
+}
+
+func (m *Destination) GetCallerNode() string {
+	if m != nil {
+		return m.CallerNode
+	}
+	return ""
+}
+
 type Eos struct {
 	// Types that are valid to be assigned to End:
 	//	*Eos_GrpcStatusCode
@@ -666,6 +718,7 @@ type TapEvent struct {
 	ProxyDirection  TapEvent_ProxyDirection `protobuf:"varint,6,opt,name=proxy_direction,json=proxyDirection,enum=conduit.common.TapEvent_ProxyDirection" json:"proxy_direction,omitempty"`
 	// Types that are valid to be assigned to Event:
 	//	*TapEvent_Http_
+	//	*TapEvent_TapStatus_
 	Event isTapEvent_Event `protobuf_oneof:"event"`
 }
 
@@ -679,8 +732,12 @@ type isTapEvent_Event interface{ isTapEvent_Event() }
 type TapEvent_Http_ struct {
 	Http *TapEvent_Http `protobuf:"bytes,3,opt,name=http,oneof"`
 }
+type TapEvent_TapStatus_ struct {
+	TapStatus *TapEvent_TapStatus `protobuf:"bytes,7,opt,name=tap_status,json=tapStatus,oneof"`
+}
 
-func (*TapEvent_Http_) isTapEvent_Event() {}
+func (*TapEvent_Http_) isTapEvent_Event()      {}
+func (*TapEvent_TapStatus_) isTapEvent_Event() {}
 
 func (m *TapEvent) GetEvent() isTapEvent_Event {
 	if m != nil {
@@ -731,10 +788,18 @@ func (m *TapEvent) GetHttp() *TapEvent_Http {
 	return nil
 }
 
+func (m *TapEvent) GetTapStatus() *TapEvent_TapStatus {
+	if x, ok := m.GetEvent().(*TapEvent_TapStatus_); ok {
+		return x.TapStatus
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapEvent_OneofMarshaler, _TapEvent_OneofUnmarshaler, _TapEvent_OneofSizer, []interface{}{
 		(*TapEvent_Http_)(nil),
+		(*TapEvent_TapStatus_)(nil),
 	}
 }
 
@@ -747,6 +812,11 @@ func _TapEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Http); err != nil {
 			return err
 		}
+	case *TapEvent_TapStatus_:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TapStatus); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TapEvent.Event has unexpected type %T", x)
@@ -765,6 +835,14 @@ func _TapEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffe
 		err := b.DecodeMessage(msg)
 		m.Event = &TapEvent_Http_{msg}
 		return true, err
+	case 7: // event.tap_status
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TapEvent_TapStatus)
+		err := b.DecodeMessage(msg)
+		m.Event = &TapEvent_TapStatus_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -779,6 +857,11 @@ func _TapEvent_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(3<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TapEvent_TapStatus_:
+		s := proto.Size(x.TapStatus)
+		n += proto.SizeVarint(7<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -984,6 +1067,9 @@ type TapEvent_Http_RequestInit struct {
 	Scheme    *Scheme                 `protobuf:"bytes,3,opt,name=scheme" json:"scheme,omitempty"`
 	Authority string                  `protobuf:"bytes,4,opt,name=authority" json:"authority,omitempty"`
 	Path      string                  `protobuf:"bytes,5,opt,name=path" json:"path,omitempty"`
+	// The request headers that the tap asked for, i.e. those named by its
+	// header matches.
+	Headers []*TapEvent_Http_Header `protobuf:"bytes,6,rep,name=headers" json:"headers,omitempty"`
 }
 
 func (m *TapEvent_Http_RequestInit) Reset()                    { *m = TapEvent_Http_RequestInit{} }
@@ -1026,6 +1112,13 @@ func (m *TapEvent_Http_RequestInit) GetPath() string {
 	return ""
 }
 
+func (m *TapEvent_Http_RequestInit) GetHeaders() []*TapEvent_Http_Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 type TapEvent_Http_ResponseInit struct {
 	Id               *TapEvent_Http_StreamId   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	SinceRequestInit *google_protobuf.Duration `protobuf:"bytes,2,opt,name=since_request_init,json=sinceRequestInit" json:"since_request_init,omitempty"`
@@ -1108,6 +1201,58 @@ func (m *TapEvent_Http_ResponseEnd) GetEos() *Eos {
 	return nil
 }
 
+type TapEvent_Http_Header struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *TapEvent_Http_Header) Reset()                    { *m = TapEvent_Http_Header{} }
+func (m *TapEvent_Http_Header) String() string            { return proto.CompactTextString(m) }
+func (*TapEvent_Http_Header) ProtoMessage()               {}
+func (*TapEvent_Http_Header) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 1, 4} }
+
+func (m *TapEvent_Http_Header) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TapEvent_Http_Header) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// The state of the tap of a proxy, reported by the tap server whenever it
+// changes rather than by the proxy. `destination` is the address of the
+// proxy, and `destination_meta` has the labels of its pod.
+type TapEvent_TapStatus struct {
+	State TapEvent_TapStatus_State `protobuf:"varint,1,opt,name=state,enum=conduit.common.TapEvent_TapStatus_State" json:"state,omitempty"`
+	// Why the tap is retrying or failed.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TapEvent_TapStatus) Reset()                    { *m = TapEvent_TapStatus{} }
+func (m *TapEvent_TapStatus) String() string            { return proto.CompactTextString(m) }
+func (*TapEvent_TapStatus) ProtoMessage()               {}
+func (*TapEvent_TapStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 2} }
+
+func (m *TapEvent_TapStatus) GetState() TapEvent_TapStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return TapEvent_TapStatus_UNKNOWN
+}
+
+func (m *TapEvent_TapStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*HttpMethod)(nil), "conduit.common.HttpMethod")
 	proto.RegisterType((*Scheme)(nil), "conduit.common.Scheme")
@@ -1123,81 +1268,94 @@ func init() {
 	proto.RegisterType((*TapEvent_Http_RequestInit)(nil), "conduit.common.TapEvent.Http.RequestInit")
 	proto.RegisterType((*TapEvent_Http_ResponseInit)(nil), "conduit.common.TapEvent.Http.ResponseInit")
 	proto.RegisterType((*TapEvent_Http_ResponseEnd)(nil), "conduit.common.TapEvent.Http.ResponseEnd")
+	proto.RegisterType((*TapEvent_Http_Header)(nil), "conduit.common.TapEvent.Http.Header")
+	proto.RegisterType((*TapEvent_TapStatus)(nil), "conduit.common.TapEvent.TapStatus")
 	proto.RegisterEnum("conduit.common.Protocol", Protocol_name, Protocol_value)
 	proto.RegisterEnum("conduit.common.HttpMethod_Registered", HttpMethod_Registered_name, HttpMethod_Registered_value)
 	proto.RegisterEnum("conduit.common.Scheme_Registered", Scheme_Registered_name, Scheme_Registered_value)
 	proto.RegisterEnum("conduit.common.TapEvent_ProxyDirection", TapEvent_ProxyDirection_name, TapEvent_ProxyDirection_value)
+	proto.RegisterEnum("conduit.common.TapEvent_TapStatus_State", TapEvent_TapStatus_State_name, TapEvent_TapStatus_State_value)
 }
 
 func init() { proto.RegisterFile("common.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1069 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x4f, 0x73, 0x22, 0x45,
-	0x14, 0x67, 0x60, 0xf8, 0xf7, 0x20, 0xec, 0xd8, 0x9b, 0xda, 0x8a, 0x94, 0xab, 0x59, 0xca, 0x68,
-	0x92, 0x03, 0x68, 0xa2, 0xa9, 0x5d, 0xcb, 0x4b, 0x80, 0x31, 0x50, 0xbb, 0x0b, 0x63, 0x33, 0x29,
-	0xab, 0xbc, 0x50, 0x03, 0xd3, 0x0b, 0x53, 0xc2, 0xf4, 0xd8, 0xd3, 0x93, 0x92, 0xef, 0xe1, 0xd9,
-	0xab, 0x57, 0xbf, 0x8f, 0x1f, 0xc2, 0x83, 0xe5, 0xdd, 0xea, 0x3f, 0xc0, 0x90, 0xdd, 0x64, 0x57,
-	0x3d, 0x78, 0xa2, 0xdf, 0xeb, 0xdf, 0xfb, 0xcd, 0xef, 0xbd, 0xee, 0xf7, 0x1a, 0xa8, 0x4e, 0xe9,
-	0x72, 0x49, 0xc3, 0x66, 0xc4, 0x28, 0xa7, 0xa8, 0x36, 0xa5, 0xa1, 0x9f, 0x04, 0xbc, 0xa9, 0xbc,
-	0xf5, 0x0f, 0x67, 0x94, 0xce, 0x16, 0xa4, 0x25, 0x77, 0x27, 0xc9, 0xab, 0x96, 0x9f, 0x30, 0x8f,
-	0x07, 0x6b, 0x7c, 0xe3, 0x4f, 0x03, 0xa0, 0xc7, 0x79, 0xf4, 0x92, 0xf0, 0x39, 0xf5, 0xd1, 0x15,
-	0x00, 0x23, 0xb3, 0x20, 0xe6, 0x84, 0x11, 0xff, 0xc0, 0x38, 0x34, 0x8e, 0x6b, 0x67, 0x47, 0xcd,
-	0x5d, 0xce, 0xe6, 0x16, 0xdf, 0xc4, 0x1b, 0x70, 0x2f, 0x83, 0x53, 0xa1, 0xe8, 0x63, 0xa8, 0x26,
-	0x61, 0x8a, 0x2a, 0x7b, 0x68, 0x1c, 0x97, 0x7b, 0x19, 0xbc, 0xe3, 0x6d, 0x84, 0x00, 0x5b, 0x06,
-	0x54, 0x84, 0xdc, 0x95, 0xed, 0x5a, 0x19, 0x54, 0x02, 0xd3, 0x19, 0x8e, 0x5c, 0xcb, 0x10, 0x2e,
-	0xe7, 0xda, 0xb5, 0xb2, 0x08, 0xa0, 0xd0, 0xb5, 0x5f, 0xd8, 0xae, 0x6d, 0xe5, 0x50, 0x19, 0xf2,
-	0xce, 0xa5, 0xdb, 0xe9, 0x59, 0x26, 0xaa, 0x40, 0x71, 0xe8, 0xb8, 0xfd, 0xe1, 0x60, 0x64, 0xe5,
-	0x85, 0xd1, 0x19, 0x0e, 0x06, 0x76, 0xc7, 0xb5, 0x0a, 0x82, 0xa3, 0x67, 0x5f, 0x76, 0xad, 0xa2,
-	0x80, 0xbb, 0xf8, 0xb2, 0x63, 0x5b, 0xa5, 0x76, 0x01, 0x4c, 0xbe, 0x8a, 0x48, 0xe3, 0x17, 0x03,
-	0x0a, 0xa3, 0xe9, 0x9c, 0x2c, 0x09, 0xea, 0xbc, 0x21, 0xe3, 0x27, 0xb7, 0x33, 0x56, 0xd8, 0xff,
-	0x9a, 0xed, 0x93, 0x9d, 0x6c, 0x85, 0x40, 0xd7, 0x75, 0xac, 0x8c, 0x10, 0x28, 0x56, 0x23, 0xcb,
-	0xd8, 0x08, 0x1c, 0x41, 0xb9, 0xef, 0x5c, 0xfa, 0x3e, 0x23, 0x71, 0x8c, 0xf6, 0xc1, 0x0c, 0xa2,
-	0x9b, 0x2f, 0xa4, 0xb8, 0x62, 0x2f, 0x83, 0xa5, 0x85, 0x4e, 0xa5, 0xf7, 0x42, 0x7e, 0xab, 0x72,
-	0xb6, 0x7f, 0x5b, 0x72, 0xdf, 0xb9, 0xb9, 0xd0, 0xd8, 0x8b, 0xb6, 0x09, 0xd9, 0x20, 0x6a, 0x7c,
-	0x06, 0xa6, 0xf0, 0xa2, 0x7d, 0xc8, 0xbf, 0x0a, 0x58, 0xcc, 0x25, 0x61, 0x01, 0x2b, 0x03, 0x21,
-	0x30, 0x17, 0x5e, 0xcc, 0x25, 0x5f, 0x01, 0xcb, 0x75, 0xe3, 0x39, 0x80, 0x3b, 0x8d, 0xd6, 0x3a,
-	0x4e, 0x04, 0x8b, 0x0c, 0xaa, 0x9c, 0xbd, 0xff, 0xfa, 0xf7, 0x34, 0x0c, 0x67, 0x83, 0x48, 0x90,
-	0x45, 0x94, 0x29, 0xb2, 0x3d, 0x2c, 0xd7, 0x8d, 0x67, 0x50, 0xe9, 0x92, 0x98, 0x07, 0xa1, 0xbc,
-	0x7f, 0xe8, 0x11, 0x14, 0x62, 0x59, 0x56, 0xc9, 0x58, 0xc6, 0xda, 0x92, 0xa1, 0x1e, 0x9f, 0xab,
-	0x1a, 0x62, 0xb9, 0x6e, 0xf8, 0x90, 0xb3, 0x69, 0x8c, 0x4e, 0xc1, 0x9a, 0xb1, 0x68, 0x3a, 0x8e,
-	0xb9, 0xc7, 0x93, 0x78, 0x3c, 0xa5, 0xbe, 0x0a, 0xde, 0xeb, 0x65, 0x70, 0x4d, 0xec, 0x8c, 0xe4,
-	0x46, 0x87, 0xfa, 0x44, 0x60, 0x19, 0x89, 0x09, 0x1f, 0x13, 0xc6, 0x28, 0x53, 0xd8, 0xec, 0x1a,
-	0x2b, 0x77, 0x6c, 0xb1, 0x21, 0xb0, 0xed, 0x3c, 0xe4, 0x48, 0xe8, 0x37, 0xfe, 0xa8, 0x42, 0xc9,
-	0xf5, 0x22, 0xfb, 0x86, 0x84, 0x1c, 0x9d, 0x41, 0x21, 0xa6, 0x09, 0x9b, 0x12, 0x9d, 0x70, 0xfd,
-	0x76, 0xc2, 0xdb, 0xc2, 0x60, 0x8d, 0x44, 0xdf, 0x40, 0x45, 0xad, 0xc6, 0x4b, 0xc2, 0xbd, 0x83,
-	0xbc, 0x0c, 0x7c, 0xad, 0x7d, 0xd6, 0x9f, 0x68, 0xda, 0xa1, 0x1f, 0xd1, 0x20, 0xe4, 0x2f, 0x09,
-	0xf7, 0x30, 0xa8, 0x48, 0xb1, 0x46, 0x5f, 0x43, 0xc5, 0xdf, 0x56, 0x4a, 0x9f, 0xf0, 0x7d, 0x02,
-	0xd2, 0x70, 0xe4, 0x80, 0x95, 0x32, 0x95, 0x14, 0xf3, 0x9f, 0x48, 0x79, 0x90, 0x0a, 0x97, 0x7a,
-	0x1c, 0x78, 0x10, 0x31, 0xfa, 0xd3, 0x6a, 0xec, 0x07, 0x8c, 0x4c, 0xa5, 0xa6, 0x82, 0x6c, 0x94,
-	0x4f, 0xef, 0x24, 0x74, 0x04, 0xbe, 0xbb, 0x86, 0xe3, 0x5a, 0xb4, 0x63, 0xa3, 0x73, 0x30, 0xe7,
-	0x9c, 0x47, 0x07, 0x39, 0xa9, 0xeb, 0xf1, 0x9d, 0x34, 0x62, 0xd4, 0x88, 0x5b, 0x2c, 0xc0, 0xf5,
-	0x9f, 0x0d, 0xa8, 0xa6, 0x85, 0xa2, 0x3e, 0x14, 0x16, 0xde, 0x84, 0x2c, 0xe2, 0x03, 0xe3, 0x30,
-	0x77, 0x5c, 0x39, 0xfb, 0xfc, 0x9d, 0xf2, 0x6b, 0xbe, 0x90, 0x31, 0x76, 0xc8, 0xd9, 0x0a, 0x6b,
-	0x82, 0xfa, 0x33, 0xa8, 0xa4, 0xdc, 0xc8, 0x82, 0xdc, 0x0f, 0x64, 0xa5, 0x6f, 0xa6, 0x58, 0x8a,
-	0xa6, 0xb9, 0xf1, 0x16, 0x09, 0xd1, 0xf7, 0x52, 0x19, 0x5f, 0x65, 0x9f, 0x1a, 0xf5, 0xbf, 0x8a,
-	0x60, 0x0a, 0x9d, 0x68, 0x00, 0x55, 0x46, 0x7e, 0x4c, 0x48, 0xcc, 0xc7, 0x41, 0x18, 0x70, 0x7d,
-	0x71, 0x4e, 0xee, 0x4d, 0xae, 0x89, 0x55, 0x44, 0x3f, 0x0c, 0x78, 0x2f, 0x83, 0x2b, 0x6c, 0x6b,
-	0xa2, 0x6f, 0x61, 0x8f, 0x91, 0x38, 0xa2, 0x61, 0x4c, 0x14, 0xa1, 0xba, 0x08, 0xa7, 0x6f, 0x23,
-	0x54, 0x21, 0x9a, 0xb1, 0xca, 0x52, 0xb6, 0x92, 0xa8, 0x29, 0x49, 0xe8, 0xeb, 0xfa, 0x9f, 0xbc,
-	0x1b, 0xa3, 0x1d, 0xfa, 0x4a, 0xe2, 0xc6, 0xac, 0x5f, 0x40, 0x69, 0xc4, 0x19, 0xf1, 0x96, 0x7d,
-	0x5f, 0x34, 0xee, 0xc4, 0x8b, 0x75, 0x47, 0x62, 0xb9, 0x96, 0x4d, 0x2e, 0xf7, 0xa5, 0x76, 0x13,
-	0x6b, 0xab, 0xfe, 0xbb, 0x01, 0x95, 0x54, 0xe6, 0xe8, 0x02, 0xb2, 0x81, 0xaf, 0x0b, 0xf6, 0xc9,
-	0xfd, 0x6a, 0xd6, 0xdf, 0xc3, 0xd9, 0xc0, 0x17, 0x5d, 0xba, 0x94, 0x2f, 0xd1, 0x5d, 0x4d, 0xb2,
-	0x7d, 0xab, 0xb0, 0x46, 0xa2, 0xe6, 0x66, 0xf0, 0xa8, 0xec, 0x1f, 0xbd, 0x79, 0xda, 0x6f, 0x06,
-	0xd2, 0x07, 0x50, 0xf6, 0x12, 0x3e, 0xa7, 0x2c, 0xe0, 0x2b, 0xd9, 0x48, 0x65, 0xbc, 0x75, 0x6c,
-	0xc6, 0x55, 0x7e, 0x3b, 0xae, 0xea, 0xbf, 0x19, 0x50, 0x4d, 0x1f, 0xc3, 0xbf, 0x4e, 0xef, 0x0a,
-	0x50, 0x1c, 0x84, 0x53, 0x32, 0xde, 0xb9, 0x57, 0x59, 0x3d, 0x81, 0xd5, 0xd3, 0xde, 0x5c, 0x3f,
-	0xed, 0xcd, 0xae, 0x7e, 0xda, 0xb1, 0x25, 0x83, 0xd2, 0xf5, 0xfd, 0x08, 0x2a, 0xa2, 0x85, 0xf4,
-	0xe4, 0x94, 0x89, 0xef, 0x61, 0x10, 0x2e, 0x35, 0x32, 0xeb, 0xbf, 0x66, 0xc5, 0x81, 0x6c, 0x0e,
-	0xf6, 0xff, 0x57, 0xdc, 0x87, 0x87, 0x6b, 0xa2, 0x74, 0x0b, 0xe4, 0xde, 0xc6, 0xf4, 0x9e, 0x66,
-	0x4a, 0x55, 0xff, 0x08, 0x6a, 0x1b, 0x92, 0xc9, 0x8a, 0x93, 0x58, 0x9e, 0xa2, 0x89, 0x37, 0xdd,
-	0xd5, 0x16, 0x4e, 0x74, 0x04, 0x39, 0x42, 0x63, 0x3d, 0xb5, 0x1f, 0xde, 0xce, 0xd9, 0xa6, 0x31,
-	0x16, 0xfb, 0xed, 0x22, 0xe4, 0x89, 0x48, 0xbe, 0xf1, 0x14, 0x6a, 0xbb, 0x53, 0x4e, 0xfc, 0x01,
-	0xb9, 0x1e, 0x3c, 0x1f, 0x0c, 0xbf, 0x1b, 0x58, 0x19, 0x61, 0xf4, 0x07, 0xed, 0xe1, 0xf5, 0xa0,
-	0x6b, 0x19, 0xa8, 0x0a, 0xa5, 0xe1, 0xb5, 0xab, 0xac, 0xec, 0x86, 0xe2, 0xf4, 0x31, 0x94, 0x1c,
-	0x91, 0xc1, 0x94, 0x2e, 0x52, 0xff, 0x07, 0x8a, 0x90, 0x73, 0x3b, 0x8e, 0x65, 0xb4, 0xbf, 0xfc,
-	0xfe, 0x7c, 0x16, 0xf0, 0x79, 0x32, 0x11, 0x22, 0x5a, 0x2c, 0x09, 0xb5, 0xa6, 0x56, 0xea, 0x97,
-	0x33, 0xba, 0x58, 0x10, 0xd6, 0x9a, 0x91, 0xb0, 0xa5, 0xa4, 0x4e, 0x0a, 0xb2, 0x2a, 0xe7, 0x7f,
-	0x07, 0x00, 0x00, 0xff, 0xff, 0xa0, 0x1b, 0x17, 0x55, 0x1a, 0x0a, 0x00, 0x00,
+	// 1224 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc5, 0x56, 0xcd, 0x72, 0xe3, 0x44,
+	0x10, 0x5e, 0xdb, 0xf2, 0x5f, 0xdb, 0x71, 0xc4, 0xec, 0xd6, 0x56, 0x70, 0xb1, 0xc0, 0xba, 0x76,
+	0x61, 0x93, 0x83, 0x0d, 0x09, 0xa4, 0x16, 0x0a, 0xa8, 0x8a, 0x6d, 0x6d, 0xe2, 0xda, 0x60, 0x0b,
+	0x59, 0x29, 0x0a, 0x2e, 0x2e, 0xd9, 0x9a, 0xb5, 0x55, 0xd8, 0x92, 0x90, 0xc6, 0x29, 0xfc, 0x00,
+	0xbc, 0x01, 0x37, 0xaa, 0x28, 0x6e, 0x5c, 0x79, 0x0f, 0x9e, 0x84, 0x33, 0x2f, 0x40, 0xcf, 0x8f,
+	0x6c, 0x39, 0xbb, 0x49, 0x16, 0x38, 0x70, 0xd2, 0x74, 0xcf, 0xd7, 0x9f, 0xba, 0x7b, 0xba, 0x7b,
+	0x06, 0xaa, 0x93, 0x60, 0xb1, 0x08, 0xfc, 0x66, 0x18, 0x05, 0x2c, 0x20, 0xb5, 0x49, 0xe0, 0xbb,
+	0x4b, 0x8f, 0x35, 0xa5, 0xb6, 0xfe, 0xf6, 0x34, 0x08, 0xa6, 0x73, 0xda, 0x12, 0xbb, 0xe3, 0xe5,
+	0x8b, 0x96, 0xbb, 0x8c, 0x1c, 0xe6, 0x25, 0xf8, 0xc6, 0x5f, 0x19, 0x80, 0x33, 0xc6, 0xc2, 0x2f,
+	0x29, 0x9b, 0x05, 0x2e, 0x39, 0x05, 0x88, 0xe8, 0xd4, 0x8b, 0x19, 0x8d, 0xa8, 0xbb, 0x97, 0x79,
+	0x37, 0xf3, 0xa4, 0x76, 0xf8, 0xb8, 0xb9, 0xcd, 0xd9, 0xdc, 0xe0, 0x9b, 0xd6, 0x1a, 0x7c, 0x76,
+	0xc7, 0x4a, 0x99, 0x92, 0x47, 0x50, 0x5d, 0xfa, 0x29, 0xaa, 0x2c, 0x52, 0x95, 0x11, 0xb3, 0xa5,
+	0x6d, 0xf8, 0x00, 0x1b, 0x06, 0x52, 0x84, 0xdc, 0xa9, 0x61, 0xeb, 0x77, 0x48, 0x09, 0x34, 0x73,
+	0x30, 0xb4, 0xf5, 0x0c, 0x57, 0x99, 0x17, 0xb6, 0x9e, 0x25, 0x00, 0x85, 0xae, 0x71, 0x6e, 0xd8,
+	0x86, 0x9e, 0x23, 0x65, 0xc8, 0x9b, 0x27, 0x76, 0xe7, 0x4c, 0xd7, 0x48, 0x05, 0x8a, 0x03, 0xd3,
+	0xee, 0x0d, 0xfa, 0x43, 0x3d, 0xcf, 0x85, 0xce, 0xa0, 0xdf, 0x37, 0x3a, 0xb6, 0x5e, 0xe0, 0x1c,
+	0x67, 0xc6, 0x49, 0x57, 0x2f, 0x72, 0xb8, 0x6d, 0x9d, 0x74, 0x0c, 0xbd, 0xd4, 0x2e, 0x80, 0xc6,
+	0x56, 0x21, 0x6d, 0xfc, 0x92, 0x81, 0xc2, 0x70, 0x32, 0xa3, 0x0b, 0x4a, 0x3a, 0xaf, 0x88, 0xf8,
+	0xe1, 0xd5, 0x88, 0x25, 0xf6, 0xbf, 0x46, 0xfb, 0x70, 0x2b, 0x5a, 0xee, 0xa0, 0x6d, 0x9b, 0x18,
+	0x2e, 0x3a, 0xc8, 0x57, 0x43, 0x3d, 0xb3, 0x76, 0x70, 0x08, 0xe5, 0x9e, 0x79, 0xe2, 0xba, 0x11,
+	0x8d, 0x63, 0x72, 0x0f, 0x34, 0x2f, 0xbc, 0xfc, 0x48, 0x38, 0x57, 0x44, 0x56, 0x21, 0x91, 0x03,
+	0xa1, 0x3d, 0x16, 0xff, 0xaa, 0x1c, 0xde, 0xbb, 0xea, 0x72, 0xcf, 0xbc, 0x3c, 0x56, 0xd8, 0xe3,
+	0xb6, 0x06, 0x59, 0x2f, 0x6c, 0x7c, 0x00, 0x1a, 0xd7, 0x22, 0x5f, 0xfe, 0x85, 0x17, 0xc5, 0x4c,
+	0x10, 0x16, 0x2c, 0x29, 0x10, 0x02, 0xda, 0xdc, 0x41, 0x65, 0x56, 0x28, 0xc5, 0xba, 0xf1, 0x1c,
+	0xc0, 0x9e, 0x84, 0x89, 0x1f, 0xfb, 0x9c, 0x45, 0x18, 0x55, 0x0e, 0xdf, 0x7c, 0xf9, 0x7f, 0x0a,
+	0x66, 0x21, 0x88, 0x93, 0x85, 0x41, 0x24, 0xc9, 0x76, 0x2c, 0xb1, 0x6e, 0xfc, 0x98, 0x81, 0x4a,
+	0x97, 0xc6, 0xcc, 0xf3, 0x45, 0x01, 0x92, 0xfb, 0x50, 0x88, 0x45, 0x5e, 0x05, 0x65, 0xd9, 0x52,
+	0x92, 0xb0, 0x75, 0xd8, 0x4c, 0x26, 0xd1, 0x12, 0x6b, 0xfc, 0xb5, 0x3e, 0x71, 0xe6, 0x73, 0x1a,
+	0x8d, 0x7c, 0x67, 0x41, 0xe3, 0xd0, 0x99, 0xd0, 0xbd, 0x9c, 0xd8, 0xdf, 0x95, 0xfa, 0x7e, 0xa2,
+	0x26, 0xef, 0x40, 0x25, 0x81, 0x06, 0x2e, 0xdd, 0xd3, 0x04, 0x0a, 0x14, 0x0a, 0x35, 0x0d, 0x17,
+	0x72, 0x46, 0x10, 0x63, 0xfe, 0xf4, 0x69, 0x14, 0x4e, 0x46, 0x31, 0x73, 0xd8, 0x32, 0x1e, 0x4d,
+	0x38, 0x98, 0x3b, 0xb2, 0x83, 0x59, 0xab, 0xf1, 0x9d, 0xa1, 0xd8, 0xe8, 0xa0, 0x9e, 0x63, 0x31,
+	0x34, 0xca, 0x46, 0x34, 0x8a, 0x82, 0x48, 0x62, 0xb3, 0x09, 0x56, 0xec, 0x18, 0x7c, 0x83, 0x63,
+	0xdb, 0x79, 0xc8, 0x51, 0xdf, 0x6d, 0xfc, 0xb1, 0x0b, 0x25, 0xdb, 0x09, 0x8d, 0x4b, 0xea, 0x33,
+	0x72, 0x88, 0xa1, 0x06, 0xcb, 0x68, 0x42, 0x55, 0xf6, 0xea, 0x57, 0xb3, 0xb7, 0xc9, 0xb2, 0xa5,
+	0x90, 0xe4, 0x19, 0x54, 0xe4, 0x6a, 0xb4, 0xa0, 0xcc, 0xd9, 0xcb, 0x0b, 0xc3, 0x97, 0x7a, 0x31,
+	0xf9, 0x45, 0xd3, 0xf0, 0xdd, 0x30, 0xf0, 0x7c, 0x86, 0x8d, 0xe9, 0x58, 0x20, 0x2d, 0xf9, 0x9a,
+	0x7c, 0x06, 0x15, 0x77, 0x93, 0x75, 0x55, 0x2e, 0x37, 0x39, 0x90, 0x86, 0x13, 0x13, 0xf4, 0x94,
+	0x28, 0x5d, 0xd1, 0xfe, 0x89, 0x2b, 0xbb, 0x29, 0x73, 0xe1, 0x8f, 0x09, 0xbb, 0x38, 0x7a, 0x7e,
+	0x58, 0x8d, 0x5c, 0x2f, 0xa2, 0x13, 0xe1, 0x53, 0x41, 0x74, 0xdd, 0xfb, 0xd7, 0x12, 0x9a, 0x1c,
+	0xdf, 0x4d, 0xe0, 0x56, 0x2d, 0xdc, 0x92, 0xc9, 0x11, 0x68, 0x33, 0x1c, 0x49, 0xa2, 0x20, 0x2a,
+	0x87, 0x0f, 0xae, 0xa5, 0xe1, 0x73, 0x8b, 0xb7, 0x04, 0x07, 0xf3, 0xbe, 0x67, 0x4e, 0xa8, 0x4e,
+	0x7f, 0xaf, 0x28, 0x4c, 0x1b, 0xd7, 0x9a, 0xe2, 0x42, 0x96, 0x03, 0xda, 0x97, 0x59, 0x22, 0xd4,
+	0x7f, 0xca, 0x40, 0x35, 0x1d, 0x2d, 0xe9, 0x41, 0x61, 0xee, 0x8c, 0xe9, 0x3c, 0xc6, 0x83, 0xce,
+	0x21, 0xe3, 0x87, 0xaf, 0x95, 0xa4, 0xe6, 0xb9, 0xb0, 0x31, 0x7c, 0x16, 0xad, 0x2c, 0x45, 0x50,
+	0xff, 0x04, 0x2a, 0x29, 0x35, 0xd1, 0x21, 0xf7, 0x1d, 0x5d, 0xa9, 0x56, 0xe1, 0x4b, 0xde, 0xc6,
+	0x97, 0xce, 0x7c, 0x49, 0x55, 0xa3, 0x48, 0xe1, 0xd3, 0xec, 0xd3, 0x4c, 0xfd, 0x4f, 0x3e, 0x5b,
+	0x78, 0x90, 0x7d, 0xa8, 0x46, 0xf4, 0xfb, 0x25, 0x9e, 0xc0, 0xc8, 0xf3, 0x3d, 0xa6, 0xaa, 0x6f,
+	0xff, 0xc6, 0x0c, 0xe1, 0x94, 0x13, 0x16, 0x3d, 0x34, 0xc0, 0x68, 0x2b, 0xd1, 0x46, 0x24, 0x5f,
+	0xc1, 0x0e, 0x96, 0x48, 0x18, 0xf8, 0x31, 0x95, 0x84, 0xb2, 0x9a, 0x0e, 0x6e, 0x23, 0x94, 0x26,
+	0x8a, 0xb1, 0x1a, 0xa5, 0x64, 0xe9, 0xa2, 0xa2, 0xc4, 0xbe, 0x51, 0x87, 0xb8, 0xff, 0x7a, 0x8c,
+	0x98, 0x44, 0xe9, 0xe2, 0x5a, 0xac, 0x1f, 0x43, 0x69, 0xc8, 0x22, 0xea, 0x2c, 0x7a, 0x2e, 0x9f,
+	0x24, 0x63, 0x27, 0x56, 0x6d, 0x6d, 0x89, 0xb5, 0x98, 0x3a, 0x62, 0x5f, 0xf8, 0xae, 0x59, 0x4a,
+	0xaa, 0xff, 0x9c, 0x85, 0x4a, 0x2a, 0x72, 0x72, 0x8c, 0xc3, 0xce, 0x55, 0x09, 0x7b, 0xef, 0x66,
+	0x6f, 0x92, 0xff, 0xe1, 0xe4, 0x73, 0x79, 0xab, 0x2f, 0xc4, 0xdd, 0x78, 0x5d, 0xa7, 0x6d, 0x6e,
+	0x4f, 0x4b, 0x21, 0x49, 0x73, 0x3d, 0x09, 0x65, 0xf4, 0xf7, 0x5f, 0x7d, 0xff, 0xac, 0x27, 0xe4,
+	0x5b, 0x50, 0x76, 0x96, 0x68, 0x19, 0x79, 0x6c, 0xa5, 0x06, 0xdc, 0x46, 0xb1, 0x9e, 0x9f, 0xf9,
+	0xd4, 0xfc, 0xfc, 0x02, 0x8a, 0x33, 0xea, 0xb8, 0x34, 0x8a, 0xb1, 0xd9, 0x78, 0x61, 0x3e, 0xba,
+	0x39, 0xa4, 0x33, 0x01, 0xb6, 0x12, 0xa3, 0xfa, 0xef, 0x58, 0xe8, 0xe9, 0x63, 0xfc, 0xd7, 0xe9,
+	0x39, 0x05, 0x12, 0x7b, 0x3e, 0x0e, 0xb5, 0xad, 0xba, 0xcc, 0xaa, 0x3b, 0x45, 0x3e, 0x56, 0x9a,
+	0xc9, 0x63, 0xa5, 0xd9, 0x55, 0x8f, 0x15, 0x4b, 0x17, 0x46, 0xe9, 0xf3, 0xc1, 0x31, 0xcf, 0xfb,
+	0x38, 0x69, 0xe0, 0x9c, 0x38, 0x62, 0xe0, 0x2a, 0xd5, 0x9b, 0xbf, 0x89, 0x03, 0x5d, 0x17, 0xc6,
+	0xff, 0xef, 0x71, 0x0f, 0xee, 0x26, 0x44, 0xe9, 0x16, 0xca, 0xdd, 0xc6, 0xf4, 0x86, 0x62, 0x4a,
+	0x65, 0xff, 0x31, 0xd4, 0xd6, 0x24, 0xe3, 0x15, 0xa3, 0xb1, 0xa8, 0x02, 0xcd, 0x5a, 0x77, 0x67,
+	0x9b, 0x2b, 0x11, 0x96, 0xa3, 0x41, 0xac, 0xae, 0x8e, 0xbb, 0x57, 0x63, 0xc6, 0x4b, 0xd0, 0xe2,
+	0xfb, 0x75, 0x2c, 0x59, 0x79, 0xde, 0xbc, 0x74, 0xf8, 0xfd, 0xaa, 0xa6, 0x8c, 0x58, 0xbf, 0x7a,
+	0xcc, 0xb4, 0x8b, 0x90, 0xa7, 0x3c, 0x61, 0xf5, 0x5f, 0x33, 0x50, 0x5e, 0x4f, 0x47, 0xac, 0xb3,
+	0x3c, 0x3f, 0x10, 0xaa, 0x1e, 0x52, 0x4f, 0x6e, 0x1f, 0xa8, 0x4d, 0xfe, 0xa1, 0x96, 0x34, 0xe3,
+	0x3f, 0x13, 0x57, 0x6c, 0xf2, 0x33, 0x21, 0x34, 0x3e, 0x87, 0xbc, 0x40, 0xf1, 0x17, 0xde, 0x45,
+	0xff, 0x79, 0x7f, 0xf0, 0x75, 0x1f, 0x9f, 0x4d, 0x3b, 0x50, 0x56, 0xcf, 0x3d, 0xa3, 0x8b, 0x4f,
+	0xc5, 0x2a, 0x94, 0x2c, 0xc3, 0xb6, 0xbe, 0xe9, 0xf5, 0x4f, 0xe5, 0x7b, 0xf1, 0xd9, 0x49, 0xef,
+	0x1c, 0x77, 0x72, 0x8d, 0xa7, 0x50, 0xdb, 0xbe, 0x41, 0xb6, 0x79, 0x50, 0xe8, 0xf5, 0xdb, 0x83,
+	0x8b, 0xbe, 0x62, 0x19, 0x5c, 0xd8, 0x52, 0xca, 0xae, 0xa3, 0x3c, 0x78, 0x00, 0x25, 0x93, 0x1f,
+	0xcc, 0x24, 0x98, 0xa7, 0x1e, 0x6e, 0xf8, 0x3a, 0xb5, 0x3b, 0x26, 0x3e, 0xdb, 0x3e, 0xfe, 0xf6,
+	0x68, 0xea, 0xb1, 0xd9, 0x72, 0xcc, 0xe3, 0x6c, 0x45, 0x4b, 0x5f, 0x85, 0xdd, 0x4a, 0x7d, 0x59,
+	0x14, 0xf0, 0x27, 0x48, 0x6b, 0x4a, 0xfd, 0x96, 0xcc, 0xc6, 0xb8, 0x20, 0x0e, 0xfb, 0xe8, 0x6f,
+	0x52, 0x24, 0x34, 0x8a, 0xc3, 0x0b, 0x00, 0x00,
 }
@@ -14,12 +14,15 @@ It has these top-level messages:
 	WeightedAddr
 	TlsIdentity
 	NoEndpoints
+	DestinationProfile
+	Route
 */
 package destination
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/duration"
 import conduit_common "github.com/runconduit/conduit/controller/gen/common"
 
 import (
@@ -401,6 +404,81 @@ func (m *NoEndpoints) GetExists() bool {
 	return false
 }
 
+type DestinationProfile struct {
+	// The routes of the destination. A request is handled according to the
+	// first route that it matches.
+	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *DestinationProfile) Reset()                    { *m = DestinationProfile{} }
+func (m *DestinationProfile) String() string            { return proto.CompactTextString(m) }
+func (*DestinationProfile) ProtoMessage()               {}
+func (*DestinationProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DestinationProfile) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type Route struct {
+	// The name of the route, e.g. for use in metrics.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The HTTP method of the requests that match the route, e.g. "GET". Requests
+	// with any method match if it is empty.
+	Method string `protobuf:"bytes,2,opt,name=method" json:"method,omitempty"`
+	// A regular expression, in RE2 syntax, that must match the entire path of
+	// the requests that match the route.
+	PathRegex string `protobuf:"bytes,3,opt,name=path_regex,json=pathRegex" json:"path_regex,omitempty"`
+	// How long to wait for the response to a request that matches the route.
+	// Requests don't time out if it is unset.
+	Timeout *google_protobuf.Duration `protobuf:"bytes,4,opt,name=timeout" json:"timeout,omitempty"`
+	// Whether requests that match the route may be retried, e.g. because they
+	// are idempotent.
+	IsRetryable bool `protobuf:"varint,5,opt,name=is_retryable,json=isRetryable" json:"is_retryable,omitempty"`
+}
+
+func (m *Route) Reset()                    { *m = Route{} }
+func (m *Route) String() string            { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()               {}
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Route) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Route) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *Route) GetPathRegex() string {
+	if m != nil {
+		return m.PathRegex
+	}
+	return ""
+}
+
+func (m *Route) GetTimeout() *google_protobuf.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *Route) GetIsRetryable() bool {
+	if m != nil {
+		return m.IsRetryable
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Update)(nil), "conduit.proxy.destination.Update")
 	proto.RegisterType((*AddrSet)(nil), "conduit.proxy.destination.AddrSet")
@@ -409,6 +487,8 @@ func init() {
 	proto.RegisterType((*TlsIdentity)(nil), "conduit.proxy.destination.TlsIdentity")
 	proto.RegisterType((*TlsIdentity_K8SPodIdentity)(nil), "conduit.proxy.destination.TlsIdentity.K8sPodIdentity")
 	proto.RegisterType((*NoEndpoints)(nil), "conduit.proxy.destination.NoEndpoints")
+	proto.RegisterType((*DestinationProfile)(nil), "conduit.proxy.destination.DestinationProfile")
+	proto.RegisterType((*Route)(nil), "conduit.proxy.destination.Route")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -425,6 +505,10 @@ type DestinationClient interface {
 	// Given a destination, return all addresses in that destination as a long-
 	// running stream of updates.
 	Get(ctx context.Context, in *conduit_common.Destination, opts ...grpc.CallOption) (Destination_GetClient, error)
+	// Given a destination, return the profile that describes its routes as a
+	// long-running stream. A new profile is sent every time it changes. A
+	// profile without routes is sent if the destination has no profile.
+	GetProfile(ctx context.Context, in *conduit_common.Destination, opts ...grpc.CallOption) (Destination_GetProfileClient, error)
 }
 
 type destinationClient struct {
@@ -467,12 +551,48 @@ func (x *destinationGetClient) Recv() (*Update, error) {
 	return m, nil
 }
 
+func (c *destinationClient) GetProfile(ctx context.Context, in *conduit_common.Destination, opts ...grpc.CallOption) (Destination_GetProfileClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Destination_serviceDesc.Streams[1], c.cc, "/conduit.proxy.destination.Destination/GetProfile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &destinationGetProfileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Destination_GetProfileClient interface {
+	Recv() (*DestinationProfile, error)
+	grpc.ClientStream
+}
+
+type destinationGetProfileClient struct {
+	grpc.ClientStream
+}
+
+func (x *destinationGetProfileClient) Recv() (*DestinationProfile, error) {
+	m := new(DestinationProfile)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Destination service
 
 type DestinationServer interface {
 	// Given a destination, return all addresses in that destination as a long-
 	// running stream of updates.
 	Get(*conduit_common.Destination, Destination_GetServer) error
+	// Given a destination, return the profile that describes its routes as a
+	// long-running stream. A new profile is sent every time it changes. A
+	// profile without routes is sent if the destination has no profile.
+	GetProfile(*conduit_common.Destination, Destination_GetProfileServer) error
 }
 
 func RegisterDestinationServer(s *grpc.Server, srv DestinationServer) {
@@ -500,6 +620,27 @@ func (x *destinationGetServer) Send(m *Update) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Destination_GetProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(conduit_common.Destination)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DestinationServer).GetProfile(m, &destinationGetProfileServer{stream})
+}
+
+type Destination_GetProfileServer interface {
+	Send(*DestinationProfile) error
+	grpc.ServerStream
+}
+
+type destinationGetProfileServer struct {
+	grpc.ServerStream
+}
+
+func (x *destinationGetProfileServer) Send(m *DestinationProfile) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Destination_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "conduit.proxy.destination.Destination",
 	HandlerType: (*DestinationServer)(nil),
@@ -510,6 +651,11 @@ var _Destination_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Destination_Get_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetProfile",
+			Handler:       _Destination_GetProfile_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proxy/destination.proto",
 }
@@ -517,40 +663,49 @@ var _Destination_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("proxy/destination.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 553 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xd1, 0x6e, 0xd3, 0x3c,
-	0x18, 0x6d, 0xda, 0xb5, 0x7f, 0xf7, 0x25, 0xdd, 0x3f, 0x2c, 0x04, 0xa1, 0xdc, 0x6c, 0x41, 0xc0,
-	0xc4, 0x45, 0x3a, 0x15, 0x21, 0x15, 0x18, 0x03, 0x2a, 0x26, 0x5a, 0x0d, 0x26, 0x14, 0x86, 0x98,
-	0xb8, 0xa0, 0x4a, 0x6b, 0xab, 0xb3, 0x9a, 0xda, 0x91, 0xed, 0x8e, 0xe5, 0xf5, 0x78, 0x08, 0xee,
-	0x78, 0x0b, 0x1e, 0x00, 0xc5, 0x71, 0x49, 0xda, 0x89, 0xae, 0x12, 0x57, 0xc9, 0x67, 0x9d, 0x73,
-	0xfc, 0x9d, 0xf3, 0xd9, 0x86, 0xdb, 0xb1, 0xe0, 0x97, 0x49, 0x0b, 0x13, 0xa9, 0x28, 0x0b, 0x15,
-	0xe5, 0xcc, 0x8f, 0x05, 0x57, 0x1c, 0xdd, 0x19, 0x71, 0x86, 0x67, 0x54, 0xf9, 0x1a, 0xe0, 0x17,
-	0x00, 0x4d, 0x67, 0xc4, 0xa7, 0xd3, 0x39, 0xd0, 0xfb, 0x69, 0x41, 0xed, 0x53, 0x8c, 0x43, 0x45,
-	0xd0, 0x21, 0x54, 0x42, 0x8c, 0x5d, 0x6b, 0xc7, 0xda, 0xb3, 0xdb, 0x8f, 0xfc, 0xbf, 0x2a, 0xf8,
-	0x9f, 0x09, 0x1d, 0x9f, 0x2b, 0x82, 0x5f, 0x63, 0x2c, 0x3e, 0x12, 0xd5, 0x2b, 0x05, 0x29, 0x11,
-	0x1d, 0x40, 0x4d, 0x90, 0x29, 0xbf, 0x20, 0x6e, 0x59, 0x4b, 0x78, 0x2b, 0x24, 0x72, 0xaa, 0xe1,
-	0xa0, 0x63, 0x70, 0x18, 0x1f, 0x10, 0x86, 0x63, 0x4e, 0x99, 0x92, 0x6e, 0x45, 0x6b, 0x3c, 0x58,
-	0xa1, 0x71, 0xc2, 0x8f, 0xe6, 0xe8, 0x5e, 0x29, 0xb0, 0x59, 0x5e, 0x76, 0xeb, 0x50, 0x9b, 0x69,
-	0x53, 0xde, 0x73, 0xf8, 0xcf, 0xec, 0x85, 0xf6, 0xa1, 0x1a, 0x62, 0x2c, 0xa4, 0x6b, 0xed, 0x54,
-	0xf6, 0xec, 0x76, 0xf3, 0x8f, 0xb4, 0x09, 0xe4, 0x74, 0x14, 0xa7, 0x50, 0x22, 0x65, 0x90, 0x01,
-	0xbd, 0x5f, 0x16, 0xfc, 0xbf, 0x64, 0x16, 0xbd, 0x58, 0x54, 0x79, 0xb8, 0x66, 0x4e, 0x46, 0x12,
-	0x85, 0xd0, 0x98, 0x12, 0x25, 0xe8, 0x68, 0x10, 0x85, 0x43, 0x12, 0x49, 0xb7, 0xac, 0x65, 0x0e,
-	0xd6, 0x8f, 0xdb, 0x7f, 0xaf, 0xf9, 0xef, 0x34, 0xfd, 0x88, 0x29, 0x91, 0x04, 0xce, 0xb4, 0xb0,
-	0xd4, 0x7c, 0x09, 0x37, 0xae, 0x40, 0xd0, 0x36, 0x54, 0x26, 0x24, 0xd1, 0xc3, 0xdd, 0x0c, 0xd2,
-	0x5f, 0x74, 0x13, 0xaa, 0x17, 0x61, 0x34, 0xcb, 0xa6, 0xb5, 0x19, 0x64, 0xc5, 0xb3, 0x72, 0xc7,
-	0xf2, 0xbe, 0x97, 0xc1, 0x29, 0x6e, 0x8a, 0x7c, 0xd8, 0x48, 0xbb, 0x37, 0x47, 0x63, 0x55, 0x70,
-	0x1a, 0x87, 0x6e, 0x41, 0xed, 0x9b, 0xe6, 0xeb, 0x29, 0x36, 0x02, 0x53, 0xa1, 0xaf, 0xcb, 0xe6,
-	0x37, 0xb4, 0xf9, 0xa7, 0x6b, 0x9a, 0xbf, 0xce, 0x39, 0xea, 0x83, 0xa3, 0x22, 0x39, 0xa0, 0x98,
-	0x30, 0x45, 0x55, 0xe2, 0x56, 0xaf, 0x3d, 0x43, 0xa7, 0x91, 0xec, 0x1b, 0x74, 0x60, 0xab, 0xbc,
-	0xf8, 0xf7, 0x10, 0x7f, 0x58, 0x60, 0x17, 0xd4, 0x51, 0x08, 0xdb, 0x93, 0x8e, 0x1c, 0xc4, 0x1c,
-	0xe7, 0xfd, 0x65, 0xf7, 0xe4, 0xc9, 0x7a, 0xfd, 0xf9, 0xc7, 0x1d, 0xf9, 0x81, 0xe3, 0x79, 0xd9,
-	0x2b, 0x05, 0x5b, 0x93, 0x85, 0x95, 0xe6, 0x19, 0x6c, 0x2d, 0x62, 0xd0, 0x2e, 0x38, 0x0b, 0x1b,
-	0x66, 0x9d, 0xdb, 0x71, 0x01, 0x72, 0x0f, 0x1a, 0x23, 0xce, 0x94, 0xe0, 0x51, 0x44, 0xc4, 0x80,
-	0x49, 0xe3, 0xc4, 0xc9, 0x17, 0x4f, 0x64, 0x17, 0xa0, 0x2e, 0x95, 0x08, 0x15, 0x19, 0x27, 0xde,
-	0x7d, 0xb0, 0x0b, 0x37, 0x2f, 0x9d, 0x35, 0xb9, 0xa4, 0x52, 0x49, 0x2d, 0x5e, 0x0f, 0x4c, 0xd5,
-	0x3e, 0x03, 0xfb, 0x4d, 0x6e, 0x04, 0xf5, 0xa1, 0xf2, 0x96, 0x28, 0x74, 0x77, 0xf9, 0xec, 0x14,
-	0x30, 0xcd, 0xdd, 0x15, 0x41, 0x64, 0x6f, 0x94, 0x57, 0xda, 0xb7, 0xba, 0xaf, 0xbe, 0x1c, 0x8e,
-	0xa9, 0x3a, 0x9f, 0x0d, 0x53, 0x8d, 0x96, 0x98, 0x31, 0xc3, 0x6a, 0x15, 0xbe, 0xa6, 0xf5, 0xd6,
-	0x98, 0xb0, 0xd6, 0x95, 0x37, 0x72, 0x58, 0xd3, 0x6f, 0xdf, 0xe3, 0xdf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x52, 0x18, 0xeb, 0x4b, 0x3f, 0x05, 0x00, 0x00,
+	// 701 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x55, 0x6d, 0x6e, 0xd3, 0x40,
+	0x10, 0xc5, 0x4d, 0x93, 0x26, 0x63, 0xb7, 0x94, 0x15, 0x82, 0x34, 0x08, 0xd4, 0x1a, 0xf1, 0x21,
+	0x24, 0xec, 0xaa, 0x15, 0x52, 0x80, 0x52, 0xa0, 0x6a, 0x05, 0x55, 0xa1, 0xaa, 0x96, 0x22, 0x10,
+	0x42, 0x44, 0x4e, 0xbc, 0x75, 0xac, 0xda, 0x5e, 0xcb, 0xbb, 0x2e, 0xcd, 0x95, 0x38, 0x00, 0x07,
+	0xe0, 0x10, 0xfc, 0xe3, 0x16, 0x1c, 0x80, 0xf5, 0x7a, 0x4d, 0x9c, 0x56, 0xb8, 0x91, 0xf8, 0x15,
+	0xcf, 0xe8, 0xcd, 0x9b, 0x79, 0x33, 0xb3, 0x13, 0xb8, 0x1e, 0x27, 0xf4, 0x74, 0x64, 0xbb, 0x84,
+	0x71, 0x3f, 0x72, 0xb8, 0x4f, 0x23, 0x4b, 0x78, 0x38, 0x45, 0x4b, 0x03, 0x1a, 0xb9, 0xa9, 0xcf,
+	0x2d, 0x09, 0xb0, 0x4a, 0x80, 0xce, 0x2d, 0x8f, 0x52, 0x2f, 0x20, 0xb6, 0x04, 0xf6, 0xd3, 0x23,
+	0xdb, 0x4d, 0x93, 0x52, 0x68, 0xc7, 0x18, 0xd0, 0x30, 0x2c, 0x2c, 0xf3, 0x97, 0x06, 0x8d, 0xf7,
+	0xb1, 0xeb, 0x70, 0x82, 0x36, 0xa1, 0xe6, 0xb8, 0x6e, 0x5b, 0x5b, 0xd6, 0xee, 0xeb, 0x6b, 0x0f,
+	0xac, 0x7f, 0x66, 0xb0, 0x3e, 0x10, 0xdf, 0x1b, 0x72, 0xe2, 0xbe, 0x74, 0xdd, 0xe4, 0x1d, 0xe1,
+	0xaf, 0x2f, 0xe1, 0x2c, 0x10, 0x6d, 0x40, 0x23, 0x21, 0x21, 0x3d, 0x21, 0xed, 0x19, 0x49, 0x61,
+	0x56, 0x50, 0x8c, 0x43, 0x55, 0x0c, 0xda, 0x03, 0x23, 0xa2, 0x3d, 0x12, 0xb9, 0x31, 0xf5, 0x23,
+	0xce, 0xda, 0x35, 0xc9, 0x71, 0xb7, 0x82, 0x63, 0x9f, 0xee, 0x14, 0x68, 0xc1, 0xa3, 0x47, 0x63,
+	0x73, 0xab, 0x09, 0x8d, 0x54, 0x8a, 0x32, 0x9f, 0xc2, 0x9c, 0xca, 0x85, 0x56, 0xa1, 0x2e, 0xca,
+	0x4c, 0x98, 0x50, 0x58, 0x13, 0xd4, 0x9d, 0xbf, 0xd4, 0xaa, 0x21, 0x87, 0x83, 0x38, 0x83, 0x12,
+	0xc6, 0x70, 0x0e, 0x34, 0x7f, 0x6b, 0x70, 0xf9, 0x8c, 0x58, 0xf4, 0x6c, 0x92, 0xe5, 0xde, 0x94,
+	0x7d, 0x52, 0x94, 0xc8, 0x81, 0xf9, 0x90, 0xf0, 0xc4, 0x1f, 0xf4, 0x02, 0xa7, 0x4f, 0x02, 0x26,
+	0x7a, 0x95, 0xd1, 0x6c, 0x4c, 0xdf, 0x6e, 0xeb, 0xad, 0x8c, 0x7f, 0x23, 0xc3, 0x77, 0x22, 0x9e,
+	0x8c, 0xb0, 0x11, 0x96, 0x5c, 0x9d, 0xe7, 0x70, 0xe5, 0x1c, 0x04, 0x2d, 0x42, 0xed, 0x98, 0x8c,
+	0xe4, 0x70, 0x5b, 0x38, 0xfb, 0x44, 0x57, 0xa1, 0x7e, 0xe2, 0x04, 0x69, 0x3e, 0xad, 0x16, 0xce,
+	0x8d, 0x27, 0x33, 0x5d, 0xcd, 0xfc, 0x31, 0x03, 0x46, 0x39, 0x29, 0xb2, 0x60, 0x36, 0xab, 0x5e,
+	0xad, 0x46, 0x55, 0xe3, 0x24, 0x0e, 0x5d, 0x83, 0xc6, 0x57, 0x19, 0x2f, 0xa7, 0x38, 0x8f, 0x95,
+	0x85, 0xbe, 0x9c, 0x15, 0x3f, 0x2b, 0xc5, 0x3f, 0x9e, 0x52, 0xfc, 0x45, 0xca, 0xd1, 0x2e, 0x18,
+	0x3c, 0x60, 0x3d, 0xdf, 0x25, 0x11, 0xf7, 0xf9, 0xa8, 0x5d, 0xbf, 0x70, 0x87, 0x0e, 0x03, 0xb6,
+	0xab, 0xd0, 0x58, 0xe7, 0x63, 0xe3, 0xff, 0x9b, 0xf8, 0x53, 0x03, 0xbd, 0xc4, 0x2e, 0x06, 0xbf,
+	0x78, 0xdc, 0x65, 0xbd, 0x98, 0xba, 0xe3, 0xfa, 0xf2, 0x77, 0xf2, 0x68, 0xba, 0xfa, 0xac, 0xbd,
+	0x2e, 0x3b, 0xa0, 0x6e, 0x61, 0x8a, 0x95, 0x5f, 0x38, 0x9e, 0xf0, 0x74, 0x3e, 0xc2, 0xc2, 0x24,
+	0x06, 0xad, 0x80, 0x31, 0x91, 0x30, 0xaf, 0x5c, 0x8f, 0x4b, 0x90, 0xdb, 0x30, 0x2f, 0xd2, 0xf3,
+	0x84, 0x06, 0x01, 0x49, 0x7a, 0x11, 0x53, 0x4a, 0x8c, 0xb1, 0x73, 0x9f, 0x6d, 0x01, 0x34, 0x19,
+	0x17, 0x57, 0x84, 0x78, 0x23, 0xf3, 0x0e, 0xe8, 0xa5, 0x97, 0x97, 0xcd, 0x9a, 0x9c, 0xfa, 0x8c,
+	0x33, 0x49, 0xde, 0xc4, 0xca, 0x32, 0xf7, 0x01, 0x6d, 0x8f, 0x85, 0x1c, 0x24, 0xf4, 0xc8, 0x0f,
+	0x08, 0xea, 0x8a, 0x1b, 0x41, 0x53, 0x4e, 0x8a, 0xe7, 0xb3, 0x5c, 0xa1, 0x1d, 0x67, 0x40, 0xac,
+	0xf0, 0xe6, 0x37, 0x0d, 0xea, 0xd2, 0x83, 0x10, 0xcc, 0x46, 0x4e, 0x48, 0x94, 0x18, 0xf9, 0x9d,
+	0x55, 0x21, 0x36, 0x61, 0x48, 0x5d, 0x55, 0xbe, 0xb2, 0xd0, 0x4d, 0x80, 0xd8, 0xe1, 0xc3, 0x5e,
+	0x42, 0x3c, 0x72, 0x2a, 0xb7, 0xb1, 0x85, 0x5b, 0x99, 0x07, 0x67, 0x0e, 0xb4, 0x0e, 0x73, 0xdc,
+	0x0f, 0x89, 0xa0, 0x15, 0xab, 0x98, 0xcd, 0x62, 0xc9, 0xca, 0xaf, 0xa7, 0x55, 0x5c, 0x4f, 0x6b,
+	0x5b, 0x5d, 0x4f, 0x5c, 0x20, 0xb3, 0xa6, 0xfa, 0x4c, 0x30, 0x8a, 0x8d, 0x70, 0xfa, 0x01, 0x91,
+	0x5b, 0xd6, 0xc4, 0xba, 0xcf, 0x70, 0xe1, 0x5a, 0xfb, 0x2e, 0x86, 0x5f, 0x52, 0x2f, 0x16, 0xb3,
+	0xf6, 0x4a, 0xdc, 0x8e, 0x1b, 0x67, 0x5f, 0x4e, 0x09, 0xd3, 0x59, 0xa9, 0x68, 0x45, 0x7e, 0xa1,
+	0xcd, 0x4b, 0xab, 0x1a, 0xfa, 0x0c, 0x20, 0xa8, 0x8a, 0x7e, 0x56, 0x32, 0x3e, 0xac, 0x60, 0x3c,
+	0x3f, 0x9b, 0x8c, 0x7d, 0xeb, 0xc5, 0xa7, 0x4d, 0xcf, 0xe7, 0xc3, 0xb4, 0x9f, 0xf1, 0xd9, 0x49,
+	0x1a, 0x29, 0x06, 0xbb, 0xf4, 0xab, 0xd6, 0xc2, 0xf6, 0x48, 0x64, 0x9f, 0xfb, 0x7f, 0xea, 0x37,
+	0x64, 0xe7, 0xd6, 0xff, 0x00, 0x5e, 0xcc, 0xd7, 0x77, 0xbb, 0x06, 0x00, 0x00,
 }
@@ -556,6 +556,7 @@ type ObserveRequest_Match_Http struct {
 	//	*ObserveRequest_Match_Http_Method
 	//	*ObserveRequest_Match_Http_Authority
 	//	*ObserveRequest_Match_Http_Path
+	//	*ObserveRequest_Match_Http_Header_
 	Match isObserveRequest_Match_Http_Match `protobuf_oneof:"match"`
 }
 
@@ -578,11 +579,15 @@ type ObserveRequest_Match_Http_Authority struct {
 type ObserveRequest_Match_Http_Path struct {
 	Path *ObserveRequest_Match_Http_StringMatch `protobuf:"bytes,4,opt,name=path,oneof"`
 }
+type ObserveRequest_Match_Http_Header_ struct {
+	Header *ObserveRequest_Match_Http_Header `protobuf:"bytes,5,opt,name=header,oneof"`
+}
 
 func (*ObserveRequest_Match_Http_Scheme) isObserveRequest_Match_Http_Match()    {}
 func (*ObserveRequest_Match_Http_Method) isObserveRequest_Match_Http_Match()    {}
 func (*ObserveRequest_Match_Http_Authority) isObserveRequest_Match_Http_Match() {}
 func (*ObserveRequest_Match_Http_Path) isObserveRequest_Match_Http_Match()      {}
+func (*ObserveRequest_Match_Http_Header_) isObserveRequest_Match_Http_Match()   {}
 
 func (m *ObserveRequest_Match_Http) GetMatch() isObserveRequest_Match_Http_Match {
 	if m != nil {
@@ -619,6 +624,13 @@ func (m *ObserveRequest_Match_Http) GetPath() *ObserveRequest_Match_Http_StringM
 	return nil
 }
 
+func (m *ObserveRequest_Match_Http) GetHeader() *ObserveRequest_Match_Http_Header {
+	if x, ok := m.GetMatch().(*ObserveRequest_Match_Http_Header_); ok {
+		return x.Header
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ObserveRequest_Match_Http) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _ObserveRequest_Match_Http_OneofMarshaler, _ObserveRequest_Match_Http_OneofUnmarshaler, _ObserveRequest_Match_Http_OneofSizer, []interface{}{
@@ -626,6 +638,7 @@ func (*ObserveRequest_Match_Http) XXX_OneofFuncs() (func(msg proto.Message, b *p
 		(*ObserveRequest_Match_Http_Method)(nil),
 		(*ObserveRequest_Match_Http_Authority)(nil),
 		(*ObserveRequest_Match_Http_Path)(nil),
+		(*ObserveRequest_Match_Http_Header_)(nil),
 	}
 }
 
@@ -653,6 +666,11 @@ func _ObserveRequest_Match_Http_OneofMarshaler(msg proto.Message, b *proto.Buffe
 		if err := b.EncodeMessage(x.Path); err != nil {
 			return err
 		}
+	case *ObserveRequest_Match_Http_Header_:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Header); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ObserveRequest_Match_Http.Match has unexpected type %T", x)
@@ -695,6 +713,14 @@ func _ObserveRequest_Match_Http_OneofUnmarshaler(msg proto.Message, tag, wire in
 		err := b.DecodeMessage(msg)
 		m.Match = &ObserveRequest_Match_Http_Path{msg}
 		return true, err
+	case 5: // match.header
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ObserveRequest_Match_Http_Header)
+		err := b.DecodeMessage(msg)
+		m.Match = &ObserveRequest_Match_Http_Header_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -724,6 +750,11 @@ func _ObserveRequest_Match_Http_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(4<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *ObserveRequest_Match_Http_Header_:
+		s := proto.Size(x.Header)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -844,6 +875,34 @@ func _ObserveRequest_Match_Http_StringMatch_OneofSizer(msg proto.Message) (n int
 	return n
 }
 
+// Matches requests with a header of the given name, any of whose values
+// matches `value`. Header names are case-insensitive.
+type ObserveRequest_Match_Http_Header struct {
+	Name  string                                 `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value *ObserveRequest_Match_Http_StringMatch `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ObserveRequest_Match_Http_Header) Reset()         { *m = ObserveRequest_Match_Http_Header{} }
+func (m *ObserveRequest_Match_Http_Header) String() string { return proto.CompactTextString(m) }
+func (*ObserveRequest_Match_Http_Header) ProtoMessage()    {}
+func (*ObserveRequest_Match_Http_Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{0, 0, 3, 1}
+}
+
+func (m *ObserveRequest_Match_Http_Header) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ObserveRequest_Match_Http_Header) GetValue() *ObserveRequest_Match_Http_StringMatch {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ObserveRequest)(nil), "conduit.proxy.tap.ObserveRequest")
 	proto.RegisterType((*ObserveRequest_Match)(nil), "conduit.proxy.tap.ObserveRequest.Match")
@@ -854,6 +913,7 @@ func init() {
 	proto.RegisterType((*ObserveRequest_Match_Tcp_PortRange)(nil), "conduit.proxy.tap.ObserveRequest.Match.Tcp.PortRange")
 	proto.RegisterType((*ObserveRequest_Match_Http)(nil), "conduit.proxy.tap.ObserveRequest.Match.Http")
 	proto.RegisterType((*ObserveRequest_Match_Http_StringMatch)(nil), "conduit.proxy.tap.ObserveRequest.Match.Http.StringMatch")
+	proto.RegisterType((*ObserveRequest_Match_Http_Header)(nil), "conduit.proxy.tap.ObserveRequest.Match.Http.Header")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -958,45 +1018,47 @@ var _Tap_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("proxy/tap.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 633 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0xc7, 0x93, 0x38, 0x4e, 0x94, 0xc9, 0xef, 0x07, 0xed, 0x0a, 0x55, 0xc6, 0xa7, 0xd2, 0x0b,
-	0x45, 0x80, 0x5d, 0xb5, 0x80, 0x2a, 0x21, 0x84, 0x5a, 0xa9, 0x92, 0x41, 0xf4, 0x0f, 0x4e, 0x0e,
-	0x08, 0x21, 0xa1, 0x8d, 0xb3, 0xc4, 0x56, 0xed, 0xdd, 0xed, 0x7a, 0x5c, 0x35, 0x2f, 0xc0, 0x63,
-	0x70, 0xe7, 0xd9, 0x78, 0x09, 0xb4, 0xeb, 0x4d, 0x08, 0xed, 0x81, 0x1a, 0x4e, 0x99, 0xdd, 0x99,
-	0xef, 0x27, 0xe3, 0xc9, 0x77, 0x62, 0xb8, 0x2b, 0x95, 0xb8, 0x9a, 0x87, 0x48, 0x65, 0x20, 0x95,
-	0x40, 0x41, 0xd6, 0x13, 0xc1, 0xa7, 0x55, 0x86, 0x81, 0x49, 0x04, 0x48, 0xa5, 0xff, 0x5f, 0x22,
-	0x8a, 0x42, 0xf0, 0xba, 0x60, 0xeb, 0xeb, 0x10, 0xee, 0x9c, 0x4e, 0x4a, 0xa6, 0x2e, 0x59, 0xcc,
-	0x2e, 0x2a, 0x56, 0x22, 0xb9, 0x07, 0x6e, 0x9e, 0x15, 0x19, 0x7a, 0xed, 0xcd, 0xf6, 0xf6, 0xff,
-	0x71, 0x7d, 0x20, 0xaf, 0xc0, 0x2d, 0x28, 0x26, 0xa9, 0xd7, 0xd9, 0x6c, 0x6f, 0x0f, 0x77, 0x1f,
-	0x06, 0x37, 0xc8, 0xc1, 0xef, 0x9c, 0xe0, 0x58, 0x97, 0xc7, 0xb5, 0xca, 0xff, 0x06, 0xe0, 0x9a,
-	0x0b, 0xf2, 0x1a, 0x1c, 0x9a, 0xe7, 0x06, 0x3e, 0xdc, 0x7d, 0x7c, 0x4b, 0x4c, 0x30, 0x62, 0x17,
-	0x51, 0x2b, 0xd6, 0x4a, 0x03, 0xe0, 0x73, 0xdb, 0x47, 0x63, 0x00, 0x9f, 0x93, 0x97, 0xe0, 0x70,
-	0x81, 0x9e, 0xd3, 0xe8, 0x41, 0xb4, 0x98, 0x0b, 0x24, 0x47, 0xd0, 0x2b, 0x45, 0xa5, 0x12, 0xe6,
-	0x75, 0x9b, 0x35, 0x30, 0x4e, 0x64, 0xd4, 0x8a, 0xad, 0x98, 0x9c, 0xc2, 0x70, 0xca, 0x4a, 0xcc,
-	0x38, 0xc5, 0x4c, 0x70, 0xcf, 0xfd, 0x1b, 0xd6, 0x2a, 0x81, 0x1c, 0x42, 0x37, 0x45, 0x94, 0x5e,
-	0xcf, 0x90, 0x9e, 0xdc, 0x96, 0x14, 0x21, 0x6a, 0x94, 0xd1, 0x92, 0x4f, 0xb0, 0xbe, 0x82, 0xfc,
-	0x9c, 0xd3, 0x09, 0xcb, 0xbd, 0xbe, 0x01, 0x3e, 0xbd, 0x2d, 0xf0, 0x9d, 0x16, 0x45, 0xad, 0x78,
-	0x6d, 0x85, 0x64, 0xee, 0xfc, 0x08, 0x9c, 0x11, 0xbb, 0x20, 0x07, 0xd0, 0x37, 0x96, 0x60, 0xa5,
-	0xd7, 0xde, 0x74, 0x9a, 0x58, 0x69, 0xa1, 0xf3, 0x43, 0x70, 0x0d, 0x92, 0xac, 0x81, 0x73, 0xce,
-	0xe6, 0xc6, 0x4b, 0x83, 0x58, 0x87, 0xda, 0xbc, 0x97, 0x34, 0xaf, 0x98, 0xb1, 0xc7, 0x20, 0xae,
-	0x0f, 0xfe, 0xf7, 0x0e, 0x38, 0xe3, 0x44, 0x92, 0x53, 0xe8, 0x73, 0x86, 0x05, 0x2d, 0xcf, 0xad,
-	0xff, 0xf6, 0x1a, 0x4c, 0x3c, 0x38, 0xa9, 0xa5, 0x51, 0x2b, 0x5e, 0x50, 0xc8, 0x31, 0xb8, 0x52,
-	0x28, 0x2c, 0xad, 0x99, 0x9e, 0x37, 0xc1, 0x9d, 0x09, 0x85, 0x31, 0xe5, 0x33, 0x16, 0xb5, 0xe2,
-	0x9a, 0xe2, 0x47, 0xd0, 0xb7, 0x5f, 0x42, 0x1e, 0x41, 0x27, 0x93, 0xb6, 0xcb, 0xfb, 0x4b, 0xac,
-	0xdd, 0xdd, 0x37, 0x67, 0x07, 0xd3, 0xa9, 0x62, 0x65, 0x19, 0x77, 0x32, 0x49, 0x08, 0x74, 0xcd,
-	0x23, 0x75, 0xcc, 0xbe, 0x9a, 0xd8, 0x0f, 0x61, 0xb0, 0xe4, 0xeb, 0x31, 0x15, 0x19, 0xb7, 0xfb,
-	0xac, 0x43, 0x73, 0x43, 0xaf, 0xac, 0x42, 0x87, 0x87, 0x7d, 0xbb, 0xdf, 0xfe, 0x8f, 0x0e, 0x74,
-	0xb5, 0x2b, 0xc8, 0x0e, 0xf4, 0xca, 0x24, 0x65, 0x05, 0xb3, 0x5d, 0x6c, 0x5c, 0xef, 0x62, 0x64,
-	0xb2, 0xc6, 0xd4, 0x26, 0x22, 0xcf, 0xa0, 0x57, 0x30, 0x4c, 0xc5, 0xd4, 0x8e, 0xc3, 0xbf, 0xae,
-	0xd0, 0xdc, 0x63, 0x53, 0xa1, 0x55, 0x75, 0x2d, 0xf9, 0x00, 0x03, 0x5a, 0x61, 0x2a, 0x54, 0x86,
-	0x8b, 0xad, 0xde, 0x6f, 0x62, 0xdf, 0x60, 0x84, 0x2a, 0xe3, 0xb3, 0xc5, 0x96, 0xfe, 0x82, 0x91,
-	0x13, 0xe8, 0x4a, 0x8a, 0xa9, 0xdd, 0xd4, 0x7f, 0x81, 0x1a, 0x8e, 0x1f, 0xc1, 0x70, 0xe5, 0x9a,
-	0x6c, 0x80, 0xcb, 0xae, 0x68, 0x52, 0xff, 0x51, 0x0e, 0xf4, 0xaf, 0x68, 0x8e, 0xc4, 0x83, 0x9e,
-	0x54, 0xec, 0x4b, 0x56, 0xcf, 0x57, 0x27, 0xec, 0x79, 0x39, 0xe4, 0x9b, 0xc1, 0xee, 0x7b, 0x70,
-	0xc6, 0x54, 0x92, 0xb7, 0xd0, 0xb7, 0x3d, 0x91, 0x07, 0x7f, 0xec, 0xd7, 0xf7, 0xae, 0x0f, 0x78,
-	0x4c, 0xe5, 0xd1, 0x25, 0xe3, 0xb8, 0xd5, 0xda, 0x69, 0x1f, 0xee, 0x7f, 0x7c, 0x31, 0xcb, 0x30,
-	0xad, 0x26, 0x3a, 0x1b, 0xaa, 0x8a, 0xdb, 0xe2, 0x70, 0xe5, 0x13, 0x95, 0xc8, 0x73, 0xa6, 0xc2,
-	0x19, 0xe3, 0xe1, 0xf2, 0xe5, 0x31, 0xe9, 0x99, 0x97, 0xc3, 0xde, 0xcf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x25, 0x39, 0x61, 0x18, 0x50, 0x06, 0x00, 0x00,
+	// 660 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x95, 0xdf, 0x6e, 0xd3, 0x30,
+	0x14, 0xc6, 0xd7, 0xa6, 0x49, 0xd5, 0xd3, 0x01, 0x9b, 0x85, 0xa6, 0x90, 0x2b, 0xd8, 0x0d, 0x20,
+	0x20, 0x9d, 0x36, 0x40, 0x93, 0x10, 0x42, 0xab, 0x34, 0xa9, 0x20, 0xf6, 0x87, 0x8c, 0x0b, 0x84,
+	0x90, 0x90, 0xdb, 0x9a, 0x36, 0x5a, 0xe2, 0x64, 0x89, 0x53, 0xb5, 0x6f, 0x04, 0xcf, 0xc1, 0x63,
+	0xf0, 0x32, 0x1c, 0x9f, 0xb8, 0x5d, 0x29, 0x17, 0x34, 0xec, 0xaa, 0xc7, 0x3e, 0xfe, 0x7e, 0xb6,
+	0x8f, 0xbf, 0xd3, 0xc0, 0x9d, 0x34, 0x4b, 0xa6, 0xb3, 0x8e, 0xe2, 0xa9, 0x8f, 0x91, 0x4a, 0xd8,
+	0xf6, 0x20, 0x91, 0xc3, 0x22, 0x54, 0x3e, 0x25, 0x7c, 0x4c, 0x78, 0x9b, 0x83, 0x24, 0x8e, 0x13,
+	0x59, 0x2e, 0xd8, 0xfd, 0xb9, 0x09, 0xb7, 0xcf, 0xfa, 0xb9, 0xc8, 0x26, 0x22, 0x10, 0x57, 0x85,
+	0xc8, 0x15, 0xbb, 0x0b, 0x76, 0x14, 0xc6, 0xa1, 0x72, 0x6b, 0xf7, 0x6b, 0x8f, 0x6e, 0x05, 0xe5,
+	0x80, 0xbd, 0x06, 0x3b, 0xe6, 0x6a, 0x30, 0x76, 0xeb, 0x38, 0xdb, 0xde, 0x7f, 0xe8, 0xff, 0x45,
+	0xf6, 0xff, 0xe4, 0xf8, 0x27, 0x7a, 0x79, 0x50, 0xaa, 0xbc, 0x5f, 0x6d, 0xb0, 0x69, 0x82, 0xbd,
+	0x01, 0x8b, 0x47, 0x11, 0xc1, 0xdb, 0xfb, 0x4f, 0xd6, 0xc4, 0xf8, 0x17, 0xe2, 0xaa, 0xb7, 0x11,
+	0x68, 0x25, 0x01, 0xe4, 0xcc, 0x9c, 0xa3, 0x32, 0x40, 0xce, 0xd8, 0x2b, 0xb0, 0x64, 0xa2, 0x5c,
+	0xab, 0xd2, 0x45, 0xb4, 0x18, 0x55, 0xec, 0x18, 0x9c, 0x3c, 0x29, 0xb2, 0x81, 0x70, 0x1b, 0xd5,
+	0x0e, 0xf0, 0x71, 0x90, 0x22, 0xc3, 0x88, 0xd9, 0x19, 0xb4, 0x87, 0x98, 0x0b, 0x25, 0x57, 0x61,
+	0x22, 0x5d, 0xfb, 0x7f, 0x58, 0xcb, 0x04, 0xd6, 0x85, 0xc6, 0x58, 0xa9, 0xd4, 0x75, 0x88, 0xf4,
+	0x74, 0x5d, 0x52, 0x0f, 0x35, 0x88, 0x22, 0x2d, 0xfb, 0x02, 0xdb, 0x4b, 0xc8, 0xaf, 0x11, 0xef,
+	0x8b, 0xc8, 0x6d, 0x12, 0xf0, 0xd9, 0xba, 0xc0, 0xf7, 0x5a, 0x84, 0xc4, 0xad, 0x25, 0x12, 0xcd,
+	0x79, 0x3d, 0xb0, 0xf0, 0x11, 0xd8, 0x11, 0x34, 0xc9, 0x12, 0x22, 0x47, 0x0f, 0x58, 0x55, 0xac,
+	0x34, 0xd7, 0x79, 0x1d, 0xb0, 0x09, 0xc9, 0xb6, 0xc0, 0xba, 0x14, 0x33, 0xf2, 0x52, 0x2b, 0xd0,
+	0xa1, 0x36, 0xef, 0x84, 0x47, 0x85, 0x20, 0x7b, 0xb4, 0x82, 0x72, 0xe0, 0xfd, 0xa8, 0x83, 0x85,
+	0x35, 0xc3, 0xaa, 0x37, 0xa5, 0x50, 0x31, 0xcf, 0x2f, 0x8d, 0xff, 0x0e, 0x2a, 0x54, 0xdc, 0x3f,
+	0x2d, 0xa5, 0x78, 0xb9, 0x39, 0x85, 0x9d, 0x80, 0x9d, 0x26, 0x99, 0xca, 0x8d, 0x99, 0x5e, 0x54,
+	0xc1, 0x9d, 0xa3, 0x30, 0xe0, 0x72, 0x24, 0x10, 0x58, 0x52, 0xb0, 0x44, 0x4d, 0xb3, 0x09, 0x7b,
+	0x0c, 0xf5, 0x30, 0x35, 0xa7, 0xbc, 0xb7, 0xc0, 0x9a, 0xde, 0x7d, 0x7b, 0x7e, 0x34, 0x1c, 0x66,
+	0x22, 0xcf, 0x03, 0x5c, 0xc4, 0x18, 0x34, 0xe8, 0x4a, 0x75, 0xea, 0x57, 0x8a, 0xb1, 0x44, 0xad,
+	0x05, 0x5f, 0x97, 0x29, 0x0e, 0xa5, 0xe9, 0x67, 0x1d, 0xd2, 0x0c, 0x9f, 0x1a, 0x85, 0x0e, 0xbb,
+	0x4d, 0xd3, 0xdf, 0xde, 0xf7, 0x06, 0x34, 0xb4, 0x2b, 0xd8, 0x1e, 0x3a, 0x1d, 0xcb, 0x1d, 0x0b,
+	0x73, 0x8a, 0x9d, 0xd5, 0x53, 0x5c, 0x50, 0x96, 0x4c, 0x4d, 0x11, 0x7b, 0x0e, 0x4e, 0x2c, 0xd4,
+	0x38, 0x19, 0x9a, 0x72, 0x78, 0xab, 0x0a, 0xcd, 0x3d, 0xa1, 0x15, 0x5a, 0x55, 0xae, 0x65, 0x9f,
+	0xa0, 0xc5, 0x0b, 0x8c, 0xb2, 0x50, 0xcd, 0xbb, 0xfa, 0xb0, 0x8a, 0x7d, 0xfd, 0x0b, 0x95, 0x85,
+	0x72, 0x34, 0xef, 0xd2, 0x6b, 0x18, 0x3b, 0x85, 0x46, 0xca, 0xd5, 0xd8, 0x74, 0xea, 0x4d, 0xa0,
+	0xc4, 0xc1, 0xd7, 0x76, 0xc6, 0x82, 0x0f, 0x45, 0x66, 0xfa, 0xf5, 0xa0, 0x12, 0xb1, 0x47, 0x52,
+	0x7d, 0xf1, 0x12, 0x82, 0xaf, 0xdd, 0x5e, 0xda, 0x85, 0xed, 0x80, 0x2d, 0xa6, 0x7c, 0x50, 0xfe,
+	0xef, 0xb6, 0xb4, 0x29, 0x68, 0xc8, 0x5c, 0x70, 0xd2, 0x4c, 0x7c, 0x0b, 0xcb, 0xe7, 0xd2, 0x09,
+	0x33, 0xbe, 0x7e, 0xb3, 0x08, 0x9c, 0x92, 0xae, 0xbd, 0x20, 0xb9, 0x79, 0xb2, 0x56, 0x40, 0x31,
+	0x96, 0x61, 0xa9, 0x27, 0x6e, 0x50, 0x07, 0xd3, 0x4d, 0x8b, 0x6d, 0x17, 0xc1, 0xfe, 0x07, 0x6c,
+	0x2f, 0x9e, 0xb2, 0x77, 0xd0, 0x34, 0x20, 0xf6, 0xe0, 0x9f, 0x9b, 0x78, 0xee, 0xaa, 0x3b, 0x10,
+	0x71, 0x3c, 0x11, 0x52, 0xed, 0x6e, 0xec, 0xd5, 0xba, 0x87, 0x9f, 0x5f, 0x8e, 0x42, 0x35, 0x2e,
+	0xfa, 0x3a, 0xdb, 0xc9, 0x0a, 0x69, 0x16, 0x77, 0x96, 0x7e, 0x55, 0x96, 0x44, 0x91, 0xc8, 0x3a,
+	0x23, 0x21, 0x3b, 0x8b, 0x2f, 0x5f, 0xdf, 0xa1, 0x2f, 0xdb, 0xc1, 0x6f, 0x9a, 0xbe, 0xe1, 0x94,
+	0x0d, 0x07, 0x00, 0x00,
 }
@@ -33,6 +33,7 @@ import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/duration"
 import conduit_common "github.com/runconduit/conduit/controller/gen/common"
 import conduit_common_healthcheck "github.com/runconduit/conduit/controller/gen/common/healthcheck"
+import conduit_proxy_destination "github.com/runconduit/conduit/controller/gen/proxy/destination"
 
 import (
 	context "golang.org/x/net/context"
@@ -632,6 +633,7 @@ type TapByResourceRequest_Match struct {
 	//	*TapByResourceRequest_Match_Not
 	//	*TapByResourceRequest_Match_Destinations
 	//	*TapByResourceRequest_Match_Http_
+	//	*TapByResourceRequest_Match_Sources
 	Match isTapByResourceRequest_Match_Match `protobuf_oneof:"match"`
 }
 
@@ -657,12 +659,16 @@ type TapByResourceRequest_Match_Destinations struct {
 type TapByResourceRequest_Match_Http_ struct {
 	Http *TapByResourceRequest_Match_Http `protobuf:"bytes,5,opt,name=http,oneof"`
 }
+type TapByResourceRequest_Match_Sources struct {
+	Sources *ResourceSelection `protobuf:"bytes,6,opt,name=sources,oneof"`
+}
 
 func (*TapByResourceRequest_Match_All) isTapByResourceRequest_Match_Match()          {}
 func (*TapByResourceRequest_Match_Any) isTapByResourceRequest_Match_Match()          {}
 func (*TapByResourceRequest_Match_Not) isTapByResourceRequest_Match_Match()          {}
 func (*TapByResourceRequest_Match_Destinations) isTapByResourceRequest_Match_Match() {}
 func (*TapByResourceRequest_Match_Http_) isTapByResourceRequest_Match_Match()        {}
+func (*TapByResourceRequest_Match_Sources) isTapByResourceRequest_Match_Match()      {}
 
 func (m *TapByResourceRequest_Match) GetMatch() isTapByResourceRequest_Match_Match {
 	if m != nil {
@@ -706,6 +712,13 @@ func (m *TapByResourceRequest_Match) GetHttp() *TapByResourceRequest_Match_Http
 	return nil
 }
 
+func (m *TapByResourceRequest_Match) GetSources() *ResourceSelection {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_Sources); ok {
+		return x.Sources
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapByResourceRequest_Match_OneofMarshaler, _TapByResourceRequest_Match_OneofUnmarshaler, _TapByResourceRequest_Match_OneofSizer, []interface{}{
@@ -714,6 +727,7 @@ func (*TapByResourceRequest_Match) XXX_OneofFuncs() (func(msg proto.Message, b *
 		(*TapByResourceRequest_Match_Not)(nil),
 		(*TapByResourceRequest_Match_Destinations)(nil),
 		(*TapByResourceRequest_Match_Http_)(nil),
+		(*TapByResourceRequest_Match_Sources)(nil),
 	}
 }
 
@@ -746,6 +760,11 @@ func _TapByResourceRequest_Match_OneofMarshaler(msg proto.Message, b *proto.Buff
 		if err := b.EncodeMessage(x.Http); err != nil {
 			return err
 		}
+	case *TapByResourceRequest_Match_Sources:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Sources); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TapByResourceRequest_Match.Match has unexpected type %T", x)
@@ -796,6 +815,14 @@ func _TapByResourceRequest_Match_OneofUnmarshaler(msg proto.Message, tag, wire i
 		err := b.DecodeMessage(msg)
 		m.Match = &TapByResourceRequest_Match_Http_{msg}
 		return true, err
+	case 6: // match.sources
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceSelection)
+		err := b.DecodeMessage(msg)
+		m.Match = &TapByResourceRequest_Match_Sources{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -830,6 +857,11 @@ func _TapByResourceRequest_Match_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TapByResourceRequest_Match_Sources:
+		s := proto.Size(x.Sources)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -861,6 +893,7 @@ type TapByResourceRequest_Match_Http struct {
 	//	*TapByResourceRequest_Match_Http_Method
 	//	*TapByResourceRequest_Match_Http_Authority
 	//	*TapByResourceRequest_Match_Http_Path
+	//	*TapByResourceRequest_Match_Http_Header_
 	Match isTapByResourceRequest_Match_Http_Match `protobuf_oneof:"match"`
 }
 
@@ -885,11 +918,15 @@ type TapByResourceRequest_Match_Http_Authority struct {
 type TapByResourceRequest_Match_Http_Path struct {
 	Path string `protobuf:"bytes,4,opt,name=path,oneof"`
 }
+type TapByResourceRequest_Match_Http_Header_ struct {
+	Header *TapByResourceRequest_Match_Http_Header `protobuf:"bytes,5,opt,name=header,oneof"`
+}
 
 func (*TapByResourceRequest_Match_Http_Scheme) isTapByResourceRequest_Match_Http_Match()    {}
 func (*TapByResourceRequest_Match_Http_Method) isTapByResourceRequest_Match_Http_Match()    {}
 func (*TapByResourceRequest_Match_Http_Authority) isTapByResourceRequest_Match_Http_Match() {}
 func (*TapByResourceRequest_Match_Http_Path) isTapByResourceRequest_Match_Http_Match()      {}
+func (*TapByResourceRequest_Match_Http_Header_) isTapByResourceRequest_Match_Http_Match()   {}
 
 func (m *TapByResourceRequest_Match_Http) GetMatch() isTapByResourceRequest_Match_Http_Match {
 	if m != nil {
@@ -926,6 +963,13 @@ func (m *TapByResourceRequest_Match_Http) GetPath() string {
 	return ""
 }
 
+func (m *TapByResourceRequest_Match_Http) GetHeader() *TapByResourceRequest_Match_Http_Header {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_Http_Header_); ok {
+		return x.Header
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapByResourceRequest_Match_Http) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapByResourceRequest_Match_Http_OneofMarshaler, _TapByResourceRequest_Match_Http_OneofUnmarshaler, _TapByResourceRequest_Match_Http_OneofSizer, []interface{}{
@@ -933,6 +977,7 @@ func (*TapByResourceRequest_Match_Http) XXX_OneofFuncs() (func(msg proto.Message
 		(*TapByResourceRequest_Match_Http_Method)(nil),
 		(*TapByResourceRequest_Match_Http_Authority)(nil),
 		(*TapByResourceRequest_Match_Http_Path)(nil),
+		(*TapByResourceRequest_Match_Http_Header_)(nil),
 	}
 }
 
@@ -952,6 +997,11 @@ func _TapByResourceRequest_Match_Http_OneofMarshaler(msg proto.Message, b *proto
 	case *TapByResourceRequest_Match_Http_Path:
 		b.EncodeVarint(4<<3 | proto.WireBytes)
 		b.EncodeStringBytes(x.Path)
+	case *TapByResourceRequest_Match_Http_Header_:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Header); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TapByResourceRequest_Match_Http.Match has unexpected type %T", x)
@@ -990,6 +1040,14 @@ func _TapByResourceRequest_Match_Http_OneofUnmarshaler(msg proto.Message, tag, w
 		x, err := b.DecodeStringBytes()
 		m.Match = &TapByResourceRequest_Match_Http_Path{x}
 		return true, err
+	case 5: // match.header
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TapByResourceRequest_Match_Http_Header)
+		err := b.DecodeMessage(msg)
+		m.Match = &TapByResourceRequest_Match_Http_Header_{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1015,6 +1073,141 @@ func _TapByResourceRequest_Match_Http_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(4<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(len(x.Path)))
 		n += len(x.Path)
+	case *TapByResourceRequest_Match_Http_Header_:
+		s := proto.Size(x.Header)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Matches requests with a header of the given name, any of whose values
+// matches exactly or starts with the given prefix. Header names are
+// case-insensitive.
+type TapByResourceRequest_Match_Http_Header struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Types that are valid to be assigned to Match:
+	//	*TapByResourceRequest_Match_Http_Header_Exact
+	//	*TapByResourceRequest_Match_Http_Header_Prefix
+	Match isTapByResourceRequest_Match_Http_Header_Match `protobuf_oneof:"match"`
+}
+
+func (m *TapByResourceRequest_Match_Http_Header) Reset() {
+	*m = TapByResourceRequest_Match_Http_Header{}
+}
+func (m *TapByResourceRequest_Match_Http_Header) String() string { return proto.CompactTextString(m) }
+func (*TapByResourceRequest_Match_Http_Header) ProtoMessage()    {}
+func (*TapByResourceRequest_Match_Http_Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0, 1, 0}
+}
+
+type isTapByResourceRequest_Match_Http_Header_Match interface {
+	isTapByResourceRequest_Match_Http_Header_Match()
+}
+
+type TapByResourceRequest_Match_Http_Header_Exact struct {
+	Exact string `protobuf:"bytes,2,opt,name=exact,oneof"`
+}
+type TapByResourceRequest_Match_Http_Header_Prefix struct {
+	Prefix string `protobuf:"bytes,3,opt,name=prefix,oneof"`
+}
+
+func (*TapByResourceRequest_Match_Http_Header_Exact) isTapByResourceRequest_Match_Http_Header_Match() {
+}
+func (*TapByResourceRequest_Match_Http_Header_Prefix) isTapByResourceRequest_Match_Http_Header_Match() {
+}
+
+func (m *TapByResourceRequest_Match_Http_Header) GetMatch() isTapByResourceRequest_Match_Http_Header_Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (m *TapByResourceRequest_Match_Http_Header) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TapByResourceRequest_Match_Http_Header) GetExact() string {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_Http_Header_Exact); ok {
+		return x.Exact
+	}
+	return ""
+}
+
+func (m *TapByResourceRequest_Match_Http_Header) GetPrefix() string {
+	if x, ok := m.GetMatch().(*TapByResourceRequest_Match_Http_Header_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*TapByResourceRequest_Match_Http_Header) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _TapByResourceRequest_Match_Http_Header_OneofMarshaler, _TapByResourceRequest_Match_Http_Header_OneofUnmarshaler, _TapByResourceRequest_Match_Http_Header_OneofSizer, []interface{}{
+		(*TapByResourceRequest_Match_Http_Header_Exact)(nil),
+		(*TapByResourceRequest_Match_Http_Header_Prefix)(nil),
+	}
+}
+
+func _TapByResourceRequest_Match_Http_Header_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*TapByResourceRequest_Match_Http_Header)
+	// match
+	switch x := m.Match.(type) {
+	case *TapByResourceRequest_Match_Http_Header_Exact:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Exact)
+	case *TapByResourceRequest_Match_Http_Header_Prefix:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.Prefix)
+	case nil:
+	default:
+		return fmt.Errorf("TapByResourceRequest_Match_Http_Header.Match has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _TapByResourceRequest_Match_Http_Header_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*TapByResourceRequest_Match_Http_Header)
+	switch tag {
+	case 2: // match.exact
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Match = &TapByResourceRequest_Match_Http_Header_Exact{x}
+		return true, err
+	case 3: // match.prefix
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Match = &TapByResourceRequest_Match_Http_Header_Prefix{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _TapByResourceRequest_Match_Http_Header_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*TapByResourceRequest_Match_Http_Header)
+	// match
+	switch x := m.Match.(type) {
+	case *TapByResourceRequest_Match_Http_Header_Exact:
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Exact)))
+		n += len(x.Exact)
+	case *TapByResourceRequest_Match_Http_Header_Prefix:
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.Prefix)))
+		n += len(x.Prefix)
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1821,6 +2014,7 @@ func init() {
 	proto.RegisterType((*TapByResourceRequest_Match)(nil), "conduit.public.TapByResourceRequest.Match")
 	proto.RegisterType((*TapByResourceRequest_Match_Seq)(nil), "conduit.public.TapByResourceRequest.Match.Seq")
 	proto.RegisterType((*TapByResourceRequest_Match_Http)(nil), "conduit.public.TapByResourceRequest.Match.Http")
+	proto.RegisterType((*TapByResourceRequest_Match_Http_Header)(nil), "conduit.public.TapByResourceRequest.Match.Http.Header")
 	proto.RegisterType((*ApiError)(nil), "conduit.public.ApiError")
 	proto.RegisterType((*PodErrors)(nil), "conduit.public.PodErrors")
 	proto.RegisterType((*PodErrors_PodError)(nil), "conduit.public.PodErrors.PodError")
@@ -1856,6 +2050,9 @@ type ApiClient interface {
 	TapByResource(ctx context.Context, in *TapByResourceRequest, opts ...grpc.CallOption) (Api_TapByResourceClient, error)
 	Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionInfo, error)
 	SelfCheck(ctx context.Context, in *conduit_common_healthcheck.SelfCheckRequest, opts ...grpc.CallOption) (*conduit_common_healthcheck.SelfCheckResponse, error)
+	// Streams the updates that the Destination service sends to proxies that
+	// look up the given destination.
+	Endpoints(ctx context.Context, in *conduit_common.Destination, opts ...grpc.CallOption) (Api_EndpointsClient, error)
 }
 
 type apiClient struct {
@@ -1966,6 +2163,38 @@ func (c *apiClient) SelfCheck(ctx context.Context, in *conduit_common_healthchec
 	return out, nil
 }
 
+func (c *apiClient) Endpoints(ctx context.Context, in *conduit_common.Destination, opts ...grpc.CallOption) (Api_EndpointsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Api_serviceDesc.Streams[2], c.cc, "/conduit.public.Api/Endpoints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiEndpointsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Api_EndpointsClient interface {
+	Recv() (*conduit_proxy_destination.Update, error)
+	grpc.ClientStream
+}
+
+type apiEndpointsClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiEndpointsClient) Recv() (*conduit_proxy_destination.Update, error) {
+	m := new(conduit_proxy_destination.Update)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Api service
 
 type ApiServer interface {
@@ -1977,6 +2206,9 @@ type ApiServer interface {
 	TapByResource(*TapByResourceRequest, Api_TapByResourceServer) error
 	Version(context.Context, *Empty) (*VersionInfo, error)
 	SelfCheck(context.Context, *conduit_common_healthcheck.SelfCheckRequest) (*conduit_common_healthcheck.SelfCheckResponse, error)
+	// Streams the updates that the Destination service sends to proxies that
+	// look up the given destination.
+	Endpoints(*conduit_common.Destination, Api_EndpointsServer) error
 }
 
 func RegisterApiServer(s *grpc.Server, srv ApiServer) {
@@ -2097,6 +2329,27 @@ func _Api_SelfCheck_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_Endpoints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(conduit_common.Destination)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServer).Endpoints(m, &apiEndpointsServer{stream})
+}
+
+type Api_EndpointsServer interface {
+	Send(*conduit_proxy_destination.Update) error
+	grpc.ServerStream
+}
+
+type apiEndpointsServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiEndpointsServer) Send(m *conduit_proxy_destination.Update) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Api_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "conduit.public.Api",
 	HandlerType: (*ApiServer)(nil),
@@ -2129,6 +2382,11 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Api_TapByResource_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Endpoints",
+			Handler:       _Api_Endpoints_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "public.proto",
 }
@@ -2136,111 +2394,116 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("public.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1695 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
-	0x11, 0x26, 0xb0, 0x00, 0x09, 0x34, 0x00, 0x8a, 0x1a, 0xc9, 0xaa, 0xd5, 0x26, 0xb1, 0xe8, 0x95,
-	0xa3, 0xb0, 0x94, 0x04, 0xa0, 0xe9, 0xb0, 0x6c, 0xd2, 0x95, 0xd8, 0x02, 0xa5, 0x32, 0x55, 0xb1,
-	0x23, 0x64, 0xc1, 0xca, 0x9f, 0x0f, 0xa8, 0xc1, 0xee, 0x10, 0x58, 0x73, 0x77, 0x66, 0xb5, 0x33,
-	0x2b, 0x06, 0x6f, 0x90, 0x43, 0xaa, 0xf2, 0x1a, 0xa9, 0xe4, 0x92, 0xa7, 0xc8, 0x13, 0xe4, 0xec,
-	0x7b, 0xee, 0xa9, 0x9c, 0x5d, 0xf3, 0xb7, 0xf8, 0x11, 0x28, 0x52, 0x3a, 0x61, 0xfa, 0x9b, 0xaf,
-	0x7b, 0x66, 0xba, 0x7b, 0xba, 0x67, 0x01, 0xed, 0xac, 0x18, 0x27, 0x71, 0xd8, 0xcd, 0x72, 0x26,
-	0x18, 0xda, 0x0e, 0x19, 0x8d, 0x8a, 0x58, 0x74, 0x35, 0xea, 0xbd, 0x3f, 0x61, 0x6c, 0x92, 0x90,
-	0x9e, 0x9a, 0x1d, 0x17, 0xe7, 0xbd, 0xa8, 0xc8, 0xb1, 0x88, 0x19, 0xd5, 0x7c, 0xaf, 0x1d, 0xb2,
-	0x34, 0x2d, 0x25, 0x57, 0x4b, 0xbd, 0x29, 0xc1, 0x89, 0x98, 0x86, 0x53, 0x12, 0x5e, 0xe8, 0x19,
-	0x7f, 0x0b, 0xea, 0xcf, 0xd2, 0x4c, 0xcc, 0xfc, 0x97, 0xd0, 0xfa, 0x1d, 0xc9, 0x79, 0xcc, 0xe8,
-	0x73, 0x7a, 0xce, 0xd0, 0x0f, 0xa1, 0x39, 0x61, 0x06, 0x70, 0x2b, 0xbb, 0x95, 0xbd, 0x66, 0x30,
-	0x07, 0xe4, 0xec, 0xb8, 0x88, 0x93, 0xe8, 0x29, 0x16, 0xc4, 0xad, 0xea, 0xd9, 0x12, 0x40, 0x8f,
-	0x60, 0x3b, 0x27, 0x09, 0xc1, 0x9c, 0x58, 0x03, 0x8e, 0xa2, 0xac, 0xa0, 0x7e, 0x0f, 0x6e, 0x7d,
-	0x15, 0x73, 0x31, 0x60, 0x11, 0x0f, 0xc8, 0xcb, 0x82, 0x70, 0x21, 0x0d, 0x53, 0x9c, 0x12, 0x9e,
-	0xe1, 0x90, 0xd8, 0x65, 0x4b, 0xc0, 0xff, 0x0c, 0x76, 0xe6, 0x0a, 0x3c, 0x63, 0x94, 0x13, 0xf4,
-	0x13, 0xa8, 0x65, 0x2c, 0xe2, 0x6e, 0x65, 0xd7, 0xd9, 0x6b, 0x1d, 0xdc, 0xe9, 0x2e, 0xfb, 0xa9,
-	0x3b, 0x60, 0x51, 0xa0, 0x08, 0xfe, 0x5f, 0x6b, 0xe0, 0x0c, 0x58, 0x84, 0x10, 0xd4, 0xa4, 0x45,
-	0x63, 0x5d, 0x8d, 0xd1, 0x5d, 0xa8, 0x67, 0x2c, 0x7a, 0x3e, 0x30, 0x67, 0xd1, 0x02, 0xda, 0x05,
-	0x88, 0x48, 0x96, 0xb0, 0x59, 0x4a, 0xa8, 0xd0, 0x67, 0x38, 0xdd, 0x08, 0x16, 0x30, 0xf4, 0x01,
-	0xb4, 0x72, 0x92, 0x25, 0x71, 0x88, 0x47, 0x9c, 0x08, 0x17, 0x2c, 0xc5, 0x80, 0x43, 0x22, 0xd0,
-	0x27, 0x70, 0xcf, 0x48, 0x32, 0x3a, 0xa3, 0x90, 0x51, 0x91, 0xb3, 0x24, 0x21, 0xb9, 0xdb, 0x32,
-	0xec, 0xf7, 0x16, 0xe6, 0x4f, 0xca, 0x69, 0xf4, 0x10, 0xda, 0x5c, 0x60, 0x41, 0xce, 0x8b, 0x44,
-	0x19, 0x6f, 0x1b, 0x7a, 0xcb, 0xa2, 0xd2, 0xfa, 0x03, 0x80, 0x08, 0x93, 0x94, 0x51, 0x45, 0xe9,
-	0x18, 0x4a, 0x53, 0x63, 0x92, 0x80, 0xc0, 0xf9, 0x96, 0x8d, 0xdd, 0x6d, 0x33, 0x23, 0x05, 0x74,
-	0x0f, 0x36, 0xa5, 0x8d, 0x82, 0xbb, 0x35, 0x75, 0x5c, 0x23, 0x49, 0x2f, 0xe0, 0x28, 0x22, 0x91,
-	0x5b, 0xdf, 0xad, 0xec, 0x35, 0x02, 0x2d, 0xa0, 0x13, 0xb8, 0xc5, 0x63, 0x1a, 0x92, 0xaf, 0x30,
-	0x17, 0x01, 0xc9, 0x58, 0x2e, 0xdc, 0xcd, 0xdd, 0xca, 0x5e, 0xeb, 0xe0, 0x7e, 0x57, 0xe7, 0x60,
-	0xd7, 0xe6, 0x60, 0xf7, 0xa9, 0xc9, 0xc1, 0x60, 0x55, 0x03, 0xed, 0xc3, 0x9d, 0xf9, 0xc9, 0x7f,
-	0x53, 0x46, 0x78, 0x4b, 0xad, 0xbf, 0x6e, 0x0a, 0xf9, 0xd0, 0x36, 0xf0, 0x20, 0xc1, 0x94, 0xb8,
-	0x0d, 0xb5, 0xa7, 0x25, 0x0c, 0x7d, 0x04, 0x9b, 0x45, 0x26, 0xe2, 0x94, 0xb8, 0xcd, 0xeb, 0x76,
-	0x64, 0x88, 0xfd, 0x2d, 0xa8, 0xb3, 0x4b, 0x4a, 0x72, 0xff, 0x1f, 0x55, 0x80, 0x33, 0x9c, 0xd9,
-	0xc4, 0x43, 0xe0, 0x64, 0x2c, 0xd2, 0x49, 0x21, 0xfd, 0x94, 0xb1, 0x68, 0x25, 0xfe, 0xd5, 0x35,
-	0xf1, 0xbf, 0x07, 0x9b, 0x29, 0xfe, 0x73, 0x90, 0x71, 0x95, 0x1d, 0xd5, 0xc0, 0x48, 0x12, 0x17,
-	0x6c, 0x20, 0x5d, 0x25, 0x3d, 0xdc, 0x09, 0x8c, 0x24, 0x73, 0x4f, 0xb0, 0xe7, 0x03, 0xe5, 0xe0,
-	0x66, 0xa0, 0xc6, 0xc8, 0x83, 0xc6, 0x79, 0xce, 0xd2, 0x81, 0x75, 0x6c, 0x27, 0x28, 0x65, 0x69,
-	0x47, 0x8e, 0x9f, 0x0f, 0x8c, 0xa7, 0x8c, 0xa4, 0x22, 0x18, 0x4e, 0x49, 0xaa, 0xdd, 0x22, 0x23,
-	0xa8, 0x24, 0xb5, 0x1f, 0x22, 0xa6, 0x2c, 0x52, 0x0e, 0x69, 0x06, 0x46, 0x92, 0xd7, 0x0a, 0x17,
-	0x62, 0xca, 0xf2, 0x58, 0xcc, 0x74, 0x96, 0x06, 0x73, 0x40, 0xee, 0x2a, 0xc3, 0x62, 0xaa, 0x13,
-	0x32, 0x50, 0xe3, 0xe3, 0xaa, 0x5b, 0xe9, 0x37, 0x60, 0x53, 0xe0, 0x7c, 0x42, 0x84, 0xff, 0x5d,
-	0x1d, 0xee, 0x9e, 0xe1, 0xac, 0x3f, 0x0b, 0x08, 0x67, 0x45, 0x1e, 0x12, 0xeb, 0xb6, 0x23, 0x4b,
-	0x51, 0x9e, 0x6b, 0x1d, 0x7c, 0xb0, 0x7a, 0xff, 0xac, 0xc2, 0x90, 0x24, 0x24, 0xd4, 0x91, 0xd0,
-	0x0a, 0xe8, 0x0b, 0xa8, 0xa7, 0x58, 0x84, 0x53, 0xe5, 0xd8, 0xd6, 0xc1, 0xe3, 0x55, 0xcd, 0x75,
-	0xeb, 0x75, 0xbf, 0x96, 0x1a, 0x81, 0x56, 0xbc, 0xca, 0xfb, 0xde, 0xbf, 0x6a, 0x50, 0x57, 0x44,
-	0xd4, 0x07, 0x07, 0x27, 0x89, 0xd9, 0x5b, 0xf7, 0xe6, 0x2b, 0x74, 0x87, 0xe4, 0xa5, 0xcc, 0x02,
-	0x9c, 0x24, 0xca, 0x06, 0x9d, 0x99, 0x5d, 0xbe, 0x8b, 0x0d, 0x3a, 0x43, 0xbf, 0x02, 0x87, 0x32,
-	0x5d, 0x42, 0xde, 0xea, 0xa4, 0x52, 0x9f, 0x32, 0x81, 0xbe, 0x84, 0x76, 0x44, 0xb8, 0x88, 0xa9,
-	0x4a, 0x66, 0x7d, 0x6f, 0x6f, 0xe2, 0xec, 0xd3, 0x8d, 0x60, 0x49, 0x11, 0x3d, 0x83, 0xda, 0x54,
-	0x88, 0x4c, 0x25, 0x60, 0xeb, 0xa0, 0xf7, 0x16, 0xa7, 0x39, 0x15, 0x22, 0x3b, 0xdd, 0x08, 0x94,
-	0xba, 0xf7, 0x6b, 0x70, 0x86, 0xe4, 0x25, 0x7a, 0x0a, 0x5b, 0x2a, 0x12, 0xc4, 0x96, 0xdf, 0xb7,
-	0x09, 0xa2, 0x55, 0xf5, 0x66, 0x50, 0x93, 0xc6, 0x91, 0x5b, 0x26, 0xb5, 0xbd, 0x85, 0x36, 0xad,
-	0xdd, 0x32, 0xad, 0xed, 0x25, 0xb4, 0x89, 0xfd, 0xfe, 0x62, 0x62, 0xdb, 0x0a, 0xbd, 0x90, 0xda,
-	0x77, 0x4d, 0x6a, 0xd7, 0xcc, 0x94, 0x92, 0x64, 0x11, 0x50, 0x8b, 0x97, 0x03, 0x7f, 0x17, 0x1a,
-	0x4f, 0xb2, 0xf8, 0x59, 0x9e, 0xb3, 0x5c, 0x96, 0x41, 0x22, 0x07, 0xa6, 0x43, 0x68, 0xc1, 0xff,
-	0x7b, 0x15, 0x9a, 0x03, 0x16, 0x29, 0x0a, 0x47, 0xc7, 0xb0, 0xa9, 0x60, 0x7b, 0x70, 0x7f, 0x4d,
-	0xdf, 0xd1, 0xd4, 0x72, 0x14, 0x18, 0x0d, 0xef, 0xbb, 0x0a, 0x34, 0x2c, 0x88, 0x7e, 0x0b, 0x4d,
-	0x59, 0xd2, 0x70, 0x4c, 0x49, 0x6e, 0xf2, 0xf4, 0xa3, 0xeb, 0x6d, 0x75, 0x4f, 0xac, 0x8e, 0x12,
-	0xe5, 0x99, 0x4b, 0x2b, 0xde, 0x2b, 0xd8, 0x5e, 0x9e, 0x46, 0x2e, 0x6c, 0xa5, 0x84, 0x73, 0x3c,
-	0xb1, 0x5d, 0xcf, 0x8a, 0xb2, 0x30, 0xcc, 0x97, 0x37, 0x8d, 0xbc, 0x04, 0xa4, 0x27, 0xe2, 0x54,
-	0x6a, 0xe9, 0xfe, 0xad, 0x05, 0x79, 0xed, 0x72, 0x82, 0x39, 0xa3, 0xb6, 0x7d, 0x68, 0x49, 0x3a,
-	0x53, 0xbb, 0x6a, 0x00, 0x0d, 0x1b, 0xf2, 0x37, 0x37, 0x74, 0x55, 0x0f, 0x67, 0x99, 0x7d, 0x42,
-	0xa8, 0x71, 0xd9, 0x9f, 0x9d, 0x79, 0x7f, 0xf6, 0x33, 0xb8, 0xfd, 0x5a, 0x6e, 0xa3, 0x5f, 0x40,
-	0x23, 0x37, 0xa0, 0xf1, 0x9c, 0x7b, 0xd5, 0x85, 0x08, 0x4a, 0x26, 0xfa, 0x31, 0x6c, 0x27, 0x78,
-	0x4c, 0x64, 0x4f, 0x95, 0x86, 0x98, 0x3d, 0x76, 0x47, 0xa1, 0x43, 0x03, 0xfa, 0xdf, 0x40, 0xc7,
-	0x2a, 0x6b, 0x1f, 0xbe, 0xdb, 0x6a, 0x65, 0x2e, 0x55, 0x17, 0x73, 0xe9, 0x9f, 0x55, 0x40, 0x43,
-	0x81, 0xc5, 0xb0, 0x48, 0x53, 0x9c, 0xcf, 0x6c, 0x31, 0xfd, 0x25, 0x34, 0xca, 0x4d, 0xdd, 0xb8,
-	0x9c, 0x96, 0x2a, 0xe8, 0x01, 0xb4, 0x64, 0x8b, 0x1b, 0x5d, 0xc6, 0x34, 0x62, 0x97, 0x66, 0x45,
-	0x90, 0xd0, 0xef, 0x15, 0x82, 0x7e, 0x0a, 0x35, 0xca, 0x28, 0x31, 0x65, 0xe8, 0xbd, 0x55, 0xdb,
-	0xea, 0x1d, 0x28, 0xef, 0x88, 0x24, 0xa1, 0xcf, 0xa0, 0x25, 0xd8, 0xa8, 0x3c, 0x72, 0xed, 0xcd,
-	0x47, 0x96, 0x7d, 0x51, 0xb0, 0x32, 0xea, 0x9f, 0x43, 0x47, 0x76, 0xaa, 0xb9, 0x7a, 0xfd, 0x5a,
-	0xf5, 0xb6, 0x54, 0xb0, 0x72, 0x1f, 0xa0, 0xc1, 0x0a, 0x31, 0x66, 0x05, 0x8d, 0xfc, 0xff, 0x54,
-	0xe0, 0xce, 0x92, 0xb7, 0xcc, 0xcb, 0xef, 0x53, 0xa8, 0xb2, 0x0b, 0xe3, 0xa8, 0x47, 0xab, 0x96,
-	0xd7, 0x28, 0x74, 0x5f, 0x5c, 0x9c, 0x6e, 0x04, 0x55, 0x76, 0x81, 0x0e, 0x17, 0xa3, 0xd2, 0x3a,
-	0xf8, 0xd1, 0x55, 0xdb, 0xb2, 0x97, 0x4b, 0xb3, 0xbd, 0x2f, 0xa0, 0xfa, 0xe2, 0x02, 0x1d, 0x83,
-	0x7a, 0x81, 0x8d, 0x04, 0x1e, 0x27, 0x65, 0xe1, 0xbb, 0xbf, 0x6e, 0xfd, 0x33, 0xc9, 0x08, 0x80,
-	0xdb, 0x21, 0x97, 0xc7, 0xca, 0xcd, 0x6e, 0xfc, 0xff, 0x55, 0x00, 0xfa, 0x98, 0xc7, 0xa1, 0xa4,
-	0x72, 0xf4, 0x10, 0x3a, 0xbc, 0x08, 0x43, 0xc2, 0xf9, 0x28, 0x64, 0x05, 0xd5, 0x0d, 0xb5, 0x16,
-	0xb4, 0x0d, 0x78, 0x22, 0x31, 0x49, 0x3a, 0xc7, 0x71, 0x52, 0xe4, 0xc4, 0x90, 0xaa, 0x9a, 0x64,
-	0x40, 0x4d, 0xfa, 0x50, 0x66, 0xb8, 0x20, 0x34, 0x9c, 0x8d, 0x52, 0x3e, 0xca, 0x0e, 0xf7, 0x55,
-	0xc0, 0x6b, 0x41, 0xdb, 0xa0, 0x5f, 0xf3, 0xc1, 0xe1, 0xfe, 0x2a, 0xeb, 0xe8, 0x50, 0x85, 0x78,
-	0x89, 0x75, 0x74, 0xf8, 0x1a, 0xeb, 0x48, 0x45, 0x72, 0x99, 0x75, 0x84, 0x1e, 0xc3, 0x6d, 0x91,
-	0xf0, 0x51, 0xae, 0xf3, 0xd8, 0x6c, 0x6d, 0x53, 0x11, 0x6f, 0x89, 0xc4, 0x3e, 0xee, 0xd5, 0xee,
-	0xfc, 0xff, 0xd6, 0xa0, 0x59, 0x3a, 0x07, 0x3d, 0x81, 0x66, 0xc6, 0xa2, 0xd1, 0x24, 0x67, 0x45,
-	0x66, 0x42, 0xe9, 0x5f, 0xe9, 0x4a, 0x59, 0xfe, 0xbe, 0x94, 0xcc, 0xd3, 0x8d, 0xa0, 0x91, 0x99,
-	0xb1, 0xf7, 0xb7, 0x9a, 0x2a, 0xa7, 0x4a, 0x40, 0xc7, 0x50, 0xcb, 0xd9, 0xa5, 0x8d, 0xca, 0xa3,
-	0xeb, 0x4d, 0x75, 0x03, 0x76, 0x19, 0x28, 0x1d, 0xef, 0xdf, 0x0e, 0x38, 0x01, 0xbb, 0x7c, 0xc7,
-	0x9b, 0x7e, 0xed, 0xed, 0xdb, 0x83, 0x9d, 0x94, 0xf0, 0x29, 0x89, 0x46, 0xf2, 0xc4, 0xda, 0x47,
-	0x3a, 0x30, 0xdb, 0x1a, 0x1f, 0xb0, 0x48, 0x07, 0xf0, 0x31, 0xdc, 0xce, 0x0b, 0x4a, 0x63, 0x3a,
-	0x59, 0xa0, 0xea, 0xe8, 0xdc, 0x32, 0x13, 0x25, 0x77, 0x0f, 0x76, 0x64, 0xf0, 0x97, 0xac, 0x6a,
-	0xcf, 0x6f, 0x6b, 0xbc, 0x64, 0xee, 0x43, 0x5d, 0x66, 0x22, 0x37, 0x77, 0xd1, 0x5b, 0x3d, 0xd3,
-	0x3c, 0x17, 0x03, 0x4d, 0x44, 0xdf, 0x40, 0x47, 0xb7, 0xac, 0xd1, 0x78, 0x26, 0xcd, 0xbb, 0x5b,
-	0xca, 0xab, 0x9f, 0xdc, 0xcc, 0xab, 0x5d, 0xdd, 0xb3, 0xfa, 0x33, 0xd9, 0xb4, 0xa8, 0xc8, 0x67,
-	0x41, 0x8b, 0xcc, 0x11, 0xef, 0x8f, 0xb0, 0xb3, 0x4a, 0x40, 0x3b, 0xe0, 0x5c, 0x90, 0x99, 0x69,
-	0x13, 0x72, 0x88, 0x7a, 0x50, 0x7f, 0x85, 0x93, 0x82, 0x98, 0x9b, 0x7a, 0xff, 0xca, 0xd6, 0x18,
-	0x68, 0xde, 0x71, 0xf5, 0xd3, 0x8a, 0x6c, 0x44, 0xea, 0x72, 0x1e, 0xfc, 0xdf, 0x01, 0xe7, 0x49,
-	0x16, 0xa3, 0x3f, 0x40, 0x6b, 0xa1, 0x1e, 0x20, 0xff, 0x8d, 0xc5, 0x42, 0xe5, 0xaa, 0xf7, 0xf0,
-	0x06, 0x05, 0xc5, 0xdf, 0x40, 0x2f, 0xa0, 0x61, 0xbf, 0x48, 0xd1, 0x83, 0x55, 0x95, 0x95, 0x8f,
-	0x5b, 0x6f, 0xf7, 0x6a, 0x42, 0x69, 0xb0, 0x0f, 0xce, 0x19, 0xce, 0x90, 0xb7, 0xe6, 0x21, 0x65,
-	0xcd, 0xcc, 0xb3, 0xd1, 0x7c, 0xe3, 0x9f, 0xe1, 0xec, 0xd9, 0x2b, 0x42, 0x85, 0xef, 0xfc, 0xa5,
-	0x5a, 0xd9, 0xaf, 0xa0, 0x21, 0x74, 0x96, 0xde, 0x5d, 0xe8, 0xc3, 0x9b, 0x3c, 0xcb, 0xde, 0x60,
-	0x77, 0x63, 0xbf, 0x82, 0x3e, 0x87, 0x2d, 0xfb, 0xf5, 0xbf, 0xbe, 0x73, 0x78, 0x3f, 0x58, 0x85,
-	0x17, 0xfe, 0x4f, 0xf0, 0x37, 0xd0, 0xb7, 0xd0, 0x1c, 0x92, 0xe4, 0xfc, 0x64, 0x4a, 0xc2, 0x0b,
-	0xf4, 0xb3, 0xd5, 0xb5, 0x16, 0xff, 0x99, 0x28, 0x69, 0x76, 0x67, 0x3f, 0xbf, 0x21, 0xdb, 0x7a,
-	0xb1, 0x7f, 0xf8, 0xa7, 0x8f, 0x27, 0xb1, 0x98, 0x16, 0x63, 0xa9, 0xd0, 0xcb, 0x0b, 0x6a, 0xf4,
-	0x7b, 0x0b, 0xbf, 0xe6, 0x8b, 0xb3, 0x37, 0x21, 0xb4, 0xa7, 0x37, 0x3c, 0xde, 0x54, 0xdf, 0x8d,
-	0x1f, 0x7f, 0x1f, 0x00, 0x00, 0xff, 0xff, 0xa8, 0xcb, 0x03, 0xf2, 0x7b, 0x11, 0x00, 0x00,
+	// 1774 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x58, 0x5b, 0x77, 0x13, 0x55,
+	0x14, 0x26, 0xd7, 0x26, 0x3b, 0x6d, 0x81, 0xc3, 0xc5, 0x30, 0x28, 0x97, 0x01, 0x91, 0x85, 0x9a,
+	0x94, 0x62, 0xd5, 0xc2, 0x52, 0xa1, 0xa5, 0x0b, 0x58, 0x82, 0xd4, 0x29, 0x8a, 0x97, 0x87, 0xac,
+	0x49, 0x72, 0x9a, 0x0c, 0x9d, 0xcc, 0x19, 0xe6, 0x42, 0xc9, 0x3f, 0xf0, 0xc1, 0xb5, 0xfc, 0x01,
+	0xbe, 0xfa, 0xe0, 0xd2, 0xff, 0xa1, 0xcb, 0x77, 0x9f, 0x7d, 0xf7, 0xdd, 0x1f, 0xe0, 0xde, 0xe7,
+	0x32, 0xb9, 0x90, 0xd2, 0xc2, 0x53, 0xce, 0xde, 0xe7, 0xdb, 0xfb, 0x9c, 0xb3, 0xef, 0x13, 0x98,
+	0x0f, 0xd3, 0xb6, 0xef, 0x75, 0x1a, 0x61, 0x24, 0x12, 0xc1, 0x16, 0x3b, 0x22, 0xe8, 0xa6, 0x5e,
+	0xd2, 0x50, 0x5c, 0xeb, 0x4c, 0x4f, 0x88, 0x9e, 0xcf, 0x9b, 0x72, 0xb7, 0x9d, 0x6e, 0x37, 0xbb,
+	0x69, 0xe4, 0x26, 0x9e, 0x08, 0x14, 0xde, 0x9a, 0xef, 0x88, 0xc1, 0x20, 0xa3, 0xea, 0x8a, 0x6a,
+	0xf6, 0xb9, 0xeb, 0x27, 0xfd, 0x4e, 0x9f, 0x77, 0x76, 0xf4, 0xce, 0x1b, 0xf8, 0xf3, 0x7c, 0xd8,
+	0xec, 0xf2, 0x38, 0xf1, 0x82, 0x31, 0x05, 0xf6, 0x1c, 0x94, 0x36, 0x06, 0x61, 0x32, 0xb4, 0x9f,
+	0x42, 0xed, 0x6b, 0x1e, 0xc5, 0xb8, 0x73, 0x2f, 0xd8, 0x16, 0xec, 0x4d, 0xa8, 0xf6, 0x84, 0x66,
+	0xd4, 0x73, 0xe7, 0x72, 0x97, 0xab, 0xce, 0x88, 0x41, 0xbb, 0xed, 0xd4, 0xf3, 0xbb, 0xb7, 0xdd,
+	0x84, 0xd7, 0xf3, 0x6a, 0x37, 0x63, 0xb0, 0x4b, 0xb0, 0x18, 0x71, 0x9f, 0xbb, 0x31, 0x37, 0x0a,
+	0x0a, 0x12, 0x32, 0xc5, 0xb5, 0x9b, 0x70, 0xf8, 0xbe, 0x17, 0x27, 0x9b, 0xa2, 0x1b, 0x3b, 0xfc,
+	0x69, 0x8a, 0x77, 0x23, 0xc5, 0x81, 0x3b, 0xe0, 0x71, 0xe8, 0x76, 0xb8, 0x39, 0x36, 0x63, 0xd8,
+	0x37, 0xe0, 0xc8, 0x48, 0x20, 0x0e, 0x45, 0x10, 0x73, 0xf6, 0x0e, 0x14, 0x43, 0xa4, 0x11, 0x5c,
+	0xb8, 0x5c, 0x5b, 0x3e, 0xd6, 0x98, 0x34, 0x60, 0x03, 0xb1, 0x8e, 0x04, 0xd8, 0x3f, 0x16, 0xa1,
+	0x80, 0x14, 0x63, 0x50, 0x24, 0x8d, 0x5a, 0xbb, 0x5c, 0xb3, 0xe3, 0x50, 0x42, 0xcc, 0xbd, 0x4d,
+	0xfd, 0x16, 0x45, 0xb0, 0x73, 0x00, 0x5d, 0x1e, 0xfa, 0x62, 0x38, 0xe0, 0x41, 0xa2, 0xde, 0x70,
+	0xf7, 0x90, 0x33, 0xc6, 0x63, 0xe7, 0xa1, 0x16, 0x21, 0xe5, 0x75, 0xdc, 0x56, 0xcc, 0x93, 0x3a,
+	0x18, 0x88, 0x66, 0x6e, 0xf1, 0x84, 0x7d, 0x04, 0x27, 0x35, 0x45, 0x56, 0x6f, 0xe1, 0xf5, 0x92,
+	0x48, 0xf8, 0x3e, 0x8f, 0xea, 0x35, 0x8d, 0x3e, 0x31, 0xb6, 0xbf, 0x9e, 0x6d, 0xb3, 0x0b, 0x30,
+	0x1f, 0x27, 0x68, 0xce, 0xed, 0xd4, 0x97, 0xca, 0xe7, 0x35, 0xbc, 0x66, 0xb8, 0xa4, 0xfd, 0x2c,
+	0x5e, 0xd1, 0xe5, 0xe8, 0x73, 0x09, 0x59, 0xd0, 0x90, 0xaa, 0xe2, 0x11, 0x80, 0x41, 0xe1, 0x89,
+	0x68, 0xd7, 0x17, 0xf5, 0x0e, 0x11, 0xec, 0x24, 0x94, 0x49, 0x47, 0x1a, 0xd7, 0x8b, 0xf2, 0xb9,
+	0x9a, 0x22, 0x2b, 0xb8, 0xdd, 0x2e, 0xef, 0xd6, 0x4b, 0xc8, 0xae, 0x38, 0x8a, 0x60, 0xeb, 0x70,
+	0x38, 0xf6, 0x82, 0x0e, 0xbf, 0xef, 0xc6, 0x89, 0xc3, 0x43, 0x11, 0x25, 0xf5, 0x32, 0xee, 0xd7,
+	0x96, 0x4f, 0x35, 0x54, 0x70, 0x36, 0x4c, 0x70, 0x36, 0x6e, 0xeb, 0xe0, 0x74, 0xa6, 0x25, 0xd8,
+	0x12, 0x1c, 0x1b, 0xbd, 0xfc, 0x8b, 0xcc, 0xc3, 0x73, 0xf2, 0xfc, 0x59, 0x5b, 0xcc, 0x86, 0x79,
+	0xcd, 0xde, 0xf4, 0xdd, 0x80, 0xd7, 0x2b, 0xf2, 0x4e, 0x13, 0x3c, 0x76, 0x15, 0xca, 0x69, 0x98,
+	0x78, 0xe8, 0xcc, 0xea, 0x7e, 0x37, 0xd2, 0xc0, 0x35, 0x8c, 0x77, 0xb1, 0x1b, 0xf0, 0xc8, 0xfe,
+	0x2d, 0x0f, 0xf0, 0xc8, 0x0d, 0x4d, 0xe0, 0xa1, 0x9d, 0xd0, 0xe9, 0x2a, 0x28, 0xc8, 0x4e, 0x48,
+	0x4c, 0xf9, 0x3f, 0x3f, 0xc3, 0xff, 0x68, 0xc9, 0x81, 0xfb, 0xdc, 0x09, 0x63, 0x19, 0x1d, 0x79,
+	0x47, 0x53, 0xc4, 0x4f, 0xc4, 0x26, 0x99, 0x8a, 0x2c, 0xbc, 0xe0, 0x68, 0x8a, 0x62, 0x2f, 0x11,
+	0x18, 0x66, 0x25, 0x15, 0x7b, 0xb4, 0x66, 0x16, 0x54, 0xb6, 0x23, 0x31, 0xd8, 0x34, 0x86, 0x5d,
+	0x70, 0x32, 0x9a, 0xf4, 0xd0, 0x1a, 0x25, 0x94, 0xa5, 0x34, 0x25, 0x3d, 0x88, 0xe9, 0x3d, 0x50,
+	0x66, 0x21, 0x0f, 0x4a, 0x4a, 0xde, 0x87, 0x27, 0x7d, 0x7c, 0x48, 0x55, 0xf1, 0x15, 0x45, 0x69,
+	0xe5, 0xa6, 0xb8, 0x8a, 0xbc, 0x64, 0xa8, 0xa2, 0xd4, 0x19, 0x31, 0xe8, 0x56, 0xa1, 0x9b, 0xf4,
+	0x55, 0x40, 0x3a, 0x72, 0x7d, 0x3d, 0x5f, 0xcf, 0xad, 0x55, 0xf0, 0x15, 0x6e, 0xd4, 0xe3, 0x89,
+	0xfd, 0xd7, 0x1c, 0x1c, 0x47, 0x63, 0xad, 0x0d, 0x31, 0xed, 0x44, 0x1a, 0x75, 0xb8, 0x31, 0xdb,
+	0xaa, 0x81, 0x48, 0xcb, 0xd5, 0x96, 0xcf, 0x4f, 0xe7, 0x9f, 0x11, 0xd8, 0xc2, 0xd4, 0xef, 0x28,
+	0x4f, 0x28, 0x01, 0x76, 0x13, 0x4a, 0x03, 0x37, 0xe9, 0xf4, 0xa5, 0x61, 0x6b, 0xcb, 0x57, 0xa6,
+	0x25, 0x67, 0x9d, 0xd7, 0x78, 0x40, 0x12, 0x8e, 0x12, 0xdc, 0xcb, 0xfa, 0xd6, 0xcf, 0x65, 0x28,
+	0x49, 0x20, 0x5b, 0x83, 0x82, 0xeb, 0xfb, 0xfa, 0x6e, 0x8d, 0x83, 0x9f, 0xd0, 0xd8, 0xe2, 0x4f,
+	0x29, 0x0a, 0x50, 0x58, 0xea, 0x08, 0x86, 0xfa, 0x96, 0xaf, 0xa3, 0x23, 0x18, 0xb2, 0x4f, 0xa1,
+	0x10, 0x08, 0x55, 0x42, 0x5e, 0xe9, 0xa5, 0x24, 0x8f, 0x82, 0xec, 0x0e, 0xcc, 0x8f, 0x95, 0x6e,
+	0x95, 0xb7, 0x07, 0x31, 0x36, 0xca, 0x4f, 0x08, 0xb2, 0x0d, 0x28, 0xf6, 0x93, 0x24, 0x94, 0x01,
+	0x58, 0x5b, 0x6e, 0xbe, 0xc2, 0x6b, 0xee, 0xa2, 0x18, 0xaa, 0x93, 0xe2, 0xec, 0x13, 0x98, 0x53,
+	0x98, 0x58, 0xd7, 0x82, 0x03, 0x5d, 0xc5, 0xc8, 0x58, 0x9f, 0x43, 0x01, 0x8d, 0xc3, 0x6e, 0xc3,
+	0x9c, 0x74, 0x24, 0x37, 0xd5, 0xfb, 0x55, 0x62, 0xc0, 0x88, 0x5a, 0xbf, 0xe4, 0xa1, 0x48, 0x97,
+	0x63, 0xf5, 0x2c, 0x29, 0x4c, 0x16, 0x9b, 0xb4, 0xa8, 0x67, 0x69, 0x61, 0x92, 0xd8, 0x24, 0xc6,
+	0x99, 0xf1, 0xc4, 0x30, 0x15, 0x7e, 0x2c, 0x35, 0x8e, 0xeb, 0xd4, 0x28, 0xea, 0x2d, 0x49, 0xb1,
+	0x4d, 0x28, 0x63, 0x8b, 0xed, 0x62, 0x0d, 0x57, 0x76, 0xfc, 0xf0, 0x15, 0xed, 0xd8, 0xb8, 0x2b,
+	0xa5, 0xe9, 0x1e, 0x4a, 0x8f, 0xf5, 0x18, 0xca, 0x8a, 0x37, 0xb3, 0x3d, 0x9d, 0x84, 0x12, 0x7f,
+	0xee, 0x76, 0x46, 0x35, 0x48, 0x91, 0xf4, 0xae, 0x30, 0xe2, 0xdb, 0xde, 0xf3, 0xec, 0xea, 0x9a,
+	0xa6, 0x32, 0x27, 0xed, 0xf3, 0xe2, 0xc2, 0x3e, 0x07, 0x95, 0x5b, 0xa1, 0xb7, 0x11, 0x45, 0x22,
+	0xa2, 0x8a, 0xcf, 0x69, 0xa1, 0x4f, 0x53, 0x84, 0xfd, 0x6b, 0x1e, 0xaa, 0xd8, 0x29, 0x25, 0x24,
+	0x66, 0xd7, 0xa1, 0x2c, 0xd9, 0xc6, 0x49, 0xf6, 0x8c, 0x16, 0xab, 0xa0, 0xd9, 0xca, 0xd1, 0x12,
+	0xd6, 0x3f, 0x39, 0xa8, 0x18, 0x26, 0xfb, 0x12, 0xaa, 0x54, 0xbd, 0x5d, 0x0f, 0xcb, 0xaf, 0x4e,
+	0xc9, 0xab, 0xfb, 0xeb, 0x6a, 0xac, 0x1b, 0x19, 0x49, 0x92, 0x7b, 0x32, 0x2d, 0xd6, 0x33, 0x58,
+	0x9c, 0xdc, 0x46, 0x93, 0xcc, 0x61, 0x07, 0x89, 0xdd, 0x9e, 0xb1, 0xa0, 0x21, 0xa9, 0x06, 0x8e,
+	0x8e, 0xd7, 0x33, 0x4b, 0xc6, 0x20, 0x4b, 0x78, 0x03, 0x92, 0x52, 0xa3, 0x8a, 0x22, 0xa8, 0xc2,
+	0x44, 0x38, 0xb1, 0xe0, 0x04, 0xa3, 0x3b, 0xa5, 0xa2, 0xc8, 0x98, 0xca, 0x54, 0x9b, 0x50, 0x31,
+	0x6e, 0x7e, 0xf9, 0xec, 0x22, 0x4b, 0xff, 0x30, 0x34, 0xd3, 0x92, 0x5c, 0x67, 0xbe, 0x2e, 0x8c,
+	0x7c, 0x6d, 0x87, 0x70, 0xf4, 0x85, 0xdc, 0x61, 0x1f, 0x40, 0x25, 0xd2, 0x4c, 0x6d, 0xb9, 0xfa,
+	0x5e, 0x09, 0xe7, 0x64, 0x48, 0xf6, 0x36, 0x2c, 0xfa, 0x6e, 0x9b, 0xd3, 0xf8, 0x40, 0x8a, 0x84,
+	0x79, 0xf6, 0x82, 0xe4, 0x6e, 0x69, 0xa6, 0xfd, 0x3d, 0x2c, 0x18, 0x61, 0x65, 0xc3, 0xd7, 0x3b,
+	0x2d, 0x8b, 0xa5, 0xfc, 0x78, 0x2c, 0xfd, 0x9e, 0x07, 0xb6, 0x85, 0xe3, 0xc5, 0x56, 0x3a, 0x18,
+	0xb8, 0xd1, 0xd0, 0xf4, 0x8d, 0x4f, 0xa0, 0x92, 0x5d, 0xea, 0xc0, 0x9d, 0x23, 0x13, 0xc1, 0xb1,
+	0xa7, 0x46, 0xdd, 0xbc, 0xb5, 0xeb, 0x05, 0x5d, 0xb1, 0xab, 0x4f, 0x04, 0x62, 0x3d, 0x96, 0x1c,
+	0xf6, 0x2e, 0x5a, 0x56, 0x04, 0x5c, 0x57, 0xdc, 0x13, 0xd3, 0xba, 0xe5, 0xc8, 0x4b, 0xe9, 0x4c,
+	0x20, 0x76, 0x03, 0xb5, 0x89, 0x56, 0xf6, 0xe4, 0xe2, 0xcb, 0x9f, 0x4c, 0x23, 0x40, 0x22, 0x32,
+	0xaf, 0x7f, 0x06, 0x0b, 0xd4, 0x94, 0x47, 0xe2, 0xa5, 0x7d, 0xc5, 0xe7, 0x49, 0xc0, 0xd0, 0x6b,
+	0x00, 0x15, 0x91, 0x26, 0x6d, 0x91, 0x06, 0x5d, 0xfb, 0xef, 0x1c, 0x1c, 0x9b, 0xb0, 0x96, 0x1e,
+	0x72, 0x3f, 0x86, 0xbc, 0xd8, 0xd1, 0x86, 0xba, 0x34, 0xad, 0x79, 0x86, 0x40, 0xe3, 0xe1, 0x0e,
+	0x9e, 0x83, 0x32, 0x6c, 0x65, 0xdc, 0x2b, 0xb5, 0xe5, 0xb7, 0xf6, 0xba, 0x96, 0x49, 0x2e, 0x85,
+	0xb6, 0x6e, 0x42, 0xfe, 0xe1, 0x0e, 0xa6, 0xbe, 0x1c, 0x36, 0x5b, 0x89, 0xdb, 0xf6, 0xb3, 0x22,
+	0x7d, 0x6a, 0xd6, 0xf9, 0x8f, 0x08, 0xe1, 0x40, 0x6c, 0x96, 0x31, 0x3d, 0x2b, 0xd2, 0xb7, 0xb1,
+	0xff, 0xcb, 0x01, 0xac, 0xb9, 0xb1, 0xd7, 0x21, 0x68, 0x8c, 0x93, 0xed, 0x42, 0x9c, 0x76, 0xb0,
+	0x11, 0xc4, 0x38, 0x0e, 0xa7, 0x81, 0x9a, 0x1d, 0x8a, 0xce, 0xbc, 0x66, 0xae, 0x13, 0x8f, 0x40,
+	0xdb, 0xae, 0xe7, 0xa7, 0x11, 0xd7, 0xa0, 0xbc, 0x02, 0x69, 0xa6, 0x02, 0x5d, 0xa4, 0x08, 0x4f,
+	0x78, 0xd0, 0x19, 0xb6, 0x06, 0x71, 0x2b, 0x5c, 0x59, 0x92, 0x0e, 0x47, 0x94, 0xe6, 0x3e, 0x88,
+	0x37, 0x57, 0x96, 0xa6, 0x51, 0xab, 0x2b, 0xd2, 0xc5, 0x13, 0xa8, 0xd5, 0x95, 0x17, 0x50, 0xab,
+	0xd2, 0x93, 0x93, 0xa8, 0x55, 0x76, 0x05, 0x8e, 0x26, 0x7e, 0x8c, 0xde, 0x96, 0x71, 0xac, 0xaf,
+	0x56, 0x96, 0xc0, 0xc3, 0xb8, 0xa1, 0xe3, 0x5b, 0xde, 0xce, 0xfe, 0xb7, 0x08, 0xd5, 0xcc, 0x38,
+	0xec, 0x16, 0x54, 0x71, 0xa8, 0x6c, 0xf5, 0x22, 0x91, 0x86, 0xda, 0x95, 0xf6, 0x9e, 0xa6, 0xa4,
+	0xf2, 0x77, 0x87, 0x90, 0xe8, 0x92, 0x4a, 0xa8, 0xd7, 0xd6, 0x4f, 0x45, 0x59, 0x4e, 0x25, 0x81,
+	0xce, 0x29, 0x46, 0x62, 0xd7, 0x78, 0xe5, 0xd2, 0xfe, 0xaa, 0x1a, 0x8e, 0xd8, 0x75, 0xa4, 0x8c,
+	0xf5, 0x47, 0x01, 0x0a, 0x48, 0xbd, 0x66, 0xa6, 0xef, 0x9b, 0x7d, 0x97, 0xe1, 0x08, 0x96, 0xbd,
+	0x3e, 0xef, 0xb6, 0xe8, 0xc5, 0xca, 0x46, 0xca, 0x31, 0x8b, 0x8a, 0x8f, 0x57, 0x52, 0x0e, 0x44,
+	0x73, 0x46, 0x69, 0x10, 0x78, 0x41, 0x6f, 0x0c, 0xaa, 0xbc, 0x73, 0x58, 0x6f, 0x64, 0x58, 0xd4,
+	0x4a, 0xce, 0x9f, 0xd0, 0xaa, 0x2c, 0xbf, 0xa8, 0xf8, 0x19, 0x72, 0x09, 0x4a, 0x14, 0x89, 0xb1,
+	0xce, 0x45, 0x6b, 0xfa, 0x4d, 0xa3, 0x58, 0x74, 0x14, 0x90, 0x61, 0x0d, 0x54, 0x2d, 0xab, 0xd5,
+	0x1e, 0x92, 0x7a, 0x9c, 0xb7, 0xc9, 0xaa, 0x1f, 0x1d, 0xcc, 0xaa, 0x0d, 0xd5, 0xb3, 0xd6, 0x86,
+	0xd4, 0xb4, 0xf0, 0xfb, 0x64, 0xe8, 0xd4, 0xf8, 0x88, 0x63, 0x7d, 0x0b, 0x47, 0xa6, 0x01, 0xec,
+	0x08, 0x14, 0x76, 0xf8, 0x50, 0xb7, 0x09, 0x5a, 0xb2, 0x26, 0x94, 0x9e, 0xb9, 0x7e, 0xca, 0x75,
+	0xa6, 0x9e, 0xda, 0xb3, 0x35, 0x3a, 0x0a, 0x77, 0x3d, 0xff, 0x71, 0x8e, 0x1a, 0x91, 0x4c, 0xce,
+	0xe5, 0x3f, 0xf1, 0xeb, 0x16, 0xdb, 0x3a, 0xfb, 0x06, 0x6a, 0x63, 0xf5, 0x80, 0xd9, 0x2f, 0x2d,
+	0x16, 0x32, 0x56, 0xad, 0x0b, 0x07, 0x28, 0x28, 0xf6, 0x21, 0xf6, 0x10, 0x2a, 0xe6, 0xe3, 0x9b,
+	0x9d, 0x9d, 0x16, 0x99, 0xfa, 0x8e, 0xb7, 0xce, 0xed, 0x0d, 0xc8, 0x14, 0xe2, 0x60, 0x8d, 0x73,
+	0x12, 0xb3, 0x66, 0x0c, 0x4f, 0x46, 0xcd, 0x28, 0x1a, 0xf5, 0xff, 0x1c, 0xb8, 0xb7, 0xf1, 0x0c,
+	0xbf, 0xbc, 0xec, 0xc2, 0x0f, 0xf9, 0xdc, 0x52, 0x8e, 0x6d, 0xc1, 0xc2, 0xc4, 0xac, 0xc5, 0x2e,
+	0x1e, 0x64, 0x14, 0x7b, 0x89, 0xde, 0x43, 0xa8, 0xf4, 0x33, 0x98, 0x33, 0x7f, 0x74, 0xcc, 0xee,
+	0x1c, 0xd6, 0xe9, 0x69, 0xf6, 0xd8, 0x5f, 0x27, 0xf8, 0xb2, 0x27, 0x98, 0xf7, 0xdc, 0xdf, 0x5e,
+	0xa7, 0x3f, 0x60, 0xd8, 0x7b, 0xd3, 0x67, 0x8d, 0xff, 0x3b, 0x93, 0xc1, 0xcc, 0xcd, 0xde, 0x3f,
+	0x20, 0x7a, 0xcc, 0x2d, 0xd5, 0x8d, 0xa0, 0x1b, 0x0a, 0x2f, 0xc0, 0x30, 0x3e, 0x3d, 0x2d, 0x7d,
+	0x7b, 0x34, 0xfc, 0x5b, 0x63, 0x1d, 0x96, 0xfe, 0x0c, 0x6a, 0x8c, 0xff, 0x19, 0xf4, 0x55, 0xd8,
+	0xc5, 0x2a, 0x47, 0xaf, 0x5f, 0x5b, 0xf9, 0xee, 0x5a, 0xcf, 0x4b, 0xfa, 0x69, 0x9b, 0x74, 0x34,
+	0x31, 0x09, 0xb5, 0x54, 0x73, 0xec, 0x57, 0x7f, 0xad, 0x37, 0x7b, 0x3c, 0x68, 0x2a, 0x0b, 0xb4,
+	0xcb, 0xf2, 0x9b, 0xfb, 0xda, 0xff, 0x5a, 0x0c, 0x82, 0x57, 0xd0, 0x12, 0x00, 0x00,
 }
@@ -28,6 +28,7 @@ const (
 	Deploy
 	Endpoint
 	NS
+	Node
 	Pod
 	RC
 	RS
@@ -42,6 +43,7 @@ type API struct {
 	deploy   appinformers.DeploymentInformer
 	endpoint coreinformers.EndpointsInformer
 	ns       coreinformers.NamespaceInformer
+	node     coreinformers.NodeInformer
 	pod      coreinformers.PodInformer
 	rc       coreinformers.ReplicationControllerInformer
 	rs       appinformers.ReplicaSetInformer
@@ -75,6 +77,9 @@ func NewAPI(k8sClient kubernetes.Interface, resources ...ApiResource) *API {
 		case NS:
 			api.ns = sharedInformers.Core().V1().Namespaces()
 			api.syncChecks = append(api.syncChecks, api.ns.Informer().HasSynced)
+		case Node:
+			api.node = sharedInformers.Core().V1().Nodes()
+			api.syncChecks = append(api.syncChecks, api.node.Informer().HasSynced)
 		case Pod:
 			api.pod = sharedInformers.Core().V1().Pods()
 			api.syncChecks = append(api.syncChecks, api.pod.Informer().HasSynced)
@@ -113,6 +118,39 @@ func (api *API) Sync(readyCh chan<- struct{}) {
 	}
 }
 
+// SyncWithRetry is like Sync, but rather than exiting if the informers don't
+// sync within 60s, it logs a warning and keeps waiting. It's meant for the
+// APIs of other clusters, which may not be reachable for a while.
+func (api *API) SyncWithRetry(readyCh chan<- struct{}) {
+	api.sharedInformers.Start(nil)
+
+	log.Infof("waiting for caches to sync")
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		synced := cache.WaitForCacheSync(ctx.Done(), api.syncChecks...)
+		cancel()
+		if synced {
+			break
+		}
+		log.Warnf("caches not synced yet, still waiting")
+	}
+	log.Infof("caches synced")
+
+	if readyCh != nil {
+		close(readyCh)
+	}
+}
+
+// HasSynced returns true if all informers have synced.
+func (api *API) HasSynced() bool {
+	for _, synced := range api.syncChecks {
+		if !synced() {
+			return false
+		}
+	}
+	return true
+}
+
 func (api *API) NS() coreinformers.NamespaceInformer {
 	if api.ns == nil {
 		panic("NS informer not configured")
@@ -134,6 +172,13 @@ func (api *API) RS() appinformers.ReplicaSetInformer {
 	return api.rs
 }
 
+func (api *API) Node() coreinformers.NodeInformer {
+	if api.node == nil {
+		panic("Node informer not configured")
+	}
+	return api.node
+}
+
 func (api *API) Pod() coreinformers.PodInformer {
 	if api.pod == nil {
 		panic("Pod informer not configured")
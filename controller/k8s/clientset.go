@@ -9,20 +9,21 @@ import (
 )
 
 func NewClientSet(kubeConfig string) (*kubernetes.Clientset, error) {
-	var config *rest.Config
-	var err error
+	config, err := getConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
 
+func getConfig(kubeConfig string) (*rest.Config, error) {
 	if kubeConfig == "" {
 		// configure client while running inside the k8s cluster
 		// uses Service Acct token mounted in the Pod
-		config, err = rest.InClusterConfig()
-	} else {
-		// configure access to the cluster from outside
-		config, err = clientcmd.BuildConfigFromFlags("", kubeConfig)
-	}
-	if err != nil {
-		return nil, err
+		return rest.InClusterConfig()
 	}
 
-	return kubernetes.NewForConfig(config)
+	// configure access to the cluster from outside
+	return clientcmd.BuildConfigFromFlags("", kubeConfig)
 }
@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// ServiceProfileResource is the plural name of the ServiceProfile custom
+	// resource.
+	ServiceProfileResource = "serviceprofiles"
+
+	serviceProfileResyncPeriod = 10 * time.Minute
+)
+
+// ServiceProfileGroupVersion is the API group and version of the
+// ServiceProfile custom resource.
+var ServiceProfileGroupVersion = schema.GroupVersion{Group: "conduit.io", Version: "v1alpha1"}
+
+// ServiceProfile describes the routes of the service with the same name and
+// namespace, and how the proxies should handle the requests for each of them.
+type ServiceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceProfileSpec `json:"spec"`
+}
+
+// ServiceProfileSpec lists the routes of a service. A request is handled
+// according to the first route that it matches.
+type ServiceProfileSpec struct {
+	Routes []RouteSpec `json:"routes,omitempty"`
+}
+
+// RouteSpec describes the requests that belong to a route, and how they are
+// handled.
+type RouteSpec struct {
+	Name string `json:"name"`
+	// The HTTP method of the requests of the route; any method if empty.
+	Method string `json:"method,omitempty"`
+	// A regular expression that must match the entire path of the requests of
+	// the route.
+	PathRegex string `json:"pathRegex"`
+	// How long to wait for responses, e.g. "2s"; no timeout if empty.
+	Timeout string `json:"timeout,omitempty"`
+	// Whether the requests of the route may be retried.
+	IsRetryable bool `json:"isRetryable,omitempty"`
+}
+
+// ServiceProfileList is a list of ServiceProfiles.
+type ServiceProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceProfile `json:"items"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (sp *ServiceProfile) DeepCopyObject() runtime.Object {
+	return sp.DeepCopy()
+}
+
+// DeepCopy returns a copy of the ServiceProfile that shares no state with it.
+func (sp *ServiceProfile) DeepCopy() *ServiceProfile {
+	if sp == nil {
+		return nil
+	}
+	out := &ServiceProfile{
+		TypeMeta: sp.TypeMeta,
+		Spec:     ServiceProfileSpec{},
+	}
+	sp.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if sp.Spec.Routes != nil {
+		out.Spec.Routes = make([]RouteSpec, len(sp.Spec.Routes))
+		copy(out.Spec.Routes, sp.Spec.Routes)
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *ServiceProfileList) DeepCopyObject() runtime.Object {
+	if l == nil {
+		return nil
+	}
+	out := &ServiceProfileList{TypeMeta: l.TypeMeta}
+	l.ListMeta.DeepCopyInto(&out.ListMeta)
+	if l.Items != nil {
+		out.Items = make([]ServiceProfile, len(l.Items))
+		for i := range l.Items {
+			out.Items[i] = *l.Items[i].DeepCopy()
+		}
+	}
+	return out
+}
+
+// NewServiceProfileInformer returns an informer for the ServiceProfiles in all
+// namespaces. It needs to be run, e.g. with `go informer.Run(stopCh)`.
+func NewServiceProfileInformer(kubeConfig string) (cache.SharedIndexInformer, error) {
+	config, err := getConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newServiceProfileClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServiceProfileInformerFor(
+		cache.NewListWatchFromClient(client, ServiceProfileResource, metav1.NamespaceAll, fields.Everything()),
+	), nil
+}
+
+// NewServiceProfileInformerFor returns an informer for the ServiceProfiles
+// that are listed and watched by `lw`.
+func NewServiceProfileInformerFor(lw cache.ListerWatcher) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		lw,
+		&ServiceProfile{},
+		serviceProfileResyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+func newServiceProfileClient(config *rest.Config) (*rest.RESTClient, error) {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(ServiceProfileGroupVersion, &ServiceProfile{}, &ServiceProfileList{})
+	metav1.AddToGroupVersion(scheme, ServiceProfileGroupVersion)
+
+	crdConfig := *config
+	crdConfig.GroupVersion = &ServiceProfileGroupVersion
+	crdConfig.APIPath = "/apis"
+	crdConfig.ContentType = runtime.ContentTypeJSON
+	crdConfig.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
+
+	return rest.RESTClientFor(&crdConfig)
+}
@@ -29,6 +29,7 @@ func NewFakeAPI(configs ...string) (*API, error) {
 		Deploy,
 		Endpoint,
 		NS,
+		Node,
 		Pod,
 		RC,
 		RS,
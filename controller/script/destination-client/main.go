@@ -53,7 +53,7 @@ func main() {
 			log.Println("Add:")
 			log.Printf("labels: %v", updateType.Add.MetricLabels)
 			for _, addr := range updateType.Add.Addrs {
-				log.Printf("- %s:%d", addrUtil.IPToString(addr.Addr.GetIp()), addr.Addr.Port)
+				log.Printf("- %s", addrUtil.AddressToString(addr.Addr))
 				log.Printf("  - labels: %v", addr.MetricLabels)
 				switch identityType := addr.GetTlsIdentity().GetStrategy().(type) {
 				case *pb.TlsIdentity_K8SPodIdentity_:
@@ -65,7 +65,7 @@ func main() {
 		case *pb.Update_Remove:
 			log.Println("Remove:")
 			for _, addr := range updateType.Remove.Addrs {
-				log.Printf("- %s:%d", addrUtil.IPToString(addr.GetIp()), addr.Port)
+				log.Printf("- %s", addrUtil.AddressToString(addr))
 			}
 			log.Println()
 		case *pb.Update_NoEndpoints:
@@ -0,0 +1,97 @@
+package tap
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	rejectReasonSessionLimit = "session_limit"
+	rejectReasonPodLimit     = "pod_limit"
+)
+
+var (
+	activeSessions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "tap_active_sessions",
+			Help: "The number of open TapByResource streams.",
+		},
+	)
+
+	activePodTaps = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "tap_active_pod_taps",
+			Help: "The number of pods being tapped, counting a pod once per stream that taps it.",
+		},
+	)
+
+	rejectedPodTaps = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tap_rejected_pod_taps_total",
+			Help: "A counter of pod taps that were not started because of a limit, by reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(activeSessions, activePodTaps, rejectedPodTaps)
+}
+
+// admission limits the number of taps that the server opens into the proxies:
+// a session taps at most maxTapsPerSession pods, and a pod is tapped by at
+// most maxTapsPerPod sessions at once. A limit of 0 disables it.
+type admission struct {
+	maxTapsPerPod     uint
+	maxTapsPerSession uint
+	// the number of sessions tapping each pod, keyed by pod IP
+	podTaps map[string]uint
+	mutex   sync.Mutex
+}
+
+func newAdmission(maxTapsPerPod, maxTapsPerSession uint) *admission {
+	return &admission{
+		maxTapsPerPod:     maxTapsPerPod,
+		maxTapsPerSession: maxTapsPerSession,
+		podTaps:           make(map[string]uint),
+	}
+}
+
+// admit reserves a tap of a pod for a session that is already tapping
+// sessionTaps pods. It returns a ResourceExhausted error if either limit has
+// been reached. Each tap that is admitted must be released.
+func (a *admission) admit(pod *apiv1.Pod, sessionTaps int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.maxTapsPerSession != 0 && uint(sessionTaps) >= a.maxTapsPerSession {
+		rejectedPodTaps.WithLabelValues(rejectReasonSessionLimit).Inc()
+		return status.Errorf(codes.ResourceExhausted, "a tap may include at most %d pods; tap a smaller resource", a.maxTapsPerSession)
+	}
+
+	ip := pod.Status.PodIP
+	if a.maxTapsPerPod != 0 && a.podTaps[ip] >= a.maxTapsPerPod {
+		rejectedPodTaps.WithLabelValues(rejectReasonPodLimit).Inc()
+		return status.Errorf(codes.ResourceExhausted, "pod %s/%s is already being tapped %d times, which is the limit", pod.Namespace, pod.Name, a.maxTapsPerPod)
+	}
+
+	a.podTaps[ip]++
+	activePodTaps.Inc()
+	return nil
+}
+
+// release frees a tap of a pod that was admitted.
+func (a *admission) release(ip string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.podTaps[ip]--
+	if a.podTaps[ip] == 0 {
+		delete(a.podTaps, ip)
+	}
+	activePodTaps.Dec()
+}
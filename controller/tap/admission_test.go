@@ -0,0 +1,66 @@
+package tap
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdmission(t *testing.T) {
+	pod := func(name, ip string) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "emojivoto"},
+			Status:     apiv1.PodStatus{Phase: apiv1.PodRunning, PodIP: ip},
+		}
+	}
+
+	expectExhausted := func(t *testing.T, err error) {
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Expected a ResourceExhausted error, got [%v]", err)
+		}
+	}
+
+	t.Run("Limits the number of taps of a pod", func(t *testing.T) {
+		a := newAdmission(2, 0)
+		web1 := pod("web-1", "10.1.0.1")
+
+		for i := 0; i < 2; i++ {
+			if err := a.admit(web1, 0); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		expectExhausted(t, a.admit(web1, 0))
+
+		if err := a.admit(pod("web-2", "10.1.0.2"), 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		a.release(web1.Status.PodIP)
+		if err := a.admit(web1, 0); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Limits the number of pods of a session", func(t *testing.T) {
+		a := newAdmission(0, 2)
+
+		if err := a.admit(pod("web-1", "10.1.0.1"), 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expectExhausted(t, a.admit(pod("web-2", "10.1.0.2"), 2))
+	})
+
+	t.Run("Doesn't limit taps with limits of 0", func(t *testing.T) {
+		a := newAdmission(0, 0)
+		web1 := pod("web-1", "10.1.0.1")
+
+		for i := 0; i < 1000; i++ {
+			if err := a.admit(web1, i); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	})
+}
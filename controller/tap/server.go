@@ -2,18 +2,21 @@ package tap
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	apiUtil "github.com/runconduit/conduit/controller/api/util"
 	common "github.com/runconduit/conduit/controller/gen/common"
 	pb "github.com/runconduit/conduit/controller/gen/controller/tap"
 	proxy "github.com/runconduit/conduit/controller/gen/proxy/tap"
 	public "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
+	"github.com/runconduit/conduit/pkg/addr"
 	pkgK8s "github.com/runconduit/conduit/pkg/k8s"
 	"github.com/runconduit/conduit/pkg/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -21,17 +24,54 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 type (
 	server struct {
 		tapPort uint
 		k8sAPI  *k8s.API
+		// the longest that a TapByResource stream may stay open, or 0 for no
+		// limit
+		maxTapDuration time.Duration
+		admission      *admission
+		// the open TapByResource streams, which are notified when pods change
+		sessions map[*tapSession]struct{}
+		mutex    sync.Mutex
+	}
+
+	// tapSession is a TapByResource stream. It taps each of the pods of its
+	// target for as long as the stream is open, following the pods as they
+	// are created and deleted.
+	tapSession struct {
+		ctx    context.Context
+		target *public.Resource
+		maxRps float32
+		// the match of the request, which is made into observeMatch again
+		// when pods change, since a source resource is matched by its pods'
+		// IPs
+		requestMatch *public.TapByResourceRequest_Match
+		events       chan *common.TapEvent
+		// signaled when a pod changes; buffered so that the informer never
+		// blocks on a session
+		podsChanged chan struct{}
+		// the taps of the target's pods, keyed by pod IP; only accessed by
+		// the stream's goroutine
+		taps map[string]context.CancelFunc
+		// the share of maxRps that each pod is tapped at
+		podRps float32
+		// the match that the pods are tapped with
+		observeMatch *proxy.ObserveRequest_Match
+		mutex        sync.Mutex
 	}
 )
 
 var (
 	tapInterval = 10 * time.Second
+
+	// the bounds of the backoff between the attempts to tap a proxy
+	minTapBackoff = 1 * time.Second
+	maxTapBackoff = 30 * time.Second
 )
 
 func (s *server) Tap(req *public.TapRequest, stream pb.Tap_TapServer) error {
@@ -46,58 +86,241 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 		return status.Errorf(codes.InvalidArgument, "TapByResource received nil target ResourceSelection: %+v", *req)
 	}
 
-	objects, err := s.k8sAPI.GetObjects(req.Target.Resource.Namespace, req.Target.Resource.Type, req.Target.Resource.Name)
+	pods, err := s.podsFor(req.Target.Resource)
+	if err != nil {
+		return apiUtil.GRPCError(err)
+	}
+
+	if len(pods) == 0 {
+		return status.Errorf(codes.NotFound, "no pods found for ResourceSelection: %+v", *req.Target)
+	}
+
+	match, err := s.makeByResourceMatch(req.Match)
 	if err != nil {
 		return apiUtil.GRPCError(err)
 	}
 
+	ctx := stream.Context()
+	if s.maxTapDuration != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.maxTapDuration)
+		defer cancel()
+	}
+
+	session := &tapSession{
+		ctx:          ctx,
+		target:       req.Target.Resource,
+		maxRps:       req.MaxRps,
+		requestMatch: req.Match,
+		observeMatch: match,
+		events:       make(chan *common.TapEvent),
+		podsChanged:  make(chan struct{}, 1),
+		taps:         make(map[string]context.CancelFunc),
+	}
+	s.addSession(session)
+	defer s.removeSession(session)
+
+	// a tap must be admitted on all of the target's pods to start
+	err = s.updateTaps(session, pods)
+	if err != nil {
+		s.stopTaps(session)
+		return err
+	}
+
+	// read events from the taps and send them back, until the request is
+	// cancelled or runs out of time
+	for {
+		select {
+		case <-session.ctx.Done():
+			if stream.Context().Err() == nil {
+				return status.Errorf(codes.DeadlineExceeded, "tap exceeded the maximum duration of %s", s.maxTapDuration)
+			}
+			return nil
+
+		case <-session.podsChanged:
+			s.updateMatch(session)
+
+			pods, err := s.podsFor(session.target)
+			if err != nil {
+				log.Errorf("Failed to get pods for target %+v: %s", *session.target, err)
+				continue
+			}
+			err = s.updateTaps(session, pods)
+			if err != nil {
+				log.Errorf("Not tapping some pods of target %+v: %s", *session.target, err)
+			}
+
+		case event := <-session.events:
+			err := stream.Send(event)
+			if err != nil {
+				return apiUtil.GRPCError(err)
+			}
+		}
+	}
+}
+
+// podsFor returns the pods of a resource.
+func (s *server) podsFor(resource *public.Resource) ([]*apiv1.Pod, error) {
+	objects, err := s.k8sAPI.GetObjects(resource.Namespace, resource.Type, resource.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	pods := []*apiv1.Pod{}
 	for _, object := range objects {
 		podsFor, err := s.k8sAPI.GetPodsFor(object, false)
 		if err != nil {
-			return apiUtil.GRPCError(err)
+			return nil, err
 		}
 
 		pods = append(pods, podsFor...)
 	}
+	return pods, nil
+}
 
-	if len(pods) == 0 {
-		return status.Errorf(codes.NotFound, "no pods found for ResourceSelection: %+v", *req.Target)
+// updateMatch makes the session's match again from its request, so that a
+// source resource matches the IPs that its pods have now. The taps pick up the
+// new match the next time they call their proxy. If the match can't be made,
+// e.g. because none of the source's pods has an IP, the previous one is kept.
+func (s *server) updateMatch(session *tapSession) {
+	match, err := s.makeByResourceMatch(session.requestMatch)
+	if err != nil {
+		log.Errorf("Failed to update the match for target %+v: %s", *session.target, err)
+		return
 	}
+	session.setMatch(match)
+}
 
-	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
-
-	events := make(chan *common.TapEvent)
+// updateTaps starts taps on the session's pods that aren't tapped yet, stops
+// the taps on pods that are gone, and divides the session's maxRps evenly
+// between the pods. Pods that don't have an IP yet are tapped once they do.
+// If a pod's tap isn't admitted, the other pods are still tapped and the last
+// admission error is returned; the pod is tried again on the next update.
+func (s *server) updateTaps(session *tapSession, pods []*apiv1.Pod) error {
+	ips := make(map[string]*apiv1.Pod)
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" {
+			ips[pod.Status.PodIP] = pod
+		}
+	}
 
-	go func() { // Stop sending back events if the request is cancelled
-		<-stream.Context().Done()
-		close(events)
-	}()
+	for ip, cancel := range session.taps {
+		if _, ok := ips[ip]; !ok {
+			log.Infof("Stopping tap on %s", ip)
+			cancel()
+			delete(session.taps, ip)
+		}
+	}
 
-	// divide the rps evenly between all pods to tap
-	rpsPerPod := req.MaxRps / float32(len(pods))
-	if rpsPerPod < 1 {
-		rpsPerPod = 1
+	var admissionErr error
+	admitted := []*apiv1.Pod{}
+	for ip, pod := range ips {
+		if _, ok := session.taps[ip]; ok {
+			continue
+		}
+		err := s.admission.admit(pod, len(session.taps)+len(admitted))
+		if err != nil {
+			admissionErr = err
+			continue
+		}
+		admitted = append(admitted, pod)
 	}
 
-	match, err := makeByResourceMatch(req.Match)
-	if err != nil {
-		return apiUtil.GRPCError(err)
+	tapCount := len(session.taps) + len(admitted)
+	if tapCount != 0 {
+		// divide the rps evenly between all pods to tap
+		rpsPerPod := session.maxRps / float32(tapCount)
+		if rpsPerPod < 1 {
+			rpsPerPod = 1
+		}
+		session.setRpsPerPod(rpsPerPod)
 	}
 
-	for _, pod := range pods {
+	for _, pod := range admitted {
+		ip := pod.Status.PodIP
+		ctx, cancel := context.WithCancel(session.ctx)
+		session.taps[ip] = cancel
+
 		// initiate a tap on the pod
-		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events)
+		go func(pod *apiv1.Pod) {
+			defer s.admission.release(pod.Status.PodIP)
+			s.tapProxy(ctx, session.rpsPerPod, session.match, pod, session.events)
+		}(pod)
 	}
 
-	// read events from the taps and send them back
-	for event := range events {
-		err := stream.Send(event)
-		if err != nil {
-			return apiUtil.GRPCError(err)
+	if len(admitted) != 0 {
+		log.Infof("Tapping %d pods for target: %+v", len(session.taps), *session.target)
+	}
+	return admissionErr
+}
+
+// stopTaps stops all of the taps of a session.
+func (s *server) stopTaps(session *tapSession) {
+	for ip, cancel := range session.taps {
+		cancel()
+		delete(session.taps, ip)
+	}
+}
+
+func (s *server) addSession(session *tapSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sessions[session] = struct{}{}
+	activeSessions.Inc()
+}
+
+func (s *server) removeSession(session *tapSession) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.sessions, session)
+	activeSessions.Dec()
+}
+
+// notifySessions tells every open session that its pods may have changed.
+func (s *server) notifySessions() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for session := range s.sessions {
+		select {
+		case session.podsChanged <- struct{}{}:
+		default:
+			// the session hasn't handled the last change yet
 		}
 	}
-	return nil
+}
+
+func (s *server) handlePodUpdate(oldObj, newObj interface{}) {
+	oldPod := oldObj.(*apiv1.Pod)
+	newPod := newObj.(*apiv1.Pod)
+	if oldPod.ResourceVersion == newPod.ResourceVersion {
+		// periodic resync
+		return
+	}
+	s.notifySessions()
+}
+
+func (t *tapSession) rpsPerPod() float32 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.podRps
+}
+
+func (t *tapSession) setRpsPerPod(rps float32) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.podRps = rps
+}
+
+func (t *tapSession) match() *proxy.ObserveRequest_Match {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.observeMatch
+}
+
+func (t *tapSession) setMatch(match *proxy.ObserveRequest_Match) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.observeMatch = match
 }
 
 // TODO: validate scheme
@@ -134,7 +357,7 @@ func parseMethod(method string) *common.HttpMethod {
 	}
 }
 
-func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.ObserveRequest_Match, error) {
+func (s *server) makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.ObserveRequest_Match, error) {
 	// TODO: for now assume it's always a single, flat `All` match list
 	seq := match.GetAll()
 	if seq == nil {
@@ -158,6 +381,14 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 				})
 			}
 
+		case *public.TapByResourceRequest_Match_Sources:
+
+			sourceMatch, err := s.makeSourceMatch(typed.Sources.GetResource())
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, sourceMatch)
+
 		case *public.TapByResourceRequest_Match_Http_:
 
 			httpMatch := proxy.ObserveRequest_Match_Http{}
@@ -195,6 +426,16 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 						},
 					},
 				}
+			case *public.TapByResourceRequest_Match_Http_Header_:
+				header, err := makeHeaderMatch(httpTyped.Header)
+				if err != nil {
+					return nil, err
+				}
+				httpMatch = proxy.ObserveRequest_Match_Http{
+					Match: &proxy.ObserveRequest_Match_Http_Header_{
+						Header: header,
+					},
+				}
 			default:
 				return nil, status.Errorf(codes.Unimplemented, "unknown HTTP match type: %v", httpTyped)
 			}
@@ -219,6 +460,87 @@ func makeByResourceMatch(match *public.TapByResourceRequest_Match) (*proxy.Obser
 	}, nil
 }
 
+// makeSourceMatch matches requests sent by any of the pods of a resource. The
+// proxy only knows the address of a request's source, so the resource is
+// resolved to the IPs of its pods, when the tap starts and whenever pods
+// change.
+func (s *server) makeSourceMatch(resource *public.Resource) (*proxy.ObserveRequest_Match, error) {
+	if resource == nil {
+		return nil, status.Error(codes.InvalidArgument, "source match without a resource")
+	}
+
+	pods, err := s.podsFor(resource)
+	if err != nil {
+		return nil, apiUtil.GRPCError(err)
+	}
+
+	matches := []*proxy.ObserveRequest_Match{}
+	for _, pod := range pods {
+		if pod.Status.PodIP == "" {
+			continue
+		}
+		ip, err := addr.ParseIP(pod.Status.PodIP)
+		if err != nil {
+			log.Errorf("Skipping source pod %s/%s: %s", pod.Namespace, pod.Name, err)
+			continue
+		}
+
+		mask := uint32(32)
+		if ip.GetIpv6() != nil {
+			mask = 128
+		}
+		matches = append(matches, &proxy.ObserveRequest_Match{
+			Match: &proxy.ObserveRequest_Match_Source{
+				Source: &proxy.ObserveRequest_Match_Tcp{
+					Match: &proxy.ObserveRequest_Match_Tcp_Netmask_{
+						Netmask: &proxy.ObserveRequest_Match_Tcp_Netmask{
+							Ip:   ip,
+							Mask: mask,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if len(matches) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no pods with an IP found for source: %+v", *resource)
+	}
+
+	return &proxy.ObserveRequest_Match{
+		Match: &proxy.ObserveRequest_Match_Any{
+			Any: &proxy.ObserveRequest_Match_Seq{
+				Matches: matches,
+			},
+		},
+	}, nil
+}
+
+func makeHeaderMatch(header *public.TapByResourceRequest_Match_Http_Header) (*proxy.ObserveRequest_Match_Http_Header, error) {
+	if header.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "header match without a name: %v", header)
+	}
+
+	value := proxy.ObserveRequest_Match_Http_StringMatch{}
+	switch typed := header.Match.(type) {
+	case *public.TapByResourceRequest_Match_Http_Header_Exact:
+		value.Match = &proxy.ObserveRequest_Match_Http_StringMatch_Exact{
+			Exact: typed.Exact,
+		}
+	case *public.TapByResourceRequest_Match_Http_Header_Prefix:
+		value.Match = &proxy.ObserveRequest_Match_Http_StringMatch_Prefix{
+			Prefix: typed.Prefix,
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "header match without a value: %v", header)
+	}
+
+	return &proxy.ObserveRequest_Match_Http_Header{
+		Name:  strings.ToLower(header.Name),
+		Value: &value,
+	}, nil
+}
+
 // TODO: factor out with `promLabels` in public-api
 func destinationLabels(resource *public.Resource) map[string]string {
 	dstLabels := map[string]string{}
@@ -232,57 +554,176 @@ func destinationLabels(resource *public.Resource) map[string]string {
 }
 
 // Tap a pod.
-// This method will run continuously until an error is encountered or the
+// This method will run continuously until the proxy refuses the tap or the
 // request is cancelled via the context.  Thus it should be called as a
 // go-routine.
 // To limit the rps to maxRps, this method calls Observe on the pod with a limit
 // of maxRps * 10s at most once per 10s window.  If this limit is reached in
 // less than 10s, we sleep until the end of the window before calling Observe
-// again.
-func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, addr string, events chan *common.TapEvent) {
-	tapAddr := fmt.Sprintf("%s:%d", addr, s.tapPort)
+// again; otherwise the call is canceled at the end of the window. maxRps is
+// read at the start of each window, as it changes when the number of tapped
+// pods does, and so is match, as it changes when the pods of a source
+// resource do.
+// If Observe fails, it is retried with an exponential backoff, and the state of
+// the tap is reported to the client with TapStatus events.
+func (s *server) tapProxy(ctx context.Context, maxRps func() float32, match func() *proxy.ObserveRequest_Match, pod *apiv1.Pod, events chan *common.TapEvent) {
+	tapAddr := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(s.tapPort)))
+	reporter := s.newTapStatusReporter(pod, events)
+
 	log.Infof("Establishing tap on %s", tapAddr)
 	conn, err := grpc.DialContext(ctx, tapAddr, grpc.WithInsecure())
 	if err != nil {
 		log.Error(err)
+		reporter.report(ctx, common.TapEvent_TapStatus_FAILED, err)
 		return
 	}
+	defer conn.Close()
 	client := proxy.NewTapClient(conn)
 
-	req := &proxy.ObserveRequest{
-		Limit: uint32(maxRps * float32(tapInterval.Seconds())),
-		Match: match,
-	}
-
+	backoff := minTapBackoff
 	for { // Request loop
 		windowStart := time.Now()
 		windowEnd := windowStart.Add(tapInterval)
-		rsp, err := client.Observe(ctx, req)
-		if err != nil {
-			log.Error(err)
+		req := &proxy.ObserveRequest{
+			Limit: uint32(maxRps() * float32(tapInterval.Seconds())),
+			Match: match(),
+		}
+
+		window, cancel := context.WithDeadline(ctx, windowEnd)
+		err := observe(ctx, window, client, req, reporter, events)
+		cancel()
+		if ctx.Err() != nil {
 			return
 		}
-		for { // Stream loop
-			event, err := rsp.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Error(err)
+		if err != nil {
+			if !isRetryable(err) {
+				log.Errorf("Tap on %s failed: %s", tapAddr, err)
+				reporter.report(ctx, common.TapEvent_TapStatus_FAILED, err)
 				return
 			}
-			events <- event
+
+			log.Errorf("Tap on %s failed, retrying in %s: %s", tapAddr, backoff, err)
+			reporter.report(ctx, common.TapEvent_TapStatus_RETRYING, err)
+			windowEnd = time.Now().Add(backoff)
+			backoff *= 2
+			if backoff > maxTapBackoff {
+				backoff = maxTapBackoff
+			}
+		} else {
+			backoff = minTapBackoff
+		}
+
+		select {
+		case <-time.After(time.Until(windowEnd)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// windowErr returns nil if err is caused by the end of `window`, rather than
+// by the end of `ctx` or a failure of the call.
+func windowErr(ctx, window context.Context, err error) error {
+	if window.Err() != nil && ctx.Err() == nil {
+		return nil
+	}
+	return err
+}
+
+// observe forwards the events of one Observe call on a proxy until its
+// stream ends or `window` is done, and reports the tap as connected once the
+// proxy accepts it. The end of the window isn't an error.
+func observe(ctx, window context.Context, client proxy.TapClient, req *proxy.ObserveRequest, reporter *tapStatusReporter, events chan *common.TapEvent) error {
+	rsp, err := client.Observe(window, req)
+	if err != nil {
+		return windowErr(ctx, window, err)
+	}
+	// the proxy sends the response headers once it accepts the tap
+	_, err = rsp.Header()
+	if err != nil {
+		return windowErr(ctx, window, err)
+	}
+	reporter.report(ctx, common.TapEvent_TapStatus_CONNECTED, nil)
+
+	for { // Stream loop
+		event, err := rsp.Recv()
+		if err == io.EOF {
+			return nil
 		}
-		if time.Now().Before(windowEnd) {
-			time.Sleep(time.Until(windowEnd))
+		if err != nil {
+			return windowErr(ctx, window, err)
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
 }
 
+// isRetryable returns false for the errors of taps that the proxy refused,
+// which fail the same way every time.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.Unimplemented, codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	default:
+		return true
+	}
+}
+
+// tapStatusReporter sends TapStatus events for the tap of a pod, whenever the
+// state of the tap or its error changes.
+type tapStatusReporter struct {
+	address *common.TcpAddress
+	labels  map[string]string
+	events  chan *common.TapEvent
+	last    *common.TapEvent_TapStatus
+}
+
+func (s *server) newTapStatusReporter(pod *apiv1.Pod, events chan *common.TapEvent) *tapStatusReporter {
+	ip, err := addr.ParseIP(pod.Status.PodIP)
+	if err != nil {
+		log.Errorf("Failed to parse the IP of pod %s/%s: %s", pod.Namespace, pod.Name, err)
+	}
+	return &tapStatusReporter{
+		address: &common.TcpAddress{Ip: ip, Port: uint32(s.tapPort)},
+		labels: map[string]string{
+			"pod":       pod.Name,
+			"namespace": pod.Namespace,
+		},
+		events: events,
+	}
+}
+
+func (r *tapStatusReporter) report(ctx context.Context, state common.TapEvent_TapStatus_State, err error) {
+	tapStatus := &common.TapEvent_TapStatus{State: state}
+	if err != nil {
+		tapStatus.Error = err.Error()
+	}
+	if proto.Equal(tapStatus, r.last) {
+		return
+	}
+	r.last = tapStatus
+
+	event := &common.TapEvent{
+		Destination:     r.address,
+		DestinationMeta: &common.TapEvent_EndpointMeta{Labels: r.labels},
+		Event:           &common.TapEvent_TapStatus_{TapStatus: tapStatus},
+	}
+	select {
+	case r.events <- event:
+	case <-ctx.Done():
+	}
+}
+
 // NewServer creates a new gRPC Tap server
 func NewServer(
 	addr string,
 	tapPort uint,
+	maxTapsPerPod uint,
+	maxTapsPerSession uint,
+	maxTapDuration time.Duration,
 	k8sAPI *k8s.API,
 ) (*grpc.Server, net.Listener, error) {
 
@@ -293,9 +734,20 @@ func NewServer(
 
 	s := prometheus.NewGrpcServer()
 	srv := server{
-		tapPort: tapPort,
-		k8sAPI:  k8sAPI,
+		tapPort:        tapPort,
+		k8sAPI:         k8sAPI,
+		maxTapDuration: maxTapDuration,
+		admission:      newAdmission(maxTapsPerPod, maxTapsPerSession),
+		sessions:       make(map[*tapSession]struct{}),
 	}
+
+	k8sAPI.Pod().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { srv.notifySessions() },
+			UpdateFunc: srv.handlePodUpdate,
+			DeleteFunc: func(obj interface{}) { srv.notifySessions() },
+		},
+	)
 	pb.RegisterTapServer(s, &srv)
 
 	return s, lis, nil
@@ -5,8 +5,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	common "github.com/runconduit/conduit/controller/gen/common"
+	proxy "github.com/runconduit/conduit/controller/gen/proxy/tap"
 	public "github.com/runconduit/conduit/controller/gen/public"
 	"github.com/runconduit/conduit/controller/k8s"
+	"github.com/runconduit/conduit/pkg/addr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type tapExpected struct {
@@ -162,7 +170,7 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			server, listener, err := NewServer("localhost:0", 0, k8sAPI)
+			server, listener, err := NewServer("localhost:0", 0, 0, 0, 0, k8sAPI)
 			if err != nil {
 				t.Fatalf("NewServer error: %s", err)
 			}
@@ -194,4 +202,430 @@ status:
 			}
 		}
 	})
+
+	t.Run("Ends taps that exceed the maximum duration", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+status:
+  phase: Running
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		server, listener, err := NewServer("localhost:0", 0, 0, 0, 50*time.Millisecond, k8sAPI)
+		if err != nil {
+			t.Fatalf("NewServer error: %s", err)
+		}
+
+		go func() { server.Serve(listener) }()
+		defer server.GracefulStop()
+
+		k8sAPI.Sync(nil)
+
+		client, conn, err := NewClient(listener.Addr().String())
+		if err != nil {
+			t.Fatalf("NewClient error: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		tapClient, err := client.TapByResource(ctx, &public.TapByResourceRequest{
+			Target: &public.ResourceSelection{
+				Resource: &public.Resource{
+					Namespace: "emojivoto",
+					Type:      "pods",
+					Name:      "emojivoto-meshed",
+				},
+			},
+			Match: &public.TapByResourceRequest_Match{
+				Match: &public.TapByResourceRequest_Match_All{
+					All: &public.TapByResourceRequest_Match_Seq{},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("TapByResource failed: %v", err)
+		}
+
+		expected := "rpc error: code = DeadlineExceeded desc = tap exceeded the maximum duration of 50ms"
+		_, err = tapClient.Recv()
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error to be [%s], but was [%v]", expected, err)
+		}
+	})
+}
+
+func TestMakeByResourceMatch(t *testing.T) {
+	headerMatch := func(header *public.TapByResourceRequest_Match_Http_Header) *public.TapByResourceRequest_Match {
+		return &public.TapByResourceRequest_Match{
+			Match: &public.TapByResourceRequest_Match_All{
+				All: &public.TapByResourceRequest_Match_Seq{
+					Matches: []*public.TapByResourceRequest_Match{
+						{
+							Match: &public.TapByResourceRequest_Match_Http_{
+								Http: &public.TapByResourceRequest_Match_Http{
+									Match: &public.TapByResourceRequest_Match_Http_Header_{Header: header},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	observeHeaderMatch := func(name string, value *proxy.ObserveRequest_Match_Http_StringMatch) *proxy.ObserveRequest_Match {
+		return &proxy.ObserveRequest_Match{
+			Match: &proxy.ObserveRequest_Match_All{
+				All: &proxy.ObserveRequest_Match_Seq{
+					Matches: []*proxy.ObserveRequest_Match{
+						{
+							Match: &proxy.ObserveRequest_Match_Http_{
+								Http: &proxy.ObserveRequest_Match_Http{
+									Match: &proxy.ObserveRequest_Match_Http_Header_{
+										Header: &proxy.ObserveRequest_Match_Http_Header{Name: name, Value: value},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Translates exact and prefix header matches, with lower-case names", func(t *testing.T) {
+		for _, tt := range []struct {
+			header   *public.TapByResourceRequest_Match_Http_Header
+			expected *proxy.ObserveRequest_Match
+		}{
+			{
+				&public.TapByResourceRequest_Match_Http_Header{
+					Name:  "X-Tenant-Id",
+					Match: &public.TapByResourceRequest_Match_Http_Header_Exact{Exact: "42"},
+				},
+				observeHeaderMatch("x-tenant-id", &proxy.ObserveRequest_Match_Http_StringMatch{
+					Match: &proxy.ObserveRequest_Match_Http_StringMatch_Exact{Exact: "42"},
+				}),
+			},
+			{
+				&public.TapByResourceRequest_Match_Http_Header{
+					Name:  "user-agent",
+					Match: &public.TapByResourceRequest_Match_Http_Header_Prefix{Prefix: "curl/"},
+				},
+				observeHeaderMatch("user-agent", &proxy.ObserveRequest_Match_Http_StringMatch{
+					Match: &proxy.ObserveRequest_Match_Http_StringMatch_Prefix{Prefix: "curl/"},
+				}),
+			},
+		} {
+			actual, err := (&server{}).makeByResourceMatch(headerMatch(tt.header))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !proto.Equal(actual, tt.expected) {
+				t.Fatalf("Expected match [%v], got [%v]", tt.expected, actual)
+			}
+		}
+	})
+
+	t.Run("Returns an error for header matches without a name or value", func(t *testing.T) {
+		for _, header := range []*public.TapByResourceRequest_Match_Http_Header{
+			{Match: &public.TapByResourceRequest_Match_Http_Header_Exact{Exact: "42"}},
+			{Name: "x-tenant-id"},
+		} {
+			_, err := (&server{}).makeByResourceMatch(headerMatch(header))
+			if err == nil {
+				t.Fatalf("Expected an error for header match [%v], got nothing", header)
+			}
+		}
+	})
+}
+
+func TestMakeSourceMatch(t *testing.T) {
+	k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-v4
+  namespace: emojivoto
+status:
+  phase: Running
+  podIP: 10.1.2.3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-v6
+  namespace: emojivoto
+status:
+  phase: Running
+  podIP: fd00::1
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-pending
+  namespace: emojivoto
+status:
+  phase: Pending
+`)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+	srv := &server{k8sAPI: k8sAPI}
+
+	observeSourceMatch := func(ip *common.IPAddress, mask uint32) *proxy.ObserveRequest_Match {
+		return &proxy.ObserveRequest_Match{
+			Match: &proxy.ObserveRequest_Match_Any{
+				Any: &proxy.ObserveRequest_Match_Seq{
+					Matches: []*proxy.ObserveRequest_Match{
+						{
+							Match: &proxy.ObserveRequest_Match_Source{
+								Source: &proxy.ObserveRequest_Match_Tcp{
+									Match: &proxy.ObserveRequest_Match_Tcp_Netmask_{
+										Netmask: &proxy.ObserveRequest_Match_Tcp_Netmask{Ip: ip, Mask: mask},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Matches the IPs of the source's pods", func(t *testing.T) {
+		for _, tt := range []struct {
+			pod      string
+			expected *proxy.ObserveRequest_Match
+		}{
+			{"web-v4", observeSourceMatch(addr.IPV4(10, 1, 2, 3), 32)},
+			{"web-v6", observeSourceMatch(addr.IPV6(0xfd00000000000000, 1), 128)},
+		} {
+			actual, err := srv.makeSourceMatch(&public.Resource{Namespace: "emojivoto", Type: "pods", Name: tt.pod})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !proto.Equal(actual, tt.expected) {
+				t.Fatalf("Expected match [%v], got [%v]", tt.expected, actual)
+			}
+		}
+	})
+
+	t.Run("Returns an error if none of the source's pods have an IP", func(t *testing.T) {
+		_, err := srv.makeSourceMatch(&public.Resource{Namespace: "emojivoto", Type: "pods", Name: "web-pending"})
+		if err == nil {
+			t.Fatalf("Expected an error, got nothing")
+		}
+	})
+
+	t.Run("Updates a session's match to the IPs of the source's pods", func(t *testing.T) {
+		sourceRequest := func(pod string) *public.TapByResourceRequest_Match {
+			return &public.TapByResourceRequest_Match{
+				Match: &public.TapByResourceRequest_Match_All{
+					All: &public.TapByResourceRequest_Match_Seq{
+						Matches: []*public.TapByResourceRequest_Match{
+							{
+								Match: &public.TapByResourceRequest_Match_Sources{
+									Sources: &public.ResourceSelection{
+										Resource: &public.Resource{Namespace: "emojivoto", Type: "pods", Name: pod},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+		expected := &proxy.ObserveRequest_Match{
+			Match: &proxy.ObserveRequest_Match_All{
+				All: &proxy.ObserveRequest_Match_Seq{
+					Matches: []*proxy.ObserveRequest_Match{observeSourceMatch(addr.IPV4(10, 1, 2, 3), 32)},
+				},
+			},
+		}
+
+		session := &tapSession{target: &public.Resource{}, requestMatch: sourceRequest("web-v4")}
+		srv.updateMatch(session)
+		if !proto.Equal(session.match(), expected) {
+			t.Fatalf("Expected match [%v], got [%v]", expected, session.match())
+		}
+
+		// the previous match is kept if the new one can't be made
+		session.requestMatch = sourceRequest("web-pending")
+		srv.updateMatch(session)
+		if !proto.Equal(session.match(), expected) {
+			t.Fatalf("Expected match [%v], got [%v]", expected, session.match())
+		}
+	})
+}
+
+func TestUpdateTaps(t *testing.T) {
+	pod := func(name, ip string) *apiv1.Pod {
+		return &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "emojivoto"},
+			Status:     apiv1.PodStatus{Phase: apiv1.PodRunning, PodIP: ip},
+		}
+	}
+
+	expectTaps := func(t *testing.T, session *tapSession, expectedRps float32, expectedIPs ...string) {
+		if len(session.taps) != len(expectedIPs) {
+			t.Fatalf("Expected [%d] taps, got [%d]", len(expectedIPs), len(session.taps))
+		}
+		for _, ip := range expectedIPs {
+			if _, ok := session.taps[ip]; !ok {
+				t.Fatalf("Expected a tap on [%s], got none", ip)
+			}
+		}
+		if session.rpsPerPod() != expectedRps {
+			t.Fatalf("Expected [%f] rps per pod, got [%f]", expectedRps, session.rpsPerPod())
+		}
+	}
+
+	t.Run("Follows the target's pods and divides the rps between them", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		srv := &server{admission: newAdmission(0, 0), sessions: make(map[*tapSession]struct{})}
+		session := &tapSession{
+			ctx:         ctx,
+			target:      &public.Resource{Namespace: "emojivoto", Type: "deployments", Name: "web"},
+			maxRps:      12,
+			events:      make(chan *common.TapEvent),
+			podsChanged: make(chan struct{}, 1),
+			taps:        make(map[string]context.CancelFunc),
+		}
+
+		srv.updateTaps(session, []*apiv1.Pod{pod("web-1", "10.1.0.1"), pod("web-2", "10.1.0.2")})
+		expectTaps(t, session, 6, "10.1.0.1", "10.1.0.2")
+
+		// a rolling deploy: web-1 is deleted, and web-3 and web-4 are created,
+		// the latter without an IP yet
+		srv.updateTaps(session, []*apiv1.Pod{pod("web-2", "10.1.0.2"), pod("web-3", "10.1.0.3"), pod("web-4", "")})
+		expectTaps(t, session, 6, "10.1.0.2", "10.1.0.3")
+
+		srv.updateTaps(session, []*apiv1.Pod{pod("web-2", "10.1.0.2"), pod("web-3", "10.1.0.3"), pod("web-4", "10.1.0.4")})
+		expectTaps(t, session, 4, "10.1.0.2", "10.1.0.3", "10.1.0.4")
+	})
+
+	t.Run("Notifies the open sessions of pod changes", func(t *testing.T) {
+		srv := &server{sessions: make(map[*tapSession]struct{})}
+		session := &tapSession{podsChanged: make(chan struct{}, 1)}
+		srv.addSession(session)
+
+		// notifications that aren't handled yet are coalesced
+		srv.notifySessions()
+		srv.notifySessions()
+		select {
+		case <-session.podsChanged:
+		default:
+			t.Fatalf("Expected the session to be notified")
+		}
+		select {
+		case <-session.podsChanged:
+			t.Fatalf("Expected a single notification")
+		default:
+		}
+
+		srv.removeSession(session)
+		srv.notifySessions()
+		select {
+		case <-session.podsChanged:
+			t.Fatalf("Expected a removed session not to be notified")
+		default:
+		}
+	})
+}
+
+func TestTapStatusReporter(t *testing.T) {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "emojivoto"},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodRunning, PodIP: "10.1.0.1"},
+	}
+	events := make(chan *common.TapEvent, 10)
+	reporter := (&server{tapPort: 4190}).newTapStatusReporter(pod, events)
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	reporter.report(context.Background(), common.TapEvent_TapStatus_CONNECTED, nil)
+	reporter.report(context.Background(), common.TapEvent_TapStatus_RETRYING, unavailable)
+	reporter.report(context.Background(), common.TapEvent_TapStatus_RETRYING, unavailable)
+	reporter.report(context.Background(), common.TapEvent_TapStatus_CONNECTED, nil)
+	close(events)
+
+	expected := []*common.TapEvent_TapStatus{
+		{State: common.TapEvent_TapStatus_CONNECTED},
+		{State: common.TapEvent_TapStatus_RETRYING, Error: unavailable.Error()},
+		{State: common.TapEvent_TapStatus_CONNECTED},
+	}
+	actual := []*common.TapEvent{}
+	for event := range events {
+		actual = append(actual, event)
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected [%d] status events, got [%d]: %v", len(expected), len(actual), actual)
+	}
+	for i, event := range actual {
+		if !proto.Equal(event.GetTapStatus(), expected[i]) {
+			t.Fatalf("Expected status [%v], got [%v]", expected[i], event.GetTapStatus())
+		}
+		if addr.AddressToString(event.GetDestination()) != "10.1.0.1:4190" {
+			t.Fatalf("Expected the status of [10.1.0.1:4190], got [%s]", addr.AddressToString(event.GetDestination()))
+		}
+		if event.GetDestinationMeta().GetLabels()["pod"] != "web-1" {
+			t.Fatalf("Expected the status of pod [web-1], got [%v]", event.GetDestinationMeta().GetLabels())
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		expected bool
+	}{
+		{status.Error(codes.Unavailable, "connection refused"), true},
+		{status.Error(codes.Internal, "stream reset"), true},
+		{status.Error(codes.Unimplemented, "unknown service"), false},
+		{status.Error(codes.InvalidArgument, "invalid match"), false},
+	} {
+		if isRetryable(tt.err) != tt.expected {
+			t.Fatalf("Expected isRetryable(%v) to be [%t]", tt.err, tt.expected)
+		}
+	}
+}
+
+func TestWindowErr(t *testing.T) {
+	canceled := status.Error(codes.Canceled, "context canceled")
+
+	t.Run("Ignores errors caused by the end of the window", func(t *testing.T) {
+		window, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := windowErr(context.Background(), window, canceled); err != nil {
+			t.Fatalf("Expected no error, got [%v]", err)
+		}
+	})
+
+	t.Run("Returns errors caused by the end of the session", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		window, cancelWindow := context.WithCancel(ctx)
+		defer cancelWindow()
+		cancel()
+		if err := windowErr(ctx, window, canceled); err != canceled {
+			t.Fatalf("Expected [%v], got [%v]", canceled, err)
+		}
+	})
+
+	t.Run("Returns errors during the window", func(t *testing.T) {
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+		if err := windowErr(context.Background(), context.Background(), unavailable); err != unavailable {
+			t.Fatalf("Expected [%v], got [%v]", unavailable, err)
+		}
+	})
 }
@@ -1,7 +1,9 @@
 package addr
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -9,8 +11,10 @@ import (
 )
 
 func AddressToString(addr *pb.TcpAddress) string {
-	octects := decodeIPToOctets(addr.GetIp().GetIpv4())
-	return fmt.Sprintf("%d.%d.%d.%d:%d", octects[0], octects[1], octects[2], octects[3], addr.GetPort())
+	if addr.GetIp().GetIpv6() != nil {
+		return fmt.Sprintf("[%s]:%d", IPToString(addr.GetIp()), addr.GetPort())
+	}
+	return fmt.Sprintf("%s:%d", IPToString(addr.GetIp()), addr.GetPort())
 }
 
 func AddressesToString(addrs []pb.TcpAddress) string {
@@ -22,6 +26,9 @@ func AddressesToString(addrs []pb.TcpAddress) string {
 }
 
 func IPToString(ip *pb.IPAddress) string {
+	if ipv6 := ip.GetIpv6(); ipv6 != nil {
+		return decodeIPv6ToNetIP(ipv6).String()
+	}
 	octets := decodeIPToOctets(ip.GetIpv4())
 	return fmt.Sprintf("%d.%d.%d.%d", octets[0], octets[1], octets[2], octets[3])
 }
@@ -35,6 +42,28 @@ func IPV4(a1, a2, a3, a4 uint8) *pb.IPAddress {
 	}
 }
 
+// IPV6 builds an IPv6 address from its first and last four hextets, in the
+// same layout as the IPv6 protobuf message.
+func IPV6(first, last uint64) *pb.IPAddress {
+	return &pb.IPAddress{
+		Ip: &pb.IPAddress_Ipv6{
+			Ipv6: &pb.IPv6{
+				First: first,
+				Last:  last,
+			},
+		},
+	}
+}
+
+// ParseIP parses either an IPv4 address in dotted decimal form or an IPv6
+// address in any of the forms accepted by net.ParseIP.
+func ParseIP(ip string) (*pb.IPAddress, error) {
+	if strings.Contains(ip, ":") {
+		return ParseIPV6(ip)
+	}
+	return ParseIPV4(ip)
+}
+
 func ParseIPV4(ip string) (*pb.IPAddress, error) {
 	segments := strings.Split(ip, ".")
 	if len(segments) != 4 {
@@ -51,6 +80,15 @@ func ParseIPV4(ip string) (*pb.IPAddress, error) {
 	return IPV4(octets[0], octets[1], octets[2], octets[3]), nil
 }
 
+func ParseIPV6(ip string) (*pb.IPAddress, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return nil, fmt.Errorf("Invalid IPv6 address: %s", ip)
+	}
+	bytes := parsed.To16()
+	return IPV6(binary.BigEndian.Uint64(bytes[:8]), binary.BigEndian.Uint64(bytes[8:])), nil
+}
+
 func decodeIPToOctets(ip uint32) [4]uint8 {
 	return [4]uint8{
 		uint8(ip >> 24 & 255),
@@ -60,6 +98,13 @@ func decodeIPToOctets(ip uint32) [4]uint8 {
 	}
 }
 
+func decodeIPv6ToNetIP(ip *pb.IPv6) net.IP {
+	bytes := make(net.IP, net.IPv6len)
+	binary.BigEndian.PutUint64(bytes[:8], ip.GetFirst())
+	binary.BigEndian.PutUint64(bytes[8:], ip.GetLast())
+	return bytes
+}
+
 func DiffAddresses(oldAddrs []pb.TcpAddress, newAddrs []pb.TcpAddress) ([]pb.TcpAddress, []pb.TcpAddress) {
 	addSet := make(map[string]pb.TcpAddress)
 	removeSet := make(map[string]pb.TcpAddress)
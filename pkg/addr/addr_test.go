@@ -0,0 +1,112 @@
+package addr
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	pb "github.com/runconduit/conduit/controller/gen/common"
+)
+
+func TestParseIP(t *testing.T) {
+	t.Run("Parses IPv4 and IPv6 addresses and prints them back", func(t *testing.T) {
+		validIPs := map[string]string{
+			"172.17.0.12":       "172.17.0.12",
+			"0.0.0.0":           "0.0.0.0",
+			"fd00::1":           "fd00::1",
+			"::1":               "::1",
+			"2001:db8:0:0:0::7": "2001:db8::7",
+			"2001:0db8:85a3:0000:0000:8a2e:0370:7334": "2001:db8:85a3::8a2e:370:7334",
+		}
+
+		for ip, expected := range validIPs {
+			parsed, err := ParseIP(ip)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing [%s]: %v", ip, err)
+			}
+
+			actual := IPToString(parsed)
+			if actual != expected {
+				t.Fatalf("Expected [%s] to be printed as [%s], got [%s]", ip, expected, actual)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid addresses", func(t *testing.T) {
+		invalidIPs := []string{
+			"",
+			"172.17.0",
+			"172.17.0.256",
+			"fd00:::1",
+			"fd00::g",
+			"::ffff:1.2.3.4",
+		}
+
+		for _, ip := range invalidIPs {
+			parsed, err := ParseIP(ip)
+			if err == nil {
+				t.Fatalf("Expected error parsing [%s], got [%v]", ip, parsed)
+			}
+		}
+	})
+
+	t.Run("Stores IPv6 hextets in first and last halves", func(t *testing.T) {
+		parsed, err := ParseIPV6("1:2:3:4:5:6:7:8")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := IPV6(0x0001000200030004, 0x0005000600070008)
+		if !reflect.DeepEqual(parsed, expected) {
+			t.Fatalf("Expected [%v], got [%v]", expected, parsed)
+		}
+	})
+}
+
+func TestAddressToString(t *testing.T) {
+	t.Run("Formats IPv4 and IPv6 addresses with a port", func(t *testing.T) {
+		expectations := map[string]*pb.TcpAddress{
+			"1.2.3.4:8080":   &pb.TcpAddress{Ip: IPV4(1, 2, 3, 4), Port: 8080},
+			"[fd00::1]:8080": &pb.TcpAddress{Ip: IPV6(0xfd00000000000000, 1), Port: 8080},
+		}
+
+		for expected, address := range expectations {
+			actual := AddressToString(address)
+			if actual != expected {
+				t.Fatalf("Expected address to be printed as [%s], got [%s]", expected, actual)
+			}
+		}
+	})
+}
+
+func TestDiffAddresses(t *testing.T) {
+	t.Run("Diffs mixed IPv4 and IPv6 address sets", func(t *testing.T) {
+		v4 := pb.TcpAddress{Ip: IPV4(10, 0, 0, 1), Port: 80}
+		v6a := pb.TcpAddress{Ip: IPV6(0xfd00000000000000, 1), Port: 80}
+		v6b := pb.TcpAddress{Ip: IPV6(0xfd00000000000000, 2), Port: 80}
+
+		add, remove := DiffAddresses(
+			[]pb.TcpAddress{v4, v6a},
+			[]pb.TcpAddress{v6a, v6b},
+		)
+
+		expectedAdd := []string{"[fd00::2]:80"}
+		if actualAdd := addressStrings(add); !reflect.DeepEqual(actualAdd, expectedAdd) {
+			t.Fatalf("Expected added addresses %v, got %v", expectedAdd, actualAdd)
+		}
+
+		expectedRemove := []string{"10.0.0.1:80"}
+		if actualRemove := addressStrings(remove); !reflect.DeepEqual(actualRemove, expectedRemove) {
+			t.Fatalf("Expected removed addresses %v, got %v", expectedRemove, actualRemove)
+		}
+	})
+}
+
+func addressStrings(addrs []pb.TcpAddress) []string {
+	strs := make([]string, len(addrs))
+	for i := range addrs {
+		strs[i] = AddressToString(&addrs[i])
+	}
+	sort.Strings(strs)
+	return strs
+}
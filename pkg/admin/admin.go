@@ -11,15 +11,25 @@ import (
 
 type handler struct {
 	promHandler http.Handler
-	ready       bool
+	// additional handlers, keyed by path
+	handlers map[string]http.Handler
+	ready    bool
 	sync.RWMutex
 }
 
 func StartServer(addr string, readyCh <-chan struct{}) {
+	StartServerWithHandlers(addr, readyCh, nil)
+}
+
+// StartServerWithHandlers starts an admin server that also serves each of
+// handlers at the path it is keyed by, e.g. to expose debugging information
+// that is specific to a component.
+func StartServerWithHandlers(addr string, readyCh <-chan struct{}, handlers map[string]http.Handler) {
 	log.Infof("starting admin server on %s", addr)
 
 	h := &handler{
 		promHandler: promhttp.Handler(),
+		handlers:    handlers,
 		ready:       readyCh == nil,
 	}
 
@@ -49,6 +59,10 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case "/ready":
 		h.serveReady(w, req)
 	default:
+		if handler, ok := h.handlers[req.URL.Path]; ok {
+			handler.ServeHTTP(w, req)
+			return
+		}
 		http.NotFound(w, req)
 	}
 }
@@ -63,6 +63,10 @@ const (
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = "conduit.io/proxy-version"
 
+	// TrafficSplitAnnotation identifies a ConfigMap that splits the traffic of
+	// the service named by its value between the services listed in its data.
+	TrafficSplitAnnotation = "conduit.io/traffic-split"
+
 	/*
 	 * Component Names
 	 */
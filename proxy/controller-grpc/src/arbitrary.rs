@@ -119,16 +119,26 @@ impl Arbitrary for observe_request::match_::http::Match {
     fn arbitrary<G: Gen>(g: &mut G) -> Self {
         use self::observe_request::match_::http;
 
-        match g.gen::<u32>() % 4 {
+        match g.gen::<u32>() % 5 {
             0 => http::Match::Scheme(Scheme::arbitrary(g)),
             1 => http::Match::Method(HttpMethod::arbitrary(g)),
             2 => http::Match::Authority(http::StringMatch::arbitrary(g)),
             3 => http::Match::Path(http::StringMatch::arbitrary(g)),
+            4 => http::Match::Header(http::Header::arbitrary(g)),
             _ => unreachable!(),
         }
     }
 }
 
+impl Arbitrary for observe_request::match_::http::Header {
+    fn arbitrary<G: Gen>(g: &mut G) -> Self {
+        observe_request::match_::http::Header {
+            name: Arbitrary::arbitrary(g),
+            value: Arbitrary::arbitrary(g),
+        }
+    }
+}
+
 impl Arbitrary for observe_request::match_::http::StringMatch {
     fn arbitrary<G: Gen>(g: &mut G) -> Self {
         observe_request::match_::http::StringMatch {
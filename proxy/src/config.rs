@@ -80,6 +80,9 @@ pub struct Config {
 
     pub namespaces: Namespaces,
 
+    /// The name of the node that the pod runs on, if it is known.
+    pub node_name: Option<String>,
+
     /// Optional minimum TTL for DNS lookups.
     pub dns_min_ttl: Option<Duration>,
 
@@ -193,6 +196,7 @@ pub const ENV_TLS_CONTROLLER_IDENTITY: &str = "CONDUIT_PROXY_TLS_CONTROLLER_IDEN
 pub const ENV_CONTROLLER_NAMESPACE: &str = "CONDUIT_PROXY_CONTROLLER_NAMESPACE";
 pub const ENV_POD_NAMESPACE: &str = "CONDUIT_PROXY_POD_NAMESPACE";
 pub const VAR_POD_NAMESPACE: &str = "$CONDUIT_PROXY_POD_NAMESPACE";
+pub const ENV_NODE_NAME: &str = "CONDUIT_PROXY_NODE_NAME";
 
 pub const ENV_CONTROL_URL: &str = "CONDUIT_PROXY_CONTROL_URL";
 const ENV_RESOLV_CONF: &str = "CONDUIT_RESOLV_CONF";
@@ -289,6 +293,7 @@ impl<'a> TryFrom<&'a Strings> for Config {
             })
         });
         let controller_namespace = strings.get(ENV_CONTROLLER_NAMESPACE);
+        let node_name = strings.get(ENV_NODE_NAME);
 
         // There is no default controller URL because a default would make it
         // too easy to connect to the wrong controller, which would be dangerous.
@@ -417,6 +422,8 @@ impl<'a> TryFrom<&'a Strings> for Config {
 
             namespaces,
 
+            node_name: node_name?,
+
             dns_min_ttl: dns_min_ttl?,
 
             dns_max_ttl: dns_max_ttl?,
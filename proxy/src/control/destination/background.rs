@@ -70,6 +70,8 @@ type ClientService = AddOrigin<Backoff<LogErrors<Reconnect<
 struct Background<T: HttpService<ResponseBody = RecvBody>> {
     dns_resolver: dns::Resolver,
     namespaces: Namespaces,
+    /// The name of the node that the pod runs on, or empty if it isn't known.
+    node_name: String,
     destinations: HashMap<DnsNameAndPort, DestinationSet<T>>,
     /// A queue of authorities that need to be reconnected.
     reconnects: VecDeque<DnsNameAndPort>,
@@ -101,6 +103,7 @@ pub(super) fn task(
     request_rx: mpsc::UnboundedReceiver<ResolveRequest>,
     dns_resolver: dns::Resolver,
     namespaces: Namespaces,
+    node_name: Option<String>,
     host_and_port: Option<HostAndPort>,
     controller_tls: tls::ConditionalConnectionConfig<tls::ClientConfigWatch>
 ) -> impl Future<Item = (), Error = ()>
@@ -131,6 +134,7 @@ pub(super) fn task(
         request_rx,
         dns_resolver,
         namespaces,
+        node_name.unwrap_or_default(),
     );
 
     future::poll_fn(move || {
@@ -151,10 +155,12 @@ where
         request_rx: mpsc::UnboundedReceiver<ResolveRequest>,
         dns_resolver: dns::Resolver,
         namespaces: Namespaces,
+        node_name: String,
     ) -> Self {
         Self {
             dns_resolver,
             namespaces,
+            node_name,
             destinations: HashMap::new(),
             reconnects: VecDeque::new(),
             rpc_ready: false,
@@ -224,9 +230,11 @@ where
                         },
                         Entry::Vacant(vac) => {
                             let pod_namespace = &self.namespaces.pod;
+                            let node_name = &self.node_name;
                             let query = client.as_mut().and_then(|client| {
                                 Self::query_destination_service_if_relevant(
                                     pod_namespace,
+                                    node_name,
                                     client,
                                     vac.key(),
                                     "connect",
@@ -271,6 +279,7 @@ where
             if let Some(set) = self.destinations.get_mut(&auth) {
                 set.query = Self::query_destination_service_if_relevant(
                     &self.namespaces.pod,
+                    &self.node_name,
                     client,
                     &auth,
                     "reconnect",
@@ -342,6 +351,7 @@ where
     /// query the Destination service. Otherwise, returns `None`.
     fn query_destination_service_if_relevant(
         default_destination_namespace: &str,
+        node_name: &str,
         client: &mut T,
         auth: &DnsNameAndPort,
         connect_or_reconnect: &str,
@@ -355,6 +365,8 @@ where
             let req = Destination {
                 scheme: "k8s".into(),
                 path: auth.without_trailing_dot().to_owned(),
+                caller_namespace: default_destination_namespace.to_owned(),
+                caller_node: node_name.to_owned(),
             };
             let mut svc = DestinationSvc::new(client.lift_ref());
             let response = svc.get(grpc::Request::new(req));
@@ -143,6 +143,7 @@ pub trait Bind {
 pub fn new(
     dns_resolver: dns::Resolver,
     namespaces: Namespaces,
+    node_name: Option<String>,
     host_and_port: Option<HostAndPort>,
     controller_tls: tls::ConditionalConnectionConfig<tls::ClientConfigWatch>,
 ) -> (Resolver, impl Future<Item = (), Error = ()>) {
@@ -152,6 +153,7 @@ pub fn new(
         rx,
         dns_resolver,
         namespaces,
+        node_name,
         host_and_port,
         controller_tls,
     );
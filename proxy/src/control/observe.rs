@@ -4,10 +4,11 @@ use std::sync::atomic::{AtomicUsize, Ordering};
 use futures::{future, Poll, Stream};
 use futures_mpsc_lossy;
 use http::HeaderMap;
+use http::header::HeaderName;
 use indexmap::{Equivalent, IndexSet};
 use tower_grpc::{self as grpc, Response};
 
-use conduit_proxy_controller_grpc::common::TapEvent;
+use conduit_proxy_controller_grpc::common::{tap_event, TapEvent};
 use conduit_proxy_controller_grpc::tap::{server, ObserveRequest};
 use convert::*;
 use ctx;
@@ -28,6 +29,9 @@ pub struct TapEvents {
     current: IndexSet<RequestById>,
     tap_id: usize,
     taps: Arc<Mutex<Taps>>,
+    /// The names of the request headers reported to this tap, which are those
+    /// that it matches on.
+    header_names: Vec<HeaderName>,
 }
 
 // `IndexSet<RequestById>` is equivalent to `IndexMap<RequestId, Request>` but
@@ -73,6 +77,8 @@ impl server::Tap for Observe {
             }
         };
 
+        let header_names = tap.header_names();
+
         let tap_id = match self.taps.lock() {
             Ok(mut taps) => {
                 let tap_id = self.next_id.fetch_add(1, Ordering::AcqRel);
@@ -93,6 +99,7 @@ impl server::Tap for Observe {
             current: IndexSet::default(),
             remaining: req.limit as usize,
             taps: self.taps.clone(),
+            header_names,
         };
 
         future::ok(Response::new(events))
@@ -141,7 +148,10 @@ impl Stream for TapEvents {
                         _ => continue,
                     }
 
-                    if let Ok(te) = TapEvent::try_from(&ev) {
+                    if let Ok(mut te) = TapEvent::try_from(&ev) {
+                        if let Event::StreamRequestOpen(ref req) = ev {
+                            self.add_headers(&mut te, req);
+                        }
                         // TODO Do limit checks here.
                         return Ok(Some(te).into());
                     }
@@ -154,6 +164,34 @@ impl Stream for TapEvents {
     }
 }
 
+impl TapEvents {
+    /// Adds the values of the headers reported to this tap to a `RequestInit`
+    /// event. Values that aren't visible ASCII are left out.
+    fn add_headers(&self, te: &mut TapEvent, req: &ctx::http::Request) {
+        if self.header_names.is_empty() {
+            return;
+        }
+
+        let init = match te.event {
+            Some(tap_event::Event::Http(tap_event::Http {
+                event: Some(tap_event::http::Event::RequestInit(ref mut init)),
+            })) => init,
+            _ => return,
+        };
+
+        for name in &self.header_names {
+            for value in req.headers.get_all(name).iter() {
+                if let Ok(value) = value.to_str() {
+                    init.headers.push(tap_event::http::Header {
+                        name: name.as_str().into(),
+                        value: value.into(),
+                    });
+                }
+            }
+        }
+    }
+}
+
 impl Drop for TapEvents {
     fn drop(&mut self) {
         if let Ok(mut taps) = self.taps.lock() {
@@ -135,6 +135,9 @@ impl<'a> TryFrom<&'a Event> for common::TapEvent {
                         .unwrap_or_default()
                         .into(),
                     path: ctx.uri.path().into(),
+                    // Headers are only reported to the taps that match on
+                    // them, so they're filled in by `control::observe`.
+                    headers: Vec::new(),
                 };
 
                 common::TapEvent {
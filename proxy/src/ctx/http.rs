@@ -25,6 +25,9 @@ pub struct Request {
     pub uri: http::Uri,
     pub method: http::Method,
 
+    /// The request headers that taps match on; other headers aren't kept.
+    pub headers: http::HeaderMap,
+
     /// Identifies the proxy server that received the request.
     pub server: Arc<ctx::transport::Server>,
 
@@ -63,6 +66,7 @@ impl Into<u64> for RequestId {
 impl Request {
     pub fn new<B>(
         request: &http::Request<B>,
+        headers: http::HeaderMap,
         server: &Arc<ctx::transport::Server>,
         client: &Arc<ctx::transport::Client>,
     ) -> Arc<Self> {
@@ -70,6 +74,7 @@ impl Request {
             id: RequestId::next(),
             uri: request.uri().clone(),
             method: request.method().clone(),
+            headers,
             server: Arc::clone(server),
             client: Arc::clone(client),
         };
@@ -128,6 +128,7 @@ pub mod test_util {
     ) -> (Arc<ctx::http::Request>, Arc<ctx::http::Response>) {
         let req = ctx::http::Request::new(
             &http::Request::get(uri).body(()).unwrap(),
+            http::HeaderMap::new(),
             &server,
             &client,
         );
@@ -253,6 +253,7 @@ where
         let (resolver, resolver_bg) = control::destination::new(
             dns_resolver.clone(),
             config.namespaces.clone(),
+            config.node_name.clone(),
             control_host_and_port,
             controller_tls,
         );
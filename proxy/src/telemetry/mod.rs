@@ -35,7 +35,10 @@ pub fn new(
     taps: &Arc<Mutex<tap::Taps>>,
 ) -> (Sensors, Control) {
     let (tx, rx) = futures_mpsc_lossy::channel(capacity);
-    let s = Sensors::new(tx);
+    let tapped_headers = taps.lock()
+        .map(|taps| taps.tapped_headers())
+        .unwrap_or_default();
+    let s = Sensors::new(tx, tapped_headers);
     let c = Control::new(rx, process, metrics_retain_idle, taps);
     (s, c)
 }
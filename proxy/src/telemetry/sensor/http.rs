@@ -11,6 +11,7 @@ use tower_h2::{client, Body};
 
 use ctx;
 use telemetry::event::{self, Event};
+use telemetry::tap::TappedHeaders;
 
 const GRPC_STATUS: &str = "grpc-status";
 
@@ -36,6 +37,7 @@ pub struct TimestampRequestOpen<S> {
 pub struct NewHttp<N, A, B> {
     new_service: N,
     handle: super::Handle,
+    tapped_headers: TappedHeaders,
     client_ctx: Arc<ctx::transport::Client>,
     _p: PhantomData<(A, B)>,
 }
@@ -43,6 +45,7 @@ pub struct NewHttp<N, A, B> {
 pub struct Init<F, A, B> {
     future: F,
     handle: super::Handle,
+    tapped_headers: TappedHeaders,
     client_ctx: Arc<ctx::transport::Client>,
     _p: PhantomData<(A, B)>,
 }
@@ -52,6 +55,7 @@ pub struct Init<F, A, B> {
 pub struct Http<S, A, B> {
     service: S,
     handle: super::Handle,
+    tapped_headers: TappedHeaders,
     client_ctx: Arc<ctx::transport::Client>,
     _p: PhantomData<(A, B)>,
 }
@@ -125,11 +129,13 @@ where
     pub(super) fn new(
         new_service: N,
         handle: &super::Handle,
+        tapped_headers: &TappedHeaders,
         client_ctx: &Arc<ctx::transport::Client>,
     ) -> Self {
         Self {
             new_service,
             handle: handle.clone(),
+            tapped_headers: tapped_headers.clone(),
             client_ctx: Arc::clone(client_ctx),
             _p: PhantomData,
         }
@@ -158,6 +164,7 @@ where
         Init {
             future: self.new_service.new_service(),
             handle: self.handle.clone(),
+            tapped_headers: self.tapped_headers.clone(),
             client_ctx: Arc::clone(&self.client_ctx),
             _p: PhantomData,
         }
@@ -185,6 +192,7 @@ where
         Ok(Async::Ready(Http {
             service,
             handle: self.handle.clone(),
+            tapped_headers: self.tapped_headers.clone(),
             client_ctx: self.client_ctx.clone(),
             _p: PhantomData,
         }))
@@ -220,7 +228,8 @@ where
         );
         let (inner, body_inner) = match metadata {
             (Some(ctx), Some(RequestOpen(request_open_at))) => {
-                let ctx = ctx::http::Request::new(&req, &ctx, &self.client_ctx);
+                let headers = self.tapped_headers.capture(req.headers());
+                let ctx = ctx::http::Request::new(&req, headers, &ctx, &self.client_ctx);
 
                 self.handle
                     .send(|| Event::StreamRequestOpen(Arc::clone(&ctx)));
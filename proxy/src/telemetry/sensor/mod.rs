@@ -10,6 +10,7 @@ use tower_h2::{client, Body};
 
 use ctx;
 use telemetry::event;
+use telemetry::tap::TappedHeaders;
 use transport::tls;
 
 pub mod http;
@@ -24,7 +25,7 @@ struct Handle(Option<Sender<event::Event>>);
 
 /// Supports the creation of telemetry scopes.
 #[derive(Clone, Debug)]
-pub struct Sensors(Handle);
+pub struct Sensors(Handle, TappedHeaders);
 
 /// Given to the TLS config watch to generate events on reloads.
 #[derive(Clone, Debug)]
@@ -50,12 +51,12 @@ impl Handle {
 }
 
 impl Sensors {
-    pub(super) fn new(h: Sender<event::Event>) -> Self {
-        Sensors(Handle(Some(h)))
+    pub(super) fn new(h: Sender<event::Event>, tapped_headers: TappedHeaders) -> Self {
+        Sensors(Handle(Some(h)), tapped_headers)
     }
 
     pub fn null() -> Sensors {
-        Sensors(Handle(None))
+        Sensors(Handle(None), TappedHeaders::default())
     }
 
     pub fn accept<T>(
@@ -94,7 +95,7 @@ impl Sensors {
         >
             + 'static,
     {
-        NewHttp::new(new_service, &self.0, client_ctx)
+        NewHttp::new(new_service, &self.0, &self.1, client_ctx)
     }
 
     pub fn tls_config(&self) -> TlsConfig {
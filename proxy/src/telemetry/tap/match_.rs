@@ -30,6 +30,7 @@ pub enum InvalidMatch {
     InvalidNetwork,
     InvalidHttpMethod,
     InvalidScheme,
+    InvalidHeader,
     Unimplemented,
 }
 
@@ -58,6 +59,7 @@ pub(super) enum HttpMatch {
     Method(http::Method),
     Path(observe_request::match_::http::string_match::Match),
     Authority(observe_request::match_::http::string_match::Match),
+    Header(http::header::HeaderName, observe_request::match_::http::string_match::Match),
 }
 
 // ===== impl Match ======
@@ -139,6 +141,27 @@ impl Match {
         }
     }
 
+    /// Adds the names of all of the headers matched on to `names`.
+    pub(super) fn header_names(&self, names: &mut Vec<http::header::HeaderName>) {
+        match *self {
+            Match::Any(ref ms) | Match::All(ref ms) => {
+                for m in ms {
+                    m.header_names(names);
+                }
+            }
+
+            Match::Not(ref not) => not.header_names(names),
+
+            Match::Http(HttpMatch::Header(ref name, _)) => {
+                if !names.contains(name) {
+                    names.push(name.clone());
+                }
+            }
+
+            _ => {}
+        }
+    }
+
     pub(super) fn new(match_: &observe_request::Match) -> Result<Match, InvalidMatch> {
         match_
             .match_
@@ -329,6 +352,13 @@ impl HttpMatch {
                 .unwrap_or(false),
 
             HttpMatch::Path(ref m) => Self::matches_string(m, req.uri.path()),
+
+            // A header matches if any of its values do; values that aren't
+            // visible ASCII never match.
+            HttpMatch::Header(ref name, ref m) => req.headers
+                .get_all(name)
+                .iter()
+                .any(|v| v.to_str().map(|v| Self::matches_string(m, v)).unwrap_or(false)),
         }
     }
 
@@ -381,6 +411,19 @@ impl<'a> TryFrom<&'a observe_request::match_::Http> for HttpMatch {
                     .as_ref()
                     .ok_or_else(|| InvalidMatch::Empty)
                     .map(|p| HttpMatch::Path(p.clone())),
+
+                Pb::Header(ref h) => {
+                    if h.name.is_empty() {
+                        return Err(InvalidMatch::Empty);
+                    }
+                    let name = http::header::HeaderName::from_bytes(h.name.as_bytes())
+                        .map_err(|_| InvalidMatch::InvalidHeader)?;
+                    h.value
+                        .as_ref()
+                        .and_then(|v| v.match_.as_ref())
+                        .ok_or_else(|| InvalidMatch::Empty)
+                        .map(|v| HttpMatch::Header(name, v.clone()))
+                }
             })
     }
 }
@@ -532,6 +575,18 @@ mod tests {
                         Some(_) => None,
                     }
                 }
+                Some(&http::Match::Header(ref h)) => {
+                    if h.name.is_empty() {
+                        Some(InvalidMatch::Empty)
+                    } else if ::http::header::HeaderName::from_bytes(h.name.as_bytes()).is_err() {
+                        Some(InvalidMatch::InvalidHeader)
+                    } else {
+                        match h.value.as_ref().and_then(|v| v.match_.as_ref()) {
+                            None => Some(InvalidMatch::Empty),
+                            Some(_) => None,
+                        }
+                    }
+                }
             };
 
             err == HttpMatch::try_from(&http).err()
@@ -1,5 +1,8 @@
 use futures_mpsc_lossy;
+use http;
+use http::header::HeaderName;
 use indexmap::IndexMap;
+use std::sync::{Arc, RwLock};
 
 use conduit_proxy_controller_grpc::tap::observe_request;
 
@@ -13,8 +16,16 @@ pub use self::match_::InvalidMatch;
 #[derive(Default, Debug)]
 pub struct Taps {
     by_id: IndexMap<usize, Tap>,
+    tapped_headers: TappedHeaders,
 }
 
+/// The names of the request headers that registered taps match on.
+///
+/// It's shared with the sensors, so that requests only keep the headers
+/// that a tap may look at, and none at all while no tap matches on headers.
+#[derive(Clone, Debug, Default)]
+pub struct TappedHeaders(Arc<RwLock<Vec<HeaderName>>>);
+
 #[derive(Debug)]
 pub struct Tap {
     match_: Match,
@@ -27,12 +38,30 @@ struct Ended;
 impl Taps {
     pub fn insert(&mut self, id: usize, tap: Tap) -> Option<Tap> {
         debug!("insert id={} tap={:?}", id, tap);
-        self.by_id.insert(id, tap)
+        let prior = self.by_id.insert(id, tap);
+        self.update_tapped_headers();
+        prior
     }
 
     pub fn remove(&mut self, id: usize) -> Option<Tap> {
         debug!("remove id={}", id);
-        self.by_id.swap_remove(&id)
+        let tap = self.by_id.swap_remove(&id);
+        self.update_tapped_headers();
+        tap
+    }
+
+    pub fn tapped_headers(&self) -> TappedHeaders {
+        self.tapped_headers.clone()
+    }
+
+    fn update_tapped_headers(&self) {
+        let mut names = Vec::new();
+        for tap in self.by_id.values() {
+            tap.match_.header_names(&mut names);
+        }
+        if let Ok(mut tapped) = (self.tapped_headers.0).write() {
+            *tapped = names;
+        }
     }
 
     ///
@@ -61,6 +90,7 @@ impl Taps {
                 Err(Ended) => {
                     debug!("ended tap={}", tap_id);
                     self.by_id.swap_remove_index(idx);
+                    self.update_tapped_headers();
                     continue;
                 }
             }
@@ -70,6 +100,21 @@ impl Taps {
     }
 }
 
+impl TappedHeaders {
+    /// Copies the values of the tapped headers out of `headers`.
+    pub fn capture(&self, headers: &http::HeaderMap) -> http::HeaderMap {
+        let mut captured = http::HeaderMap::new();
+        if let Ok(names) = self.0.read() {
+            for name in names.iter() {
+                for value in headers.get_all(name).iter() {
+                    captured.append(name.clone(), value.clone());
+                }
+            }
+        }
+        captured
+    }
+}
+
 impl Tap {
     pub fn new(
         match_: &observe_request::Match,
@@ -84,6 +129,14 @@ impl Tap {
         Ok((tap, rx))
     }
 
+    /// Returns the names of the headers that the tap matches on, which are
+    /// the only headers reported in its events.
+    pub fn header_names(&self) -> Vec<HeaderName> {
+        let mut names = Vec::new();
+        self.match_.header_names(&mut names);
+        names
+    }
+
     fn inspect(&self, ev: &Event) -> Result<bool, Ended> {
         if self.match_.matches(ev) {
             return self.tx
@@ -45,6 +45,9 @@ impl Controller {
         let dst = common::Destination {
             scheme: "k8s".into(),
             path: dest.into(),
+            // the namespace that `support::proxy` runs the proxy in
+            caller_namespace: "test".into(),
+            caller_node: "".into(),
         };
         self.expect_dst_calls
             .lock()
@@ -107,6 +110,13 @@ impl pb::server::Destination for Controller {
 
         future::err(grpc::Error::Grpc(grpc::Status::INTERNAL, HeaderMap::new()))
     }
+
+    type GetProfileStream = stream::Empty<pb::DestinationProfile, grpc::Error>;
+    type GetProfileFuture = future::FutureResult<grpc::Response<Self::GetProfileStream>, grpc::Error>;
+
+    fn get_profile(&mut self, _req: grpc::Request<Destination>) -> Self::GetProfileFuture {
+        future::ok(grpc::Response::new(stream::empty()))
+    }
 }
 
 fn run(controller: Controller) -> Listening {